@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/configcheck"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	configCmdName         = "config"
+	configValidateCmdName = "validate"
+	configRenderCmdName   = "render"
+	configSchemaCmdName   = "schema"
+)
+
+var errInvalidConfig = errors.New("configuration is invalid")
+
+// runConfigCmd runs the `botkube config` subcommands, which can be used e.g. in CI pipelines:
+//
+//	botkube config validate -c values.yaml -c _overrides.yaml [--plugin-index botkube=index.yaml]
+//	botkube config render -c values.yaml -c _overrides.yaml [--profile prod]
+//	botkube config schema [-c values.yaml --plugin-index botkube=index.yaml]
+//
+// The `valueFrom` references are validated, but not resolved, so Secrets, files and environment variables are not read.
+// Issues are printed to errOut, so the rendered configuration can be redirected to a file.
+func runConfigCmd(ctx context.Context, args []string, out, errOut io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("subcommand is required, allowed values are %q, %q and %q", configValidateCmdName, configRenderCmdName, configSchemaCmdName)
+	}
+	subCmd := args[0]
+	if subCmd != configValidateCmdName && subCmd != configRenderCmdName && subCmd != configSchemaCmdName {
+		return fmt.Errorf("unknown %q subcommand, allowed values are %q, %q and %q", subCmd, configValidateCmdName, configRenderCmdName, configSchemaCmdName)
+	}
+
+	var opts configcheck.Options
+	flags := pflag.NewFlagSet(fmt.Sprintf("%s %s", configCmdName, subCmd), pflag.ContinueOnError)
+	flags.SetOutput(errOut)
+	flags.StringSliceVarP(&opts.Paths, "config", "c", nil, "Specify configuration file in YAML format (can specify multiple).")
+	flags.StringToStringVar(&opts.PluginIndexes, "plugin-index", nil, "Local plugin repository index in the {repo_name}={path} format used to validate plugin configurations (can specify multiple).")
+	config.RegisterProfileFlag(flags)
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if subCmd == configSchemaCmdName {
+		return printConfigSchema(ctx, opts, out, errOut)
+	}
+	if len(opts.Paths) == 0 {
+		return errors.New("at least one configuration file is required")
+	}
+
+	result, err := configcheck.Run(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range result.Warnings {
+		fmt.Fprintf(errOut, "WARNING: %s\n", issue)
+	}
+	for _, issue := range result.Errors {
+		fmt.Fprintf(errOut, "ERROR: %s\n", issue)
+	}
+	if !result.IsValid() {
+		return errInvalidConfig
+	}
+
+	if subCmd == configValidateCmdName {
+		fmt.Fprintln(out, "Configuration is valid.")
+		return nil
+	}
+
+	rendered, err := configcheck.Render(*result.Config)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(rendered)
+	return err
+}
+
+// printConfigSchema prints the JSON schema of the Botkube configuration. If the configuration files and plugin indexes are specified,
+// the JSON schemas of enabled plugins are merged into the printed schema.
+func printConfigSchema(ctx context.Context, opts configcheck.Options, out, errOut io.Writer) error {
+	result, err := configcheck.Schema(ctx, opts)
+	if err != nil {
+		return err
+	}
+	for _, issue := range result.Warnings {
+		fmt.Fprintf(errOut, "WARNING: %s\n", issue)
+	}
+
+	schema, err := configcheck.MarshalSchema(result.Schema)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(schema)
+	return err
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-github/v44/github"
@@ -16,6 +18,7 @@ import (
 	"github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -28,6 +31,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/internal/config/crd"
 	"github.com/kubeshop/botkube/internal/graphql"
 	"github.com/kubeshop/botkube/internal/lifecycle"
 	"github.com/kubeshop/botkube/internal/loggerx"
@@ -36,6 +41,7 @@ import (
 	"github.com/kubeshop/botkube/internal/status"
 	"github.com/kubeshop/botkube/internal/storage"
 	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/audit"
 	"github.com/kubeshop/botkube/pkg/bot"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
@@ -46,7 +52,6 @@ import (
 	"github.com/kubeshop/botkube/pkg/httpsrv"
 	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/recommendation"
-	"github.com/kubeshop/botkube/pkg/sink"
 	"github.com/kubeshop/botkube/pkg/version"
 )
 
@@ -65,6 +70,13 @@ func main() {
 	ctx, cancelCtxFn := context.WithCancel(ctx)
 	defer cancelCtxFn()
 
+	if len(os.Args) > 1 && os.Args[1] == configCmdName {
+		if err := runConfigCmd(ctx, os.Args[2:], os.Stdout, os.Stderr); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if err := run(ctx); err != nil {
 		log.Fatal(err)
 	}
@@ -74,9 +86,16 @@ func main() {
 func run(ctx context.Context) error {
 	// Load configuration
 	config.RegisterFlags(pflag.CommandLine)
+	if err := config.ParseProfileFlag(os.Args[1:]); err != nil {
+		return err
+	}
 	gqlClient := graphql.NewDefaultGqlClient()
 	cfgProvider := config.GetProvider(gqlClient)
 	configs, err := cfgProvider.Configs(ctx)
+	var initialConfigRevision string
+	if revProvider, ok := cfgProvider.(intconfig.RevisionProvider); ok {
+		initialConfigRevision = revProvider.Revision()
+	}
 	if err != nil {
 		return fmt.Errorf("while loading configuration files: %w", err)
 	}
@@ -87,8 +106,44 @@ func run(ctx context.Context) error {
 	}
 
 	logger := loggerx.New(conf.Settings.Log)
+	if initialConfigRevision != "" {
+		logger.Infof("Loaded configuration revision %q", initialConfigRevision)
+	}
+
+	// The applied configuration revision is reported by the Reloader, which is created once bots are started
+	var hotReloader atomic.Pointer[lifecycle.Reloader]
+	configRevision := func() string {
+		if r := hotReloader.Load(); r != nil {
+			return r.Revision()
+		}
+		return initialConfigRevision
+	}
 	statusReporter := status.NewStatusReporter(logger, gqlClient)
 
+	// Merge configuration defined by custom resources
+	var crdProvider *crd.Provider
+	if conf.Settings.ConfigCRDs.Enabled {
+		crdKubeConfig, err := clientcmd.BuildConfigFromFlags("", conf.Settings.Kubeconfig)
+		if err != nil {
+			return fmt.Errorf("while loading k8s config: %w", err)
+		}
+		crdDynamicCli, _, crdMapper, err := getK8sClients(crdKubeConfig)
+		if err != nil {
+			return fmt.Errorf("while creating K8s clients: %w", err)
+		}
+		crdProvider = crd.NewProvider(logger.WithField(componentLogFieldKey, "Config CRDs"), cfgProvider, crdDynamicCli, crdMapper, conf.Settings.ConfigCRDs)
+		cfgProvider = crdProvider
+
+		configs, err = cfgProvider.Configs(ctx)
+		if err != nil {
+			return fmt.Errorf("while loading configuration custom resources: %w", err)
+		}
+		conf, confDetails, err = config.LoadWithDefaults(configs)
+		if err != nil {
+			return fmt.Errorf("while merging app configuration with custom resources: %w", err)
+		}
+	}
+
 	if confDetails.ValidateWarnings != nil {
 		logger.Warnf("Configuration validation warnings: %v", confDetails.ValidateWarnings.Error())
 	}
@@ -162,17 +217,24 @@ func run(ctx context.Context) error {
 	// Kubectl config merger
 	kcMerger := kubectl.NewMerger(conf.Executors)
 
-	// Load resource variants name if needed
+	// Load resource variants name if needed. It's also called on config reload, as kubectl may be enabled later.
 	var resourceNameNormalizerFunc kubectl.ResourceVariantsFunc
-	if kcMerger.IsAtLeastOneEnabled() {
+	ensureResourceNameNormalizer := func() error {
+		if resourceNameNormalizerFunc != nil || !kcMerger.IsAtLeastOneEnabled() {
+			return nil
+		}
 		resourceNameNormalizer, err := kubectl.NewResourceNormalizer(
 			logger.WithField(componentLogFieldKey, "Resource Name Normalizer"),
 			discoveryCli,
 		)
 		if err != nil {
-			return reportFatalError("while creating resource name normalizer", err)
+			return err
 		}
 		resourceNameNormalizerFunc = resourceNameNormalizer.Normalize
+		return nil
+	}
+	if err := ensureResourceNameNormalizer(); err != nil {
+		return reportFatalError("while creating resource name normalizer", err)
 	}
 
 	cmdGuard := kubectl.NewCommandGuard(logger.WithField(componentLogFieldKey, "Command Guard"), discoveryCli)
@@ -185,34 +247,45 @@ func run(ctx context.Context) error {
 	}
 	botkubeVersion := findBotkubeVersion(k8sVersion)
 
+	// Sinks which opt in to command execution audit records are registered below, when they are created
+	auditEmitter := audit.NewEmitter(logger.WithField(componentLogFieldKey, "Audit emitter"))
+	errGroup.Go(func() error {
+		return auditEmitter.Start(ctx)
+	})
+
 	// Create executor factory
 	cfgManager := config.NewManager(logger.WithField(componentLogFieldKey, "Config manager"), conf.Settings.PersistentConfig, k8sCli)
-	executorFactory, err := execute.NewExecutorFactory(
-		execute.DefaultExecutorFactoryParams{
-			Log:               logger.WithField(componentLogFieldKey, "Executor"),
-			CmdRunner:         runner,
-			Cfg:               *conf,
-			FilterEngine:      filterEngine,
-			KcChecker:         kubectl.NewChecker(resourceNameNormalizerFunc),
-			Merger:            kcMerger,
-			CfgManager:        cfgManager,
-			AnalyticsReporter: reporter,
-			NamespaceLister:   k8sCli.CoreV1().Namespaces(),
-			CommandGuard:      cmdGuard,
-			PluginManager:     pluginManager,
-			BotKubeVersion:    botkubeVersion,
-		},
-	)
-
+	newExecutorFactory := func(cfg *config.Config, filterEngine filterengine.FilterEngine) (*execute.DefaultExecutorFactory, error) {
+		return execute.NewExecutorFactory(
+			execute.DefaultExecutorFactoryParams{
+				Log:               logger.WithField(componentLogFieldKey, "Executor"),
+				CmdRunner:         runner,
+				Cfg:               *cfg,
+				FilterEngine:      filterEngine,
+				KcChecker:         kubectl.NewChecker(resourceNameNormalizerFunc),
+				Merger:            kcMerger,
+				CfgManager:        cfgManager,
+				AnalyticsReporter: reporter,
+				NamespaceLister:   k8sCli.CoreV1().Namespaces(),
+				CommandGuard:      cmdGuard,
+				PluginManager:     pluginManager,
+				BotKubeVersion:    botkubeVersion,
+				AuditEmitter:      auditEmitter,
+				ConfigRevision:    execute.ConfigRevisionGetter(configRevision),
+			},
+		)
+	}
+	defaultExecutorFactory, err := newExecutorFactory(conf, filterEngine)
 	if err != nil {
 		return reportFatalError("while creating executor factory", err)
 	}
-
-	router := source.NewRouter(mapper, dynamicCli, logger.WithField(componentLogFieldKey, "Router"))
+	// Bots keep the reloadable factory, so the executors can be replaced when the configuration is reloaded
+	executorFactory := execute.NewReloadableExecutorFactory(defaultExecutorFactory)
 
 	var (
-		notifiers []notifier.Notifier
-		bots      = map[string]bot.Bot{}
+		botNotifiers    []notifier.Notifier
+		bots            = map[string]bot.Bot{}
+		botsByCommGroup = map[string][]bot.Bot{}
 	)
 
 	// TODO: Current limitation: Communication platform config should be separate inside every group:
@@ -222,11 +295,10 @@ func run(ctx context.Context) error {
 	for commGroupName, commGroupCfg := range conf.Communications {
 		commGroupLogger := logger.WithField(commGroupFieldKey, commGroupName)
 
-		router.AddCommunicationsBindings(commGroupCfg)
-
 		scheduleBot := func(in bot.Bot) {
-			notifiers = append(notifiers, in)
+			botNotifiers = append(botNotifiers, in)
 			bots[fmt.Sprintf("%s-%s", commGroupName, in.IntegrationName())] = in
+			botsByCommGroup[commGroupName] = append(botsByCommGroup[commGroupName], in)
 			errGroup.Go(func() error {
 				defer analytics.ReportPanicIfOccurs(commGroupLogger, reporter)
 				return in.Start(ctx)
@@ -274,41 +346,37 @@ func run(ctx context.Context) error {
 			scheduleBot(db)
 		}
 
-		// Run sinks
-		if commGroupCfg.Elasticsearch.Enabled {
-			es, err := sink.NewElasticsearch(commGroupLogger.WithField(sinkLogFieldKey, "Elasticsearch"), commGroupCfg.Elasticsearch, reporter)
+		if commGroupCfg.Telegram.Enabled {
+			tb, err := bot.NewTelegram(commGroupLogger.WithField(botLogFieldKey, "Telegram"), commGroupName, commGroupCfg.Telegram, executorFactory, reporter)
 			if err != nil {
-				return reportFatalError("while creating Elasticsearch sink", err)
+				return reportFatalError("while creating Telegram bot", err)
 			}
-			notifiers = append(notifiers, es)
+			scheduleBot(tb)
 		}
 
-		if commGroupCfg.Webhook.Enabled {
-			wh, err := sink.NewWebhook(commGroupLogger.WithField(sinkLogFieldKey, "Webhook"), commGroupCfg.Webhook, reporter)
+		if commGroupCfg.GoogleChat.Enabled {
+			gb, err := bot.NewGoogleChat(commGroupLogger.WithField(botLogFieldKey, "Google Chat"), commGroupName, commGroupCfg.GoogleChat, executorFactory, reporter)
 			if err != nil {
-				return reportFatalError("while creating Webhook sink", err)
+				return reportFatalError("while creating Google Chat bot", err)
 			}
+			scheduleBot(gb)
+		}
 
-			notifiers = append(notifiers, wh)
+		if commGroupCfg.RocketChat.Enabled {
+			rb, err := bot.NewRocketChat(commGroupLogger.WithField(botLogFieldKey, "Rocket.Chat"), commGroupName, commGroupCfg.RocketChat, executorFactory, reporter)
+			if err != nil {
+				return reportFatalError("while creating Rocket.Chat bot", err)
+			}
+			scheduleBot(rb)
 		}
 	}
 
-	// Lifecycle server
-	if conf.Settings.LifecycleServer.Enabled {
-		lifecycleSrv := lifecycle.NewServer(
-			logger.WithField(componentLogFieldKey, "Lifecycle server"),
-			k8sCli,
-			conf.Settings.LifecycleServer,
-			conf.Settings.ClusterName,
-			func(msg string) error {
-				return notifier.SendPlaintextMessage(ctx, notifiers, msg)
-			},
-		)
-		errGroup.Go(func() error {
-			defer analytics.ReportPanicIfOccurs(logger, reporter)
-			return lifecycleSrv.Serve(ctx)
-		})
+	// Run sinks
+	sinks, err := startSinks(ctx, errGroup, logger, conf, reporter)
+	if err != nil {
+		return reportFatalError("while starting sinks", err)
 	}
+	auditEmitter.Register(sinks.auditSinks...)
 
 	if conf.ConfigWatcher.Enabled {
 		err := config.WaitForWatcherSync(
@@ -328,7 +396,7 @@ func run(ctx context.Context) error {
 
 	// Send help message
 	helpDB := storage.NewForHelp(conf.Settings.SystemConfigMap.Namespace, conf.Settings.SystemConfigMap.Name, k8sCli)
-	err = sendHelp(ctx, helpDB, conf.Settings.ClusterName, enabledPluginExecutors, bots)
+	err = sendHelp(ctx, helpDB, conf.Settings.ClusterName, configRevision(), enabledPluginExecutors, bots)
 	if err != nil {
 		return fmt.Errorf("while sending initial help message: %w", err)
 	}
@@ -340,7 +408,7 @@ func run(ctx context.Context) error {
 	if conf.Settings.UpgradeNotifier {
 		upgradeChecker := controller.NewUpgradeChecker(
 			logger.WithField(componentLogFieldKey, "Upgrade Checker"),
-			notifiers,
+			botNotifiers,
 			ghCli.Repositories,
 		)
 		errGroup.Go(func() error {
@@ -352,11 +420,16 @@ func run(ctx context.Context) error {
 	recommFactory := recommendation.NewFactory(logger.WithField(componentLogFieldKey, "Recommendations"), dynamicCli)
 
 	actionProvider := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory)
-	router.AddEnabledActionBindings(conf.Actions)
 
+	notifiers := append(append([]notifier.Notifier{}, botNotifiers...), sinks.notifiers...)
+	// Source plugins are started with a separate context, so they can be restarted on config reload
+	schedulerCtx, stopScheduler := context.WithCancel(ctx)
+	defer func() {
+		stopScheduler()
+	}()
 	sourcePluginDispatcher := source.NewDispatcher(logger, notifiers, pluginManager)
 	scheduler := source.NewScheduler(logger, conf, sourcePluginDispatcher)
-	err = scheduler.Start(ctx)
+	err = scheduler.Start(schedulerCtx)
 	if err != nil {
 		return fmt.Errorf("while starting source plugin event dispatcher: %w", err)
 	}
@@ -371,12 +444,199 @@ func run(ctx context.Context) error {
 		dynamicCli,
 		mapper,
 		conf.Settings.InformersResyncPeriod,
-		router.BuildTable(conf),
+		newSourcesRouter(logger, mapper, dynamicCli, conf),
 		actionProvider,
 		reporter,
 		statusReporter,
 	)
 
+	// Config hot reload
+	var reloader *lifecycle.Reloader
+	if conf.Settings.HotReload.Enabled {
+		// the currently applied state, which is restored if the new configuration cannot be applied
+		var (
+			currentConf            = conf
+			currentFilterEngine    = filterEngine
+			currentExecutorFactory = defaultExecutorFactory
+		)
+
+		applyConfig := func(ctx context.Context, newConf *config.Config, diff config.Diff) (err error) {
+			newPluginExecutors, newPluginSources := collector.GetAllEnabledAndUsedPlugins(newConf)
+			if !sets.NewString(enabledPluginExecutors...).IsSuperset(sets.NewString(newPluginExecutors...)) ||
+				!sets.NewString(enabledPluginSources...).IsSuperset(sets.NewString(newPluginSources...)) {
+				return fmt.Errorf("new plugins enabled: %w", lifecycle.ErrRestartRequired)
+			}
+
+			newFilterEngine := filterengine.WithAllFilters(logger, dynamicCli, mapper, newConf.Filters)
+			oldNotifiers := append(append([]notifier.Notifier{}, botNotifiers...), sinks.notifiers...)
+
+			// Every applied change registers its rollback. If any of the next steps fails, the applied changes are
+			// reverted in the reverse order, so the app keeps running with the current configuration and the reload
+			// is retried on the next check. Otherwise, the replaced components are released.
+			var rollbacks, releases []func()
+			defer func() {
+				if err != nil {
+					for i := len(rollbacks) - 1; i >= 0; i-- {
+						rollbacks[i]()
+					}
+					return
+				}
+				for _, release := range releases {
+					release()
+				}
+				currentConf = newConf
+				currentFilterEngine = newFilterEngine
+			}()
+
+			if diff.Has(config.ExecutorsReloadComponent) {
+				kcMerger.SetExecutors(newConf.Executors)
+				rollbacks = append(rollbacks, func() {
+					kcMerger.SetExecutors(currentConf.Executors)
+				})
+				if err := ensureResourceNameNormalizer(); err != nil {
+					return fmt.Errorf("while creating resource name normalizer: %w", err)
+				}
+				factory, err := newExecutorFactory(newConf, newFilterEngine)
+				if err != nil {
+					return fmt.Errorf("while creating executor factory: %w", err)
+				}
+				oldFactory := currentExecutorFactory
+				executorFactory.Swap(factory)
+				currentExecutorFactory = factory
+				rollbacks = append(rollbacks, func() {
+					executorFactory.Swap(oldFactory)
+					currentExecutorFactory = oldFactory
+				})
+			}
+
+			if diff.Has(config.BotChannelsReloadComponent) {
+				for commGroupName, groupBots := range botsByCommGroup {
+					for _, b := range groupBots {
+						channelsReloader, ok := b.(bot.ChannelsReloader)
+						if !ok {
+							continue
+						}
+						commGroupName, b := commGroupName, b
+						rollbacks = append(rollbacks, func() {
+							if err := channelsReloader.ReloadChannels(currentConf.Communications[commGroupName]); err != nil {
+								logger.Errorf("while restoring %s bot channels: %s", b.IntegrationName(), err.Error())
+							}
+						})
+						if err := channelsReloader.ReloadChannels(newConf.Communications[commGroupName]); err != nil {
+							return fmt.Errorf("while reloading %s bot channels: %w", b.IntegrationName(), err)
+						}
+					}
+				}
+			}
+
+			if diff.Has(config.SinksReloadComponent) {
+				// The new sinks are started before the old ones are stopped, so events are not lost in the meantime.
+				newSinks, err := startSinks(ctx, errGroup, logger, newConf, reporter)
+				if err != nil {
+					return fmt.Errorf("while starting sinks: %w", err)
+				}
+				oldSinks := sinks
+				sinks = newSinks
+				auditEmitter.Replace(newSinks.auditSinks...)
+
+				rollbacks = append(rollbacks, func() {
+					sinks = oldSinks
+					auditEmitter.Replace(oldSinks.auditSinks...)
+					newSinks.stop(ctx, logger)
+				})
+				// the old sinks are flushed and stopped once the new ones are used by the controller and source plugins
+				releases = append(releases, func() {
+					oldSinks.stop(ctx, logger)
+				})
+			}
+
+			if diff.Has(config.RouterReloadComponent) {
+				notifiers := append(append([]notifier.Notifier{}, botNotifiers...), sinks.notifiers...)
+
+				stopScheduler()
+				schedulerCtx, stopScheduler = context.WithCancel(ctx)
+				rollbacks = append(rollbacks, func() {
+					stopScheduler()
+					schedulerCtx, stopScheduler = context.WithCancel(ctx)
+					scheduler := source.NewScheduler(logger, currentConf, source.NewDispatcher(logger, oldNotifiers, pluginManager))
+					if err := scheduler.Start(schedulerCtx); err != nil {
+						logger.Errorf("while restoring source plugin event dispatcher: %s", err.Error())
+					}
+				})
+				scheduler := source.NewScheduler(logger, newConf, source.NewDispatcher(logger, notifiers, pluginManager))
+				if err := scheduler.Start(schedulerCtx); err != nil {
+					return fmt.Errorf("while starting source plugin event dispatcher: %w", err)
+				}
+
+				rollbacks = append(rollbacks, func() {
+					err := ctrl.Reload(ctx, controller.ReloadInput{
+						Conf:           currentConf,
+						Notifiers:      oldNotifiers,
+						FilterEngine:   currentFilterEngine,
+						Router:         newSourcesRouter(logger, mapper, dynamicCli, currentConf),
+						ActionProvider: action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), currentConf.Actions, executorFactory),
+					})
+					if err != nil {
+						logger.Errorf("while restoring controller: %s", err.Error())
+					}
+				})
+				err = ctrl.Reload(ctx, controller.ReloadInput{
+					Conf:           newConf,
+					Notifiers:      notifiers,
+					FilterEngine:   newFilterEngine,
+					Router:         newSourcesRouter(logger, mapper, dynamicCli, newConf),
+					ActionProvider: action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), newConf.Actions, executorFactory),
+				})
+				if err != nil {
+					return fmt.Errorf("while reloading controller: %w", err)
+				}
+			}
+
+			return nil
+		}
+
+		reloader = lifecycle.NewReloader(
+			logger.WithField(componentLogFieldKey, "Config Reloader"),
+			cfgProvider,
+			conf.Settings.HotReload,
+			conf,
+			configs,
+			applyConfig,
+			lifecycle.NewDeploymentRestartFn(k8sCli, conf.Settings.LifecycleServer.Deployment, conf.Settings.ClusterName, func(msg string) error {
+				return notifier.SendPlaintextMessage(ctx, botNotifiers, msg)
+			}),
+		)
+		hotReloader.Store(reloader)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return reloader.Start(ctx)
+		})
+		if crdProvider != nil {
+			errGroup.Go(func() error {
+				defer analytics.ReportPanicIfOccurs(logger, reporter)
+				return crdProvider.Watch(ctx, reloader.Trigger)
+			})
+		}
+	}
+
+	// Lifecycle server
+	if conf.Settings.LifecycleServer.Enabled {
+		lifecycleSrv := lifecycle.NewServer(
+			logger.WithField(componentLogFieldKey, "Lifecycle server"),
+			k8sCli,
+			conf.Settings.LifecycleServer,
+			conf.Settings.ClusterName,
+			func(msg string) error {
+				return notifier.SendPlaintextMessage(ctx, botNotifiers, msg)
+			},
+			reloader,
+		)
+		errGroup.Go(func() error {
+			defer analytics.ReportPanicIfOccurs(logger, reporter)
+			return lifecycleSrv.Serve(ctx)
+		})
+	}
+
 	if _, err := statusReporter.ReportDeploymentStartup(ctx); err != nil {
 		return reportFatalError("while reporting botkube startup", err)
 	}
@@ -394,6 +654,16 @@ func run(ctx context.Context) error {
 	return nil
 }
 
+// newSourcesRouter returns the sources router with the routing table built for a given configuration.
+func newSourcesRouter(logger logrus.FieldLogger, mapper meta.RESTMapper, dynamicCli dynamic.Interface, conf *config.Config) *source.Router {
+	router := source.NewRouter(mapper, dynamicCli, logger.WithField(componentLogFieldKey, "Router"))
+	for _, commGroupCfg := range conf.Communications {
+		router.AddCommunicationsBindings(commGroupCfg)
+	}
+	router.AddEnabledActionBindings(conf.Actions)
+	return router.BuildTable(conf)
+}
+
 func newMetricsServer(log logrus.FieldLogger, metricsPort string) *httpsrv.Server {
 	addr := fmt.Sprintf(":%s", metricsPort)
 	router := mux.NewRouter()
@@ -479,7 +749,7 @@ func reportFatalErrFn(logger logrus.FieldLogger, reporter analytics.Reporter, st
 }
 
 // sendHelp sends the help message to all interactive bots.
-func sendHelp(ctx context.Context, s *storage.Help, clusterName string, executors []string, notifiers map[string]bot.Bot) error {
+func sendHelp(ctx context.Context, s *storage.Help, clusterName, configRevision string, executors []string, notifiers map[string]bot.Bot) error {
 	alreadySentHelp, err := s.GetSentHelpDetails(ctx)
 	if err != nil {
 		return fmt.Errorf("while getting the help data: %w", err)
@@ -492,7 +762,7 @@ func sendHelp(ctx context.Context, s *storage.Help, clusterName string, executor
 			continue
 		}
 
-		help := interactive.NewHelpMessage(notifier.IntegrationName(), clusterName, executors).Build()
+		help := interactive.NewHelpMessage(notifier.IntegrationName(), clusterName, executors).WithConfigRevision(configRevision).Build()
 		err := notifier.SendMessageToAll(ctx, help)
 		if err != nil {
 			return fmt.Errorf("while sending help message for %s: %w", notifier.IntegrationName(), err)
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/notifier"
+	"github.com/kubeshop/botkube/pkg/sink"
+)
+
+// sinkSet holds the sinks created for a given configuration, so they can be replaced when the configuration is reloaded.
+type sinkSet struct {
+	notifiers  []notifier.Notifier
+	auditSinks []audit.Sink
+
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	replaced atomic.Bool
+}
+
+// startSinks creates the sinks for all communication groups and runs their background loops in a given errgroup.
+func startSinks(ctx context.Context, errGroup *errgroup.Group, logger logrus.FieldLogger, conf *config.Config, reporter analytics.Reporter) (*sinkSet, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	set := &sinkSet{cancel: cancel}
+
+	run := func(log logrus.FieldLogger, startFn func(ctx context.Context) error) {
+		set.wg.Add(1)
+		errGroup.Go(func() error {
+			defer set.wg.Done()
+			defer analytics.ReportPanicIfOccurs(log, reporter)
+			err := startFn(ctx)
+			if err != nil && set.replaced.Load() {
+				// the sink was already replaced, so it shouldn't stop the app
+				log.Errorf("while stopping replaced sink: %s", err.Error())
+				return nil
+			}
+			return err
+		})
+	}
+
+	err := set.create(logger, conf, reporter, run)
+	if err != nil {
+		set.stop(ctx, logger)
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (s *sinkSet) create(logger logrus.FieldLogger, conf *config.Config, reporter analytics.Reporter, run func(logrus.FieldLogger, func(ctx context.Context) error)) error {
+	for commGroupName, commGroupCfg := range conf.Communications {
+		commGroupLogger := logger.WithField(commGroupFieldKey, commGroupName)
+
+		if commGroupCfg.Elasticsearch.Enabled {
+			es, err := sink.NewElasticsearch(commGroupLogger.WithField(sinkLogFieldKey, "Elasticsearch"), commGroupName, commGroupCfg.Elasticsearch, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating Elasticsearch sink: %w", err)
+			}
+			s.notifiers = append(s.notifiers, es)
+			if commGroupCfg.Elasticsearch.AuditEnabled() {
+				s.auditSinks = append(s.auditSinks, es)
+			}
+			run(commGroupLogger, es.Start)
+		}
+
+		if commGroupCfg.Webhook.Enabled {
+			wh, err := sink.NewWebhook(commGroupLogger.WithField(sinkLogFieldKey, "Webhook"), commGroupName, commGroupCfg.Webhook, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating Webhook sink: %w", err)
+			}
+
+			s.notifiers = append(s.notifiers, wh)
+			if commGroupCfg.Webhook.Bindings.Audit {
+				s.auditSinks = append(s.auditSinks, wh)
+			}
+			run(commGroupLogger, wh.Start)
+		}
+
+		if commGroupCfg.Kafka.Enabled {
+			ks, err := sink.NewKafka(commGroupLogger.WithField(sinkLogFieldKey, "Kafka"), commGroupCfg.Kafka, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating Kafka sink: %w", err)
+			}
+
+			s.notifiers = append(s.notifiers, ks)
+			if commGroupCfg.Kafka.Bindings.Audit {
+				s.auditSinks = append(s.auditSinks, ks)
+			}
+			run(commGroupLogger, ks.Start)
+		}
+
+		if commGroupCfg.NATS.Enabled {
+			ns, err := sink.NewNATS(commGroupLogger.WithField(sinkLogFieldKey, "NATS"), commGroupCfg.NATS, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating NATS sink: %w", err)
+			}
+
+			s.notifiers = append(s.notifiers, ns)
+			if commGroupCfg.NATS.Bindings.Audit {
+				s.auditSinks = append(s.auditSinks, ns)
+			}
+			run(commGroupLogger, ns.Start)
+		}
+
+		if commGroupCfg.Loki.Enabled {
+			ls, err := sink.NewLoki(commGroupLogger.WithField(sinkLogFieldKey, "Loki"), commGroupCfg.Loki, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating Loki sink: %w", err)
+			}
+			s.notifiers = append(s.notifiers, ls)
+			if commGroupCfg.Loki.Bindings.Audit {
+				s.auditSinks = append(s.auditSinks, ls)
+			}
+		}
+
+		if commGroupCfg.OTLPLogs.Enabled {
+			ol, err := sink.NewOTLPLogs(commGroupLogger.WithField(sinkLogFieldKey, "OTLP logs"), commGroupCfg.OTLPLogs, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating OTLP logs sink: %w", err)
+			}
+			s.notifiers = append(s.notifiers, ol)
+			if commGroupCfg.OTLPLogs.Bindings.Audit {
+				s.auditSinks = append(s.auditSinks, ol)
+			}
+		}
+
+		if commGroupCfg.Alertmanager.Enabled {
+			am, err := sink.NewAlertmanager(commGroupLogger.WithField(sinkLogFieldKey, "Alertmanager"), commGroupCfg.Alertmanager, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating Alertmanager sink: %w", err)
+			}
+			s.notifiers = append(s.notifiers, am)
+		}
+
+		if commGroupCfg.S3.Enabled {
+			s3Sink, err := sink.NewS3(commGroupLogger.WithField(sinkLogFieldKey, "S3"), commGroupCfg.S3, reporter)
+			if err != nil {
+				return fmt.Errorf("while creating S3 sink: %w", err)
+			}
+
+			s.notifiers = append(s.notifiers, s3Sink)
+			run(commGroupLogger, s3Sink.Start)
+		}
+	}
+
+	return nil
+}
+
+// stop flushes the buffered events and stops the background loops of the sinks.
+// It waits for the loops to finish, so the replacing sinks don't compete for the same outbox files.
+func (s *sinkSet) stop(ctx context.Context, log logrus.FieldLogger) {
+	if err := notifier.Flush(ctx, s.notifiers); err != nil {
+		log.Errorf("while flushing buffered events: %s", err.Error())
+	}
+	s.replaced.Store(true)
+	s.cancel()
+	s.wg.Wait()
+}
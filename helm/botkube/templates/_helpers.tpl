@@ -57,3 +57,11 @@ Create the name of the service account to use
 {{- end -}}
 {{- end -}}
 {{- end -}}
+
+{{- define "botkube.communication.inbound.enabled" -}}
+{{- range $key, $val := .Values.communications -}}
+{{- if or $val.teams.enabled (dig "googleChat" "enabled" false $val) (dig "rocketChat" "enabled" false $val) -}}
+  {{- true -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,383 @@
+package crd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// ReadyCondition reports whether the configuration defined by a given custom resource is valid and merged into the Botkube configuration.
+	ReadyCondition = "Ready"
+
+	validReason         = "Valid"
+	invalidSpecReason   = "InvalidSpec"
+	invalidConfigReason = "InvalidConfiguration"
+	conflictReason      = "Conflict"
+)
+
+// Provider aggregates the configuration defined by custom resources with the configuration returned by a base provider.
+// Custom resources which define invalid configuration are skipped, and the validation errors are reported in their status conditions.
+type Provider struct {
+	log        logrus.FieldLogger
+	base       intconfig.Provider
+	cli        dynamic.Interface
+	mapper     apimeta.RESTMapper
+	namespaces []string
+	commGroups map[string]struct{}
+
+	// factories hold the informers started by Watch. Once they are synced, the custom resources are read from their caches.
+	factories []dynamicinformer.DynamicSharedInformerFactory
+	synced    atomic.Bool
+
+	// the custom resources are validated only if they or the base configuration changed since the last call
+	mu              sync.Mutex
+	lastFingerprint string
+	lastFiles       intconfig.YAMLFiles
+}
+
+// NewProvider returns a new Provider instance.
+// The mapper is used to reject cluster-scoped resources in the kubectl executors defined by custom resources.
+func NewProvider(log logrus.FieldLogger, base intconfig.Provider, cli dynamic.Interface, mapper apimeta.RESTMapper, cfg config.ConfigCRDs) *Provider {
+	namespaces := cfg.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var factories []dynamicinformer.DynamicSharedInformerFactory
+	for _, ns := range namespaces {
+		factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(cli, time.Duration(0), ns, nil)
+		for _, kind := range orderedKinds {
+			// register informers, so they are started by the factory
+			factory.ForResource(kind.GVR())
+		}
+		factories = append(factories, factory)
+	}
+
+	commGroups := make(map[string]struct{}, len(cfg.CommunicationGroups))
+	for _, name := range cfg.CommunicationGroups {
+		commGroups[name] = struct{}{}
+	}
+
+	return &Provider{
+		log:        log,
+		base:       base,
+		cli:        cli,
+		mapper:     mapper,
+		namespaces: namespaces,
+		commGroups: commGroups,
+		factories:  factories,
+	}
+}
+
+// Configs returns the configuration files from the base provider followed by the configuration generated from the custom resources.
+func (p *Provider) Configs(ctx context.Context) (intconfig.YAMLFiles, error) {
+	files, err := p.base.Configs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objsByKind := make(map[Kind][]unstructured.Unstructured, len(orderedKinds))
+	for _, kind := range orderedKinds {
+		objs, err := p.list(ctx, kind)
+		if err != nil {
+			return nil, err
+		}
+		objsByKind[kind] = objs
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fingerprint := configFingerprint(files, objsByKind)
+	if fingerprint == p.lastFingerprint {
+		return append(intconfig.YAMLFiles{}, p.lastFiles...), nil
+	}
+
+	resolver := bindingsResolver{
+		sources:   map[string]struct{}{},
+		executors: map[string]struct{}{},
+	}
+	aliasOwners := map[string]string{}
+	channelOwners := baseChannelOwners(files)
+	statusUpdated := true
+
+	for _, kind := range orderedKinds {
+		objs := objsByKind[kind]
+		for idx := range objs {
+			obj := &objs[idx]
+
+			cond := metav1.Condition{Type: ReadyCondition, Status: metav1.ConditionTrue, Reason: validReason, Message: "Configuration is valid."}
+			file, err := p.configFile(kind, obj, resolver, aliasOwners)
+			channel, definesChannel := channelClaim(kind, obj)
+			switch {
+			case err != nil:
+				cond.Status, cond.Reason, cond.Message = metav1.ConditionFalse, invalidSpecReason, err.Error()
+			case definesChannel && channelOwners[channel] != "":
+				cond.Status, cond.Reason, cond.Message = metav1.ConditionFalse, conflictReason, fmt.Sprintf("channel is already used by %s", channelOwners[channel])
+			default:
+				if _, _, err := config.LoadWithDefaults(append(append(intconfig.YAMLFiles{}, files...), file)); err != nil {
+					cond.Status, cond.Reason, cond.Message = metav1.ConditionFalse, invalidConfigReason, err.Error()
+					break
+				}
+				files = append(files, file)
+				switch kind {
+				case SourceKind:
+					resolver.sources[configKey(obj)] = struct{}{}
+				case ExecutorBindingKind:
+					resolver.executors[configKey(obj)] = struct{}{}
+				case AliasKind:
+					aliasOwners[obj.GetName()] = configKey(obj)
+				case ChannelKind, TenantBindingKind:
+					channelOwners[channel] = fmt.Sprintf("%s %q", kind, configKey(obj))
+				}
+			}
+
+			if err := p.updateStatus(ctx, kind, obj, cond); err != nil {
+				// status is informational, so don't block loading configuration
+				p.log.Errorf("while updating status of %s %q: %s", kind, configKey(obj), err.Error())
+				statusUpdated = false
+			}
+		}
+	}
+
+	if statusUpdated {
+		// otherwise, validate again on the next call to retry the status update
+		p.lastFingerprint = fingerprint
+		p.lastFiles = append(intconfig.YAMLFiles{}, files...)
+	}
+	return files, nil
+}
+
+// Revision returns the revision reported by the base provider, if it reports any.
+func (p *Provider) Revision() string {
+	revProvider, ok := p.base.(intconfig.RevisionProvider)
+	if !ok {
+		return ""
+	}
+	return revProvider.Revision()
+}
+
+// Watch calls onChange every time a spec of the custom resources changes, until the context is cancelled.
+func (p *Provider) Watch(ctx context.Context, onChange func()) error {
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(any) { onChange() },
+		UpdateFunc: func(oldObj, newObj any) {
+			oldU, oldOK := oldObj.(*unstructured.Unstructured)
+			newU, newOK := newObj.(*unstructured.Unstructured)
+			if oldOK && newOK && oldU.GetGeneration() == newU.GetGeneration() {
+				// only status or metadata changed
+				return
+			}
+			onChange()
+		},
+		DeleteFunc: func(any) { onChange() },
+	}
+
+	for _, factory := range p.factories {
+		for _, kind := range orderedKinds {
+			factory.ForResource(kind.GVR()).Informer().AddEventHandler(handler)
+		}
+		factory.Start(ctx.Done())
+	}
+	for _, factory := range p.factories {
+		for gvr, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if !synced && ctx.Err() == nil {
+				return fmt.Errorf("while waiting for %s informer cache sync", gvr.Resource)
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	p.synced.Store(true)
+
+	p.log.Infof("Watching Botkube configuration custom resources in %q namespaces...", p.namespaces)
+	<-ctx.Done()
+	return nil
+}
+
+// list returns the custom resources of a given kind. They are read from the informer caches, if they are synced.
+func (p *Provider) list(ctx context.Context, kind Kind) ([]unstructured.Unstructured, error) {
+	var out []unstructured.Unstructured
+	if p.synced.Load() {
+		for _, factory := range p.factories {
+			items, err := factory.ForResource(kind.GVR()).Lister().List(labels.Everything())
+			if err != nil {
+				return nil, fmt.Errorf("while listing %s custom resources: %w", kind, err)
+			}
+			for _, item := range items {
+				obj, ok := item.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				// cached objects must not be modified
+				out = append(out, *obj.DeepCopy())
+			}
+		}
+	} else {
+		for _, ns := range p.namespaces {
+			list, err := p.cli.Resource(kind.GVR()).Namespace(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("while listing %s custom resources: %w", kind, err)
+			}
+			out = append(out, list.Items...)
+		}
+	}
+
+	// sort to have a stable merge order
+	sort.Slice(out, func(i, j int) bool {
+		return configKey(&out[i]) < configKey(&out[j])
+	})
+	return out, nil
+}
+
+func (p *Provider) configFile(kind Kind, obj *unstructured.Unstructured, resolver bindingsResolver, aliasOwners map[string]string) ([]byte, error) {
+	if kind == AliasKind {
+		if owner, found := aliasOwners[obj.GetName()]; found {
+			return nil, fmt.Errorf("alias %q is already defined by %q", obj.GetName(), owner)
+		}
+	}
+
+	cfg, err := toConfig(kind, obj, resolver, p.mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == ChannelKind || kind == TenantBindingKind {
+		commGroup, _, _ := unstructured.NestedString(obj.Object, "spec", "communicationGroup")
+		if _, allowed := p.commGroups[commGroup]; !allowed {
+			return nil, fmt.Errorf("communication group %q is not allowed for custom resources", commGroup)
+		}
+	}
+
+	out, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	if config.HasValueFromRefs([][]byte{out}) {
+		// references could read Secrets and files available to Botkube, but not to the custom resource owner
+		return nil, errors.New("valueFrom references are not allowed in custom resources")
+	}
+	return out, nil
+}
+
+// channelClaim returns the key of the channel defined by a given custom resource, e.g. `default-group/socketSlack/alerts`.
+// It returns false if the custom resource doesn't define a channel.
+func channelClaim(kind Kind, obj *unstructured.Unstructured) (string, bool) {
+	if kind != ChannelKind && kind != TenantBindingKind {
+		return "", false
+	}
+	commGroup, _, _ := unstructured.NestedString(obj.Object, "spec", "communicationGroup")
+	platform, _, _ := unstructured.NestedString(obj.Object, "spec", "platform")
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "channel", "name")
+	if name == "" {
+		name, _, _ = unstructured.NestedString(obj.Object, "spec", "channel", "id")
+	}
+	return channelKey(commGroup, platform, name), true
+}
+
+// baseChannelOwners returns the channels defined by a given base configuration, so the custom resources cannot claim them.
+func baseChannelOwners(files intconfig.YAMLFiles) map[string]string {
+	out := map[string]string{}
+	cfg, _, err := config.LoadWithDefaults(files)
+	if err != nil {
+		// custom resources are reported as invalid anyway
+		return out
+	}
+
+	raw, err := yaml.Marshal(cfg.Communications)
+	if err != nil {
+		return out
+	}
+	var groups map[string]map[string]struct {
+		Channels map[string]struct {
+			Name string `yaml:"name"`
+			ID   string `yaml:"id"`
+		} `yaml:"channels"`
+	}
+	if err := yaml.Unmarshal(raw, &groups); err != nil {
+		return out
+	}
+
+	for commGroup, platforms := range groups {
+		for platform, platformCfg := range platforms {
+			for _, channel := range platformCfg.Channels {
+				name := channel.Name
+				if name == "" {
+					name = channel.ID
+				}
+				out[channelKey(commGroup, platform, name)] = "the base configuration"
+			}
+		}
+	}
+	return out
+}
+
+func channelKey(commGroup, platform, name string) string {
+	return fmt.Sprintf("%s/%s/%s", commGroup, platform, name)
+}
+
+// configFingerprint returns a hash which changes when the base configuration or any of the custom resources specs change.
+func configFingerprint(files intconfig.YAMLFiles, objsByKind map[Kind][]unstructured.Unstructured) string {
+	hash := sha256.New()
+	for _, file := range files {
+		fileHash := sha256.Sum256(file)
+		hash.Write(fileHash[:])
+	}
+	for _, kind := range orderedKinds {
+		for _, obj := range objsByKind[kind] {
+			fmt.Fprintf(hash, "%s:%s:%s:%d;", kind, configKey(&obj), obj.GetUID(), obj.GetGeneration())
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (p *Provider) updateStatus(ctx context.Context, kind Kind, obj *unstructured.Unstructured, cond metav1.Condition) error {
+	var status struct {
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+	}
+	if rawStatus, found, _ := unstructured.NestedMap(obj.Object, "status"); found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawStatus, &status); err != nil {
+			return fmt.Errorf("while converting status: %w", err)
+		}
+	}
+
+	cond.ObservedGeneration = obj.GetGeneration()
+	if old := apimeta.FindStatusCondition(status.Conditions, cond.Type); old != nil &&
+		old.Status == cond.Status && old.Reason == cond.Reason && old.Message == cond.Message && old.ObservedGeneration == cond.ObservedGeneration {
+		return nil
+	}
+	apimeta.SetStatusCondition(&status.Conditions, cond)
+
+	rawStatus, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("while converting status: %w", err)
+	}
+	if err := unstructured.SetNestedMap(obj.Object, rawStatus, "status"); err != nil {
+		return fmt.Errorf("while setting status: %w", err)
+	}
+
+	_, err = p.cli.Resource(kind.GVR()).Namespace(obj.GetNamespace()).UpdateStatus(ctx, obj, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,416 @@
+package crd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var baseCfg = heredoc.Doc(`
+	sources:
+	  k8s-events:
+	    displayName: Events
+	executors:
+	  kubectl-read-only:
+	    kubectl:
+	      enabled: true
+	communications:
+	  default-group:
+	    socketSlack:
+	      enabled: true
+	      botToken: xoxb-token
+	      appToken: xapp-token
+	      channels:
+	        default:
+	          name: botkube
+	          bindings:
+	            sources: [k8s-events]
+	`)
+
+func TestProviderConfigs(t *testing.T) {
+	// given
+	objs := []runtime.Object{
+		fixObj(SourceKind, "team-a", "team-events", map[string]any{
+			"displayName": "Team A events",
+			"kubernetes": map[string]any{
+				"resources": []any{map[string]any{"type": "v1/pods"}},
+			},
+		}),
+		fixObj(ExecutorBindingKind, "team-a", "kubectl", map[string]any{
+			"kubectl": map[string]any{
+				"enabled":  true,
+				"commands": map[string]any{"verbs": []any{"get"}, "resources": []any{"pods"}},
+			},
+		}),
+		fixObj(ChannelKind, "team-a", "alerts", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel": map[string]any{
+				"name": "team-a-alerts",
+				"bindings": map[string]any{
+					"sources":   []any{"team-events"},
+					"executors": []any{"kubectl"},
+				},
+			},
+		}),
+		fixObj(SourceKind, "team-b", "all-namespaces", map[string]any{
+			"kubernetes": map[string]any{
+				"namespaces": map[string]any{"include": []any{".*"}},
+			},
+		}),
+		fixObj(SourceKind, "team-b", "resource-namespaces", map[string]any{
+			"kubernetes": map[string]any{
+				"resources": []any{map[string]any{"type": "v1/secrets", "namespaces": map[string]any{"include": []any{".*"}}}},
+			},
+		}),
+		fixObj(SourceKind, "team-b", "plugin", map[string]any{
+			"botkube/prometheus": map[string]any{"enabled": true},
+		}),
+		fixObj(ExecutorBindingKind, "team-b", "all-namespaces", map[string]any{
+			"kubectl": map[string]any{
+				"enabled":    true,
+				"namespaces": map[string]any{"include": []any{".*"}},
+				"commands":   map[string]any{"verbs": []any{"get"}, "resources": []any{"secrets"}},
+			},
+		}),
+		fixObj(ExecutorBindingKind, "team-b", "cluster-scoped", map[string]any{
+			"kubectl": map[string]any{
+				"enabled":  true,
+				"commands": map[string]any{"verbs": []any{"get"}, "resources": []any{"nodes"}},
+			},
+		}),
+		fixObj(ExecutorBindingKind, "team-b", "plugin", map[string]any{
+			"botkube/kubectl": map[string]any{"enabled": true},
+		}),
+		fixObj(ChannelKind, "team-b", "global-source", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel": map[string]any{
+				"name":     "team-b",
+				"bindings": map[string]any{"sources": []any{"k8s-events"}},
+			},
+		}),
+		fixObj(ChannelKind, "team-b", "invalid", map[string]any{
+			"platform": "socketSlack",
+			"channel":  map[string]any{"name": "team-b"},
+		}),
+		fixObj(ChannelKind, "team-b", "unknown-source", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel": map[string]any{
+				"name":     "team-b",
+				"bindings": map[string]any{"sources": []any{"team-events"}},
+			},
+		}),
+		fixObj(ChannelKind, "team-b", "global-executor", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel": map[string]any{
+				"name":     "team-b",
+				"bindings": map[string]any{"executors": []any{"kubectl-read-only"}},
+			},
+		}),
+		fixObj(ChannelKind, "team-b", "not-allowed-group", map[string]any{
+			"communicationGroup": "admin-group",
+			"platform":           "socketSlack",
+			"channel":            map[string]any{"name": "team-b"},
+		}),
+		fixObj(ChannelKind, "team-b", "value-from", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel": map[string]any{
+				"name": map[string]any{"valueFrom": map[string]any{"fileRef": map[string]any{"path": "/etc/passwd"}}},
+			},
+		}),
+		fixObj(AliasKind, "team-a", "kgp", map[string]any{"command": "kubectl get pods"}),
+		fixObj(AliasKind, "team-b", "kgp", map[string]any{"command": "kubectl get pods -A"}),
+	}
+	cli := newFakeClient(objs...)
+	provider := NewProvider(loggerx.NewNoop(), staticProvider{[]byte(baseCfg)}, cli, newFakeMapper(), config.ConfigCRDs{Enabled: true, CommunicationGroups: []string{"default-group"}})
+
+	// when
+	files, err := provider.Configs(context.Background())
+
+	// then
+	require.NoError(t, err)
+	cfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Team A events", cfg.Sources["team-a/team-events"].DisplayName)
+	assert.Equal(t, "kubectl get pods", cfg.Aliases["kgp"].Command)
+
+	channels := cfg.Communications["default-group"].SocketSlack.Channels
+	assert.Len(t, channels, 2)
+	assert.Equal(t, "team-a-alerts", channels["team-a/alerts"].Name)
+	assert.Equal(t, []string{"team-a/team-events"}, channels["team-a/alerts"].Bindings.Sources)
+	assert.Equal(t, []string{"team-a/kubectl"}, channels["team-a/alerts"].Bindings.Executors)
+
+	source := cfg.Sources["team-a/team-events"].Kubernetes
+	assert.Equal(t, []string{"^team-a$"}, source.Namespaces.Include)
+	assert.Equal(t, "team-a", source.TenantNamespace)
+	executor := cfg.Executors["team-a/kubectl"].Kubectl
+	assert.Equal(t, []string{"^team-a$"}, executor.Namespaces.Include)
+	assert.Equal(t, "team-a", executor.DefaultNamespace)
+
+	assertReady(t, cli, SourceKind, "team-a", "team-events", metav1.ConditionTrue, validReason)
+	assertReady(t, cli, ExecutorBindingKind, "team-a", "kubectl", metav1.ConditionTrue, validReason)
+	assertReady(t, cli, ChannelKind, "team-a", "alerts", metav1.ConditionTrue, validReason)
+	assertReady(t, cli, SourceKind, "team-b", "all-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, SourceKind, "team-b", "resource-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, SourceKind, "team-b", "plugin", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ExecutorBindingKind, "team-b", "all-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ExecutorBindingKind, "team-b", "cluster-scoped", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ExecutorBindingKind, "team-b", "plugin", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "global-source", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "invalid", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "unknown-source", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "global-executor", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "not-allowed-group", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, ChannelKind, "team-b", "value-from", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, AliasKind, "team-a", "kgp", metav1.ConditionTrue, validReason)
+	assertReady(t, cli, AliasKind, "team-b", "kgp", metav1.ConditionFalse, invalidSpecReason)
+}
+
+func TestProviderConfigsValidatesOnlyChanges(t *testing.T) {
+	// given
+	cli := newFakeClient(
+		fixObj(SourceKind, "team-a", "events", map[string]any{"displayName": "A"}),
+	)
+	provider := NewProvider(loggerx.NewNoop(), staticProvider{[]byte(baseCfg)}, cli, newFakeMapper(), config.ConfigCRDs{Enabled: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = provider.Watch(ctx, func() {})
+	}()
+	require.Eventually(t, provider.synced.Load, 5*time.Second, 10*time.Millisecond)
+
+	first, err := provider.Configs(ctx)
+	require.NoError(t, err)
+	cli.ClearActions()
+
+	// when
+	second, err := provider.Configs(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Empty(t, cli.Actions(), "custom resources should be read from cache and not validated again")
+}
+
+func TestProviderConfigsLimitedToNamespaces(t *testing.T) {
+	// given
+	cli := newFakeClient(
+		fixObj(SourceKind, "team-a", "events", map[string]any{"displayName": "A"}),
+		fixObj(SourceKind, "team-b", "events", map[string]any{"displayName": "B"}),
+	)
+	provider := NewProvider(loggerx.NewNoop(), staticProvider{[]byte(baseCfg)}, cli, newFakeMapper(), config.ConfigCRDs{Enabled: true, Namespaces: []string{"team-b"}})
+
+	// when
+	files, err := provider.Configs(context.Background())
+
+	// then
+	require.NoError(t, err)
+	cfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+	assert.NotContains(t, cfg.Sources, "team-a/events")
+	assert.Contains(t, cfg.Sources, "team-b/events")
+}
+
+func assertReady(t *testing.T, cli *fake.FakeDynamicClient, kind Kind, ns, name string, expStatus metav1.ConditionStatus, expReason string) {
+	t.Helper()
+
+	obj, err := cli.Resource(kind.GVR()).Namespace(ns).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	var status struct {
+		Conditions []metav1.Condition `json:"conditions"`
+	}
+	rawStatus, found, err := unstructured.NestedMap(obj.Object, "status")
+	require.NoError(t, err)
+	require.True(t, found, "status not found for %s %s/%s", kind, ns, name)
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(rawStatus, &status))
+
+	cond := apimeta.FindStatusCondition(status.Conditions, ReadyCondition)
+	require.NotNil(t, cond)
+	assert.Equal(t, expStatus, cond.Status, cond.Message)
+	assert.Equal(t, expReason, cond.Reason, cond.Message)
+}
+
+func fixObj(kind Kind, ns, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetGroupVersionKind(GroupVersion.WithKind(string(kind)))
+	obj.SetNamespace(ns)
+	obj.SetName(name)
+	obj.SetGeneration(1)
+	return obj
+}
+
+func newFakeClient(objs ...runtime.Object) *fake.FakeDynamicClient {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, kind := range orderedKinds {
+		listKinds[kind.GVR()] = string(kind) + "List"
+	}
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objs...)
+}
+
+func newFakeMapper() apimeta.RESTMapper {
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, apimeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, apimeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, apimeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Node"}, apimeta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, apimeta.RESTScopeRoot)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, apimeta.RESTScopeRoot)
+	return mapper
+}
+
+type staticProvider struct {
+	file []byte
+}
+
+func (s staticProvider) Configs(context.Context) (intconfig.YAMLFiles, error) {
+	return intconfig.YAMLFiles{s.file}, nil
+}
+
+func TestProviderConfigsTenantBindings(t *testing.T) {
+	// given
+	cli := newFakeClient(
+		fixObj(TenantBindingKind, "team-a", "alerts", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel":            map[string]any{"name": "team-a-alerts"},
+			"kubernetes": map[string]any{
+				"resources": []any{
+					map[string]any{"type": "v1/pods", "event": map[string]any{"types": []any{"error"}}},
+				},
+			},
+			"executors": map[string]any{
+				"kubectl": map[string]any{"enabled": true, "resources": []any{"pods", "deployments.apps"}},
+			},
+		}),
+		fixObj(TenantBindingKind, "team-b", "all-namespaces", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel":            map[string]any{"name": "team-b-alerts"},
+			"kubernetes": map[string]any{
+				"namespaces": map[string]any{"include": []any{".*"}},
+				"resources":  []any{map[string]any{"type": "v1/pods"}},
+			},
+		}),
+		fixObj(TenantBindingKind, "team-b", "resource-namespaces", map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel":            map[string]any{"name": "team-b-alerts"},
+			"kubernetes": map[string]any{
+				"resources": []any{map[string]any{"type": "v1/pods", "namespaces": map[string]any{"include": []any{".*"}}}},
+			},
+		}),
+	)
+	for name, spec := range map[string]map[string]any{
+		"label-value-from": {
+			"kubernetes": map[string]any{
+				"labels": map[string]any{"app": map[string]any{"valueFrom": map[string]any{"fileRef": map[string]any{"path": "/etc/passwd"}}}},
+			},
+		},
+		"event-namespaces": {
+			"kubernetes": map[string]any{
+				"event": map[string]any{"types": []any{"error"}, "namespaces": map[string]any{"include": []any{".*"}}},
+			},
+		},
+		"channel-name-object": {
+			"channel":    map[string]any{"name": map[string]any{"include": []any{".*"}}},
+			"kubernetes": map[string]any{},
+		},
+		"not-allowed-group": {
+			"communicationGroup": "admin-group",
+			"channel":            map[string]any{"name": "team-b-admin"},
+		},
+		"admin-channel": {
+			"channel": map[string]any{"name": "botkube"},
+		},
+		"other-tenant-channel": {
+			"channel": map[string]any{"name": "team-a-alerts"},
+		},
+		"cluster-scoped-resources": {
+			"executors": map[string]any{
+				"kubectl": map[string]any{"enabled": true, "resources": []any{"pods", "nodes", "namespaces", "clusterroles.rbac.authorization.k8s.io"}},
+			},
+		},
+		"secrets": {
+			"executors": map[string]any{
+				"kubectl": map[string]any{"enabled": true, "resources": []any{"secrets"}},
+			},
+		},
+		"unknown-resource": {
+			"executors": map[string]any{
+				"kubectl": map[string]any{"enabled": true, "resources": []any{"unknowns"}},
+			},
+		},
+	} {
+		obj := fixObj(TenantBindingKind, "team-b", name, map[string]any{
+			"communicationGroup": "default-group",
+			"platform":           "socketSlack",
+			"channel":            map[string]any{"name": "team-b-" + name},
+			"kubernetes":         map[string]any{"resources": []any{map[string]any{"type": "v1/pods"}}},
+		})
+		for key, val := range spec {
+			require.NoError(t, unstructured.SetNestedField(obj.Object, val, "spec", key))
+		}
+		require.NoError(t, cli.Tracker().Add(obj))
+	}
+	provider := NewProvider(loggerx.NewNoop(), staticProvider{[]byte(baseCfg)}, cli, newFakeMapper(), config.ConfigCRDs{Enabled: true, CommunicationGroups: []string{"default-group"}})
+
+	// when
+	files, err := provider.Configs(context.Background())
+
+	// then
+	require.NoError(t, err)
+	cfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	source := cfg.Sources["team-a/tenant-alerts"]
+	assert.Equal(t, "team-a", source.Kubernetes.TenantNamespace)
+	assert.Equal(t, []string{"^team-a$"}, source.Kubernetes.Namespaces.Include)
+
+	executor := cfg.Executors["team-a/tenant-alerts"]
+	assert.True(t, executor.Kubectl.Enabled)
+	assert.Equal(t, []string{"^team-a$"}, executor.Kubectl.Namespaces.Include)
+	assert.Equal(t, []string{"get", "describe", "logs", "top", "explain", "api-resources"}, executor.Kubectl.Commands.Verbs)
+	assert.Equal(t, []string{"pods", "deployments.apps"}, executor.Kubectl.Commands.Resources)
+
+	channel := cfg.Communications["default-group"].SocketSlack.Channels["team-a/tenant-alerts"]
+	assert.Equal(t, "team-a-alerts", channel.Name)
+	assert.Equal(t, []string{"team-a/tenant-alerts"}, channel.Bindings.Sources)
+	assert.Equal(t, []string{"team-a/tenant-alerts"}, channel.Bindings.Executors)
+
+	assert.NotContains(t, cfg.Sources, "team-b/tenant-all-namespaces")
+	assert.NotContains(t, cfg.Sources, "team-b/tenant-resource-namespaces")
+
+	assertReady(t, cli, TenantBindingKind, "team-a", "alerts", metav1.ConditionTrue, validReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "all-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "resource-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "label-value-from", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "event-namespaces", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "channel-name-object", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "not-allowed-group", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "admin-channel", metav1.ConditionFalse, conflictReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "other-tenant-channel", metav1.ConditionFalse, conflictReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "cluster-scoped-resources", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "secrets", metav1.ConditionFalse, invalidSpecReason)
+	assertReady(t, cli, TenantBindingKind, "team-b", "unknown-resource", metav1.ConditionFalse, invalidSpecReason)
+}
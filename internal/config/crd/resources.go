@@ -0,0 +1,313 @@
+package crd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/strings/slices"
+)
+
+// deniedKubectlResources holds the resources which cannot be used by kubectl executors defined by custom resources, even if they are namespaced.
+var deniedKubectlResources = map[schema.GroupResource]struct{}{
+	{Resource: "secrets"}: {},
+}
+
+// GroupVersion is the API group and version of the Botkube configuration custom resources.
+var GroupVersion = schema.GroupVersion{Group: "config.botkube.io", Version: "v1alpha1"}
+
+// Kind is the kind of the Botkube configuration custom resource.
+type Kind string
+
+const (
+	// SourceKind represents the Source custom resource, which defines a single entry of the `sources` configuration.
+	SourceKind Kind = "Source"
+	// ExecutorBindingKind represents the ExecutorBinding custom resource, which defines a single entry of the `executors` configuration.
+	ExecutorBindingKind Kind = "ExecutorBinding"
+	// ActionKind represents the Action custom resource, which defines a single entry of the `actions` configuration.
+	ActionKind Kind = "Action"
+	// AliasKind represents the Alias custom resource, which defines a single entry of the `aliases` configuration.
+	AliasKind Kind = "Alias"
+	// ChannelKind represents the Channel custom resource, which defines a single channel of a given communication platform.
+	ChannelKind Kind = "Channel"
+	// TenantBindingKind represents the TenantBinding custom resource, which defines a channel together with its own source and read-only executor.
+	// It has a restricted schema, so it can be owned by a tenant team. The source never matches resources outside the TenantBinding namespace.
+	TenantBindingKind Kind = "TenantBinding"
+)
+
+// orderedKinds holds all kinds in the order in which they are merged. Sources and executors go first, as actions and channels refer to them.
+var orderedKinds = []Kind{SourceKind, ExecutorBindingKind, AliasKind, ActionKind, ChannelKind, TenantBindingKind}
+
+var resourceForKind = map[Kind]string{
+	SourceKind:          "sources",
+	ExecutorBindingKind: "executorbindings",
+	ActionKind:          "actions",
+	AliasKind:           "aliases",
+	ChannelKind:         "channels",
+	TenantBindingKind:   "tenantbindings",
+}
+
+// channelPlatforms holds the communication platforms which support channel configuration.
+var channelPlatforms = map[string]struct{}{
+	"slack":       {},
+	"socketSlack": {},
+	"mattermost":  {},
+	"discord":     {},
+	"rocketChat":  {},
+	"googleChat":  {},
+}
+
+// GVR returns the GroupVersionResource for a given kind.
+func (k Kind) GVR() schema.GroupVersionResource {
+	return GroupVersion.WithResource(resourceForKind[k])
+}
+
+// configKey returns the key under which the configuration defined by a given namespaced custom resource is merged.
+func configKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+}
+
+// bindingsResolver replaces the names of sources and executors defined in the same namespace with their configuration keys.
+// Sources and executors have to be defined in the same namespace, so the custom resources cannot bind the ones from the global configuration.
+type bindingsResolver struct {
+	sources   map[string]struct{}
+	executors map[string]struct{}
+}
+
+func (r bindingsResolver) resolve(namespace string, in any, known map[string]struct{}, namespaceOnly bool) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	items, ok := in.([]any)
+	if !ok {
+		return nil, errors.New("bindings must be a list of names")
+	}
+
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		name, ok := item.(string)
+		if !ok {
+			return nil, errors.New("bindings must be a list of names")
+		}
+		nsKey := fmt.Sprintf("%s/%s", namespace, name)
+		_, found := known[nsKey]
+		switch {
+		case found:
+			name = nsKey
+		case namespaceOnly:
+			return nil, fmt.Errorf("%q is not defined in the %q namespace", name, namespace)
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+func (r bindingsResolver) resolveBindings(namespace string, spec map[string]any) error {
+	rawBindings, found := spec["bindings"]
+	if !found {
+		return nil
+	}
+	bindings, ok := rawBindings.(map[string]any)
+	if !ok {
+		return errors.New("bindings must be an object")
+	}
+
+	sources, err := r.resolve(namespace, bindings["sources"], r.sources, true)
+	if err != nil {
+		return fmt.Errorf("invalid bindings.sources: %w", err)
+	}
+	executors, err := r.resolve(namespace, bindings["executors"], r.executors, true)
+	if err != nil {
+		return fmt.Errorf("invalid bindings.executors: %w", err)
+	}
+
+	if sources != nil {
+		bindings["sources"] = sources
+	}
+	if executors != nil {
+		bindings["executors"] = executors
+	}
+	return nil
+}
+
+// toConfig returns the Botkube configuration defined by a given custom resource.
+func toConfig(kind Kind, obj *unstructured.Unstructured, resolver bindingsResolver, mapper meta.RESTMapper) (map[string]any, error) {
+	spec, found, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return nil, fmt.Errorf("while getting spec: %w", err)
+	}
+	if !found {
+		return nil, errors.New("spec is required")
+	}
+
+	switch kind {
+	case SourceKind:
+		return sourceToConfig(obj, spec)
+	case ExecutorBindingKind:
+		return executorBindingToConfig(obj, spec, mapper)
+	case AliasKind:
+		// aliases are global commands, so they are not prefixed with namespace
+		return map[string]any{"aliases": map[string]any{obj.GetName(): spec}}, nil
+	case ActionKind:
+		if err := resolver.resolveBindings(obj.GetNamespace(), spec); err != nil {
+			return nil, err
+		}
+		return map[string]any{"actions": map[string]any{configKey(obj): spec}}, nil
+	case ChannelKind:
+		return channelToConfig(obj, spec, resolver)
+	case TenantBindingKind:
+		return tenantBindingToConfig(obj, spec, mapper)
+	default:
+		return nil, fmt.Errorf("unknown kind %q", kind)
+	}
+}
+
+// sourceToConfig returns the source configuration limited to the Source namespace. Namespace constraints cannot be set,
+// and source plugins are not supported, as they cannot be limited to a single namespace.
+func sourceToConfig(obj *unstructured.Unstructured, spec map[string]any) (map[string]any, error) {
+	if err := checkAllowedProperties(spec, "displayName", "kubernetes"); err != nil {
+		return nil, err
+	}
+	kubernetes, err := nestedObject(spec, "kubernetes")
+	if err != nil {
+		return nil, err
+	}
+
+	ns := obj.GetNamespace()
+	if _, found := kubernetes["namespaces"]; found {
+		return nil, fmt.Errorf("spec.kubernetes.namespaces cannot be set, as the source is limited to the %q namespace", ns)
+	}
+	resources, _ := kubernetes["resources"].([]any)
+	for idx, item := range resources {
+		resource, _ := item.(map[string]any)
+		if _, found := resource["namespaces"]; found {
+			return nil, fmt.Errorf("spec.kubernetes.resources[%d].namespaces cannot be set, as the source is limited to the %q namespace", idx, ns)
+		}
+	}
+
+	kubernetes["namespaces"] = namespaceOnlyConstraints(ns)
+	// excludes cluster-scoped resources
+	kubernetes["tenantNamespace"] = ns
+
+	return map[string]any{"sources": map[string]any{configKey(obj): spec}}, nil
+}
+
+// executorBindingToConfig returns the executor configuration limited to the ExecutorBinding namespace. Namespace constraints cannot be set,
+// and executor plugins are not supported, as they cannot be limited to a single namespace.
+func executorBindingToConfig(obj *unstructured.Unstructured, spec map[string]any, mapper meta.RESTMapper) (map[string]any, error) {
+	if err := checkAllowedProperties(spec, "kubectl", "authorization"); err != nil {
+		return nil, err
+	}
+	kubectl, err := nestedObject(spec, "kubectl")
+	if err != nil {
+		return nil, err
+	}
+
+	ns := obj.GetNamespace()
+	for _, key := range []string{"namespaces", "defaultNamespace"} {
+		if _, found := kubectl[key]; found {
+			return nil, fmt.Errorf("spec.kubectl.%s cannot be set, as the executor is limited to the %q namespace", key, ns)
+		}
+	}
+	resources, _, err := unstructured.NestedStringSlice(kubectl, "commands", "resources")
+	if err != nil {
+		return nil, fmt.Errorf("while getting spec.kubectl.commands.resources: %w", err)
+	}
+	if err := checkNamespacedResources(mapper, resources); err != nil {
+		return nil, err
+	}
+	kubectl["namespaces"] = namespaceOnlyConstraints(ns)
+	kubectl["defaultNamespace"] = ns
+
+	return map[string]any{"executors": map[string]any{configKey(obj): spec}}, nil
+}
+
+// checkAllowedProperties returns an error if a given spec has properties other than the allowed ones.
+func checkAllowedProperties(spec map[string]any, allowed ...string) error {
+	for key := range spec {
+		if !slices.Contains(allowed, key) {
+			return fmt.Errorf("spec.%s is not supported, only %s can be set", key, strings.Join(allowed, " and "))
+		}
+	}
+	return nil
+}
+
+// nestedObject returns the object under a given key. If it doesn't exist, an empty object is set.
+func nestedObject(spec map[string]any, key string) (map[string]any, error) {
+	raw, found := spec[key]
+	if !found || raw == nil {
+		out := map[string]any{}
+		spec[key] = out
+		return out, nil
+	}
+	out, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("spec.%s must be an object", key)
+	}
+	return out, nil
+}
+
+// checkNamespacedResources returns an error if a given kubectl resource is unknown, cluster-scoped or not allowed for custom resources.
+// The executors defined by custom resources are limited to a single namespace, so cluster-scoped resources would leak data from the whole cluster.
+func checkNamespacedResources(mapper meta.RESTMapper, resources []string) error {
+	for _, name := range resources {
+		gvr, err := mapper.ResourceFor(schema.ParseGroupResource(name).WithVersion(""))
+		if err != nil {
+			return fmt.Errorf("while resolving kubectl resource %q: %w", name, err)
+		}
+		if _, denied := deniedKubectlResources[gvr.GroupResource()]; denied {
+			return fmt.Errorf("kubectl resource %q is not allowed for custom resources", name)
+		}
+
+		gvk, err := mapper.KindFor(gvr)
+		if err != nil {
+			return fmt.Errorf("while resolving kind for kubectl resource %q: %w", name, err)
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("while getting mapping for kubectl resource %q: %w", name, err)
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return fmt.Errorf("kubectl resource %q is cluster-scoped, only namespaced resources can be set", name)
+		}
+	}
+	return nil
+}
+
+func namespaceOnlyConstraints(ns string) map[string]any {
+	return map[string]any{"include": []any{fmt.Sprintf("^%s$", regexp.QuoteMeta(ns))}}
+}
+
+func channelToConfig(obj *unstructured.Unstructured, spec map[string]any, resolver bindingsResolver) (map[string]any, error) {
+	commGroup, _ := spec["communicationGroup"].(string)
+	if commGroup == "" {
+		return nil, errors.New("spec.communicationGroup is required")
+	}
+	platform, _ := spec["platform"].(string)
+	if _, found := channelPlatforms[platform]; !found {
+		return nil, fmt.Errorf("spec.platform %q is not supported", platform)
+	}
+	channel, ok := spec["channel"].(map[string]any)
+	if !ok {
+		return nil, errors.New("spec.channel is required")
+	}
+	if err := resolver.resolveBindings(obj.GetNamespace(), channel); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"communications": map[string]any{
+			commGroup: map[string]any{
+				platform: map[string]any{
+					"channels": map[string]any{
+						configKey(obj): channel,
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,165 @@
+package crd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// tenantReadOnlyVerbs holds the kubectl verbs allowed for tenant executors.
+var tenantReadOnlyVerbs = []string{"get", "describe", "logs", "top", "explain", "api-resources"}
+
+// tenantBindingSpec is the restricted TenantBinding spec. Unknown properties and values of unexpected types,
+// e.g. `valueFrom` references, are rejected, so the tenant cannot widen the scope of the source and executor.
+type tenantBindingSpec struct {
+	CommunicationGroup string           `yaml:"communicationGroup"`
+	Platform           string           `yaml:"platform"`
+	Channel            *tenantChannel   `yaml:"channel"`
+	Kubernetes         *tenantK8sSource `yaml:"kubernetes"`
+	Executors          tenantExecutors  `yaml:"executors"`
+}
+
+type tenantChannel struct {
+	Name         string                      `yaml:"name"`
+	Notification *config.ChannelNotification `yaml:"notification,omitempty"`
+}
+
+type tenantK8sSource struct {
+	Event       *config.KubernetesEvent `yaml:"event,omitempty"`
+	Resources   []tenantResource        `yaml:"resources,omitempty"`
+	Labels      map[string]string       `yaml:"labels,omitempty"`
+	Annotations map[string]string       `yaml:"annotations,omitempty"`
+}
+
+type tenantResource struct {
+	Type          string                   `yaml:"type"`
+	Name          *config.RegexConstraints `yaml:"name,omitempty"`
+	Event         *config.KubernetesEvent  `yaml:"event,omitempty"`
+	UpdateSetting *config.UpdateSetting    `yaml:"updateSetting,omitempty"`
+	Labels        map[string]string        `yaml:"labels,omitempty"`
+	Annotations   map[string]string        `yaml:"annotations,omitempty"`
+}
+
+type tenantExecutors struct {
+	Kubectl tenantKubectl `yaml:"kubectl"`
+}
+
+type tenantKubectl struct {
+	Enabled   bool     `yaml:"enabled"`
+	Resources []string `yaml:"resources"`
+}
+
+// tenantBindingToConfig returns the channel, source and executor configuration for a given TenantBinding.
+// The source and executor are limited to the TenantBinding namespace.
+func tenantBindingToConfig(obj *unstructured.Unstructured, rawSpec map[string]any, mapper meta.RESTMapper) (map[string]any, error) {
+	var spec tenantBindingSpec
+	if err := decodeTenantSpec(rawSpec, &spec); err != nil {
+		return nil, err
+	}
+
+	if spec.CommunicationGroup == "" {
+		return nil, errors.New("spec.communicationGroup is required")
+	}
+	if _, found := channelPlatforms[spec.Platform]; !found {
+		return nil, fmt.Errorf("spec.platform %q is not supported", spec.Platform)
+	}
+	if spec.Channel == nil || spec.Channel.Name == "" {
+		return nil, errors.New("spec.channel.name is required")
+	}
+	if spec.Kubernetes == nil {
+		return nil, errors.New("spec.kubernetes is required")
+	}
+
+	ns := obj.GetNamespace()
+	key := fmt.Sprintf("%s/tenant-%s", ns, obj.GetName())
+	namespaces := namespaceOnlyConstraints(ns)
+
+	kubernetes := map[string]any{
+		"namespaces":      namespaces,
+		"tenantNamespace": ns,
+	}
+	if spec.Kubernetes.Event != nil {
+		kubernetes["event"] = spec.Kubernetes.Event
+	}
+	if spec.Kubernetes.Resources != nil {
+		kubernetes["resources"] = spec.Kubernetes.Resources
+	}
+	if spec.Kubernetes.Labels != nil {
+		kubernetes["labels"] = spec.Kubernetes.Labels
+	}
+	if spec.Kubernetes.Annotations != nil {
+		kubernetes["annotations"] = spec.Kubernetes.Annotations
+	}
+
+	out := map[string]any{
+		"sources": map[string]any{
+			key: map[string]any{
+				"displayName": fmt.Sprintf("%s/%s tenant", ns, obj.GetName()),
+				"kubernetes":  kubernetes,
+			},
+		},
+	}
+
+	bindings := map[string]any{"sources": []string{key}}
+	if kubectl := spec.Executors.Kubectl; kubectl.Enabled {
+		if err := checkNamespacedResources(mapper, kubectl.Resources); err != nil {
+			return nil, err
+		}
+		resources := kubectl.Resources
+		if resources == nil {
+			resources = []string{}
+		}
+		out["executors"] = map[string]any{
+			key: map[string]any{
+				"kubectl": map[string]any{
+					"enabled":          true,
+					"restrictAccess":   true,
+					"defaultNamespace": ns,
+					"namespaces":       namespaces,
+					"commands": map[string]any{
+						"verbs":     tenantReadOnlyVerbs,
+						"resources": resources,
+					},
+				},
+			},
+		}
+		bindings["executors"] = []string{key}
+	}
+
+	channel := map[string]any{
+		"name":     spec.Channel.Name,
+		"bindings": bindings,
+	}
+	if spec.Channel.Notification != nil {
+		channel["notification"] = spec.Channel.Notification
+	}
+	out["communications"] = map[string]any{
+		spec.CommunicationGroup: map[string]any{
+			spec.Platform: map[string]any{
+				"channels": map[string]any{key: channel},
+			},
+		},
+	}
+	return out, nil
+}
+
+// decodeTenantSpec decodes a given spec. It returns an error if the spec has properties which are not allowed, or values of unexpected types.
+func decodeTenantSpec(in map[string]any, out *tenantBindingSpec) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		TagName:     "yaml",
+		Result:      out,
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(in); err != nil {
+		return fmt.Errorf("invalid spec: %w", err)
+	}
+	return nil
+}
@@ -20,9 +20,14 @@ func NewFileSystemProvider(configs []string) *FileSystemProvider {
 	return &FileSystemProvider{Files: configs}
 }
 
+// Paths returns config file locations in the order in which the files are merged.
+func (e *FileSystemProvider) Paths() []string {
+	return sortCfgFiles(e.Files)
+}
+
 // Configs returns list of config file locations.
 func (e *FileSystemProvider) Configs(_ context.Context) (YAMLFiles, error) {
-	configPaths := sortCfgFiles(e.Files)
+	configPaths := e.Paths()
 
 	var out YAMLFiles
 	for _, path := range configPaths {
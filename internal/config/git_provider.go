@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// GitProviderRepositoryEnvKey defines the Git repository URL with the Botkube configuration. If set, the Git provider is used.
+	GitProviderRepositoryEnvKey = "BOTKUBE_CONFIG_GIT_REPOSITORY"
+	// GitProviderRevisionEnvKey defines the Git branch, tag or commit to check out. Defaults to the remote HEAD.
+	GitProviderRevisionEnvKey = "BOTKUBE_CONFIG_GIT_REVISION"
+	// GitProviderPathEnvKey defines the directory within the repository, from which the configuration files are read.
+	GitProviderPathEnvKey = "BOTKUBE_CONFIG_GIT_PATH"
+	// GitProviderDirEnvKey defines the local directory, to which the repository is cloned.
+	GitProviderDirEnvKey = "BOTKUBE_CONFIG_GIT_DIR"
+
+	defaultGitRevision = "HEAD"
+	gitBinary          = "git"
+)
+
+// RevisionProvider is implemented by providers which know the revision of the configuration they returned.
+type RevisionProvider interface {
+	// Revision returns the revision of the configuration returned by the last successful Configs call.
+	Revision() string
+}
+
+// GitProviderOptions holds the GitProvider configuration.
+type GitProviderOptions struct {
+	// Repository is the URL of the Git repository. Local paths and file:// URLs are supported as well.
+	Repository string
+	// Revision is a branch, tag or commit to check out. Defaults to the remote HEAD.
+	Revision string
+	// Path is the directory within the repository with the configuration files. Defaults to the repository root.
+	Path string
+	// Dir is the local directory to which the repository is cloned.
+	Dir string
+}
+
+// GitProvider reads the configuration files from a Git repository.
+// Every Configs call fetches the latest commit of a given revision,
+// so periodic calls, or calls triggered by a webhook, pick up new commits.
+type GitProvider struct {
+	opts GitProviderOptions
+
+	mu       sync.Mutex
+	revision string
+}
+
+// NewGitProvider returns a new GitProvider instance.
+func NewGitProvider(opts GitProviderOptions) *GitProvider {
+	if opts.Revision == "" {
+		opts.Revision = defaultGitRevision
+	}
+	if opts.Dir == "" {
+		opts.Dir = filepath.Join(os.TempDir(), "botkube-config")
+	}
+	return &GitProvider{opts: opts}
+}
+
+// NewGitProviderFromEnv returns a new GitProvider instance configured with environment variables.
+func NewGitProviderFromEnv() *GitProvider {
+	return NewGitProvider(GitProviderOptions{
+		Repository: os.Getenv(GitProviderRepositoryEnvKey),
+		Revision:   os.Getenv(GitProviderRevisionEnvKey),
+		Path:       os.Getenv(GitProviderPathEnvKey),
+		Dir:        os.Getenv(GitProviderDirEnvKey),
+	})
+}
+
+// Configs fetches the latest commit and returns the YAML files from the configured path.
+// Files are sorted by name, and the files prefixed with specialConfigFileNamePrefix are returned at the end.
+func (g *GitProvider) Configs(ctx context.Context) (YAMLFiles, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.sync(ctx); err != nil {
+		return nil, fmt.Errorf("while synchronizing Git repository %q: %w", g.repositoryURL(), err)
+	}
+
+	sha, err := g.git(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("while getting checked out commit: %w", err)
+	}
+
+	paths, err := g.configPaths()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no configuration files found in %q directory of Git repository %q", g.opts.Path, g.repositoryURL())
+	}
+
+	files, err := NewFileSystemProvider(paths).Configs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.revision = sha
+	return files, nil
+}
+
+// Revision returns the SHA of the commit from which the configuration was read by the last successful Configs call.
+func (g *GitProvider) Revision() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.revision
+}
+
+func (g *GitProvider) sync(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(g.opts.Dir, ".git")); os.IsNotExist(err) {
+		if _, err := g.run(ctx, "init", "-q", g.opts.Dir); err != nil {
+			return fmt.Errorf("while initializing repository: %w", err)
+		}
+		if _, err := g.git(ctx, "remote", "add", "origin", g.opts.Repository); err != nil {
+			return fmt.Errorf("while adding remote: %w", err)
+		}
+	}
+
+	if _, err := g.git(ctx, "fetch", "-q", "--depth", "1", "origin", g.opts.Revision); err != nil {
+		return fmt.Errorf("while fetching %q revision: %w", g.opts.Revision, err)
+	}
+	if _, err := g.git(ctx, "checkout", "-q", "--force", "FETCH_HEAD"); err != nil {
+		return fmt.Errorf("while checking out %q revision: %w", g.opts.Revision, err)
+	}
+	return nil
+}
+
+func (g *GitProvider) configPaths() ([]string, error) {
+	dir := filepath.Join(g.opts.Dir, filepath.Clean("/"+g.opts.Path))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("while reading configuration directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+
+	return sortCfgFiles(paths), nil
+}
+
+func (g *GitProvider) git(ctx context.Context, args ...string) (string, error) {
+	return g.run(ctx, append([]string{"-C", g.opts.Dir}, args...)...)
+}
+
+func (g *GitProvider) run(ctx context.Context, args ...string) (string, error) {
+	//nolint:gosec // G204: Subprocess launched with a potential tainted input or cmd arguments
+	cmd := exec.CommandContext(ctx, gitBinary, args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, g.redactCredentials(strings.TrimSpace(stderr.String())))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// repositoryURL returns the repository URL without credentials, so it can be logged.
+func (g *GitProvider) repositoryURL() string {
+	u, err := url.Parse(g.opts.Repository)
+	if err != nil || u.User == nil {
+		// e.g. local paths or SCP-like SSH addresses, which don't contain secrets
+		return g.opts.Repository
+	}
+	u.User = nil
+	return u.String()
+}
+
+// redactCredentials removes the repository URL credentials from a given text, e.g. the git error output.
+func (g *GitProvider) redactCredentials(in string) string {
+	u, err := url.Parse(g.opts.Repository)
+	if err != nil || u.User == nil {
+		return in
+	}
+
+	in = strings.ReplaceAll(in, g.opts.Repository, g.repositoryURL())
+	in = strings.ReplaceAll(in, u.User.String()+"@", "")
+	if password, set := u.User.Password(); set && password != "" {
+		in = strings.ReplaceAll(in, password, "***")
+	}
+	return in
+}
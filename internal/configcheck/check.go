@@ -0,0 +1,230 @@
+package configcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"gopkg.in/yaml.v3"
+
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/internal/plugin"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// validationKeyRegex matches the struct namespace reported by config.ValidateStruct.
+var validationKeyRegex = regexp.MustCompile(`^Key: '([^']+)'`)
+
+// Options holds the configuration check options.
+type Options struct {
+	// Paths holds configuration file paths. Files are merged in the same order as by the FileSystemProvider,
+	// so the files prefixed with `_` are merged at the end.
+	Paths []string
+	// PluginIndexes holds local plugin repository index file paths indexed by the repository name.
+	// If set, configurations of enabled plugins are validated against JSON schemas defined in the indexes.
+	PluginIndexes map[string]string
+}
+
+// Issue describes a single configuration problem.
+type Issue struct {
+	// Location points to the configuration file line which the issue relates to, e.g. `values.yaml:12`.
+	// It's empty if the location is not known.
+	Location string
+	Message  string
+}
+
+// String returns the issue representation.
+func (i Issue) String() string {
+	if i.Location == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Location, i.Message)
+}
+
+// Result holds the configuration check result.
+type Result struct {
+	// Config is the merged configuration. It's nil if the configuration cannot be loaded.
+	Config   *config.Config
+	Warnings []Issue
+	Errors   []Issue
+}
+
+// IsValid returns true if there are no errors.
+func (r Result) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+// Run loads and validates the configuration in the same way as Botkube does on startup.
+func Run(ctx context.Context, opts Options) (Result, error) {
+	provider := intconfig.NewFileSystemProvider(opts.Paths)
+	files, err := provider.Configs(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("while loading configuration files: %w", err)
+	}
+
+	var result Result
+	locator := &locator{}
+	for idx, path := range provider.Paths() {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(files[idx], &doc); err != nil {
+			if config.IsTemplate(files[idx]) {
+				// templates are rendered when the configuration is loaded, so their issues are reported without location
+				continue
+			}
+			result.Errors = append(result.Errors, Issue{Location: path, Message: err.Error()})
+			continue
+		}
+		locator.files = append(locator.files, locatorFile{path: path, doc: &doc})
+	}
+	if !result.IsValid() {
+		return result, nil
+	}
+
+	cfg, details, err := config.LoadWithDefaults(files, config.WithValueFromPlaceholders())
+	if err != nil {
+		result.Errors = append(result.Errors, locator.issuesFor(err)...)
+		return result, nil
+	}
+	result.Config = cfg
+	result.Warnings = append(result.Warnings, locator.issuesFor(details.ValidateWarnings)...)
+
+	if len(opts.PluginIndexes) == 0 {
+		return result, nil
+	}
+
+	validator, err := newPluginSchemaValidator(opts.PluginIndexes)
+	if err != nil {
+		return Result{}, err
+	}
+	warns, criticals := validator.Validate(cfg)
+	result.Warnings = append(result.Warnings, locator.issuesFor(warns)...)
+	result.Errors = append(result.Errors, locator.issuesFor(criticals)...)
+
+	return result, nil
+}
+
+// Render returns the merged configuration in YAML format with sensitive data redacted.
+func Render(cfg config.Config) ([]byte, error) {
+	out, err := yaml.Marshal(config.Redacted(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling configuration: %w", err)
+	}
+	return out, nil
+}
+
+func newPluginSchemaValidator(indexPaths map[string]string) (*plugin.SchemaValidator, error) {
+	indexes := make(map[string][]byte, len(indexPaths))
+	for repo, path := range indexPaths {
+		raw, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("while reading %q plugin index: %w", repo, err)
+		}
+		indexes[repo] = raw
+	}
+
+	validator, err := plugin.NewSchemaValidator(indexes)
+	if err != nil {
+		return nil, fmt.Errorf("while loading plugin indexes: %w", err)
+	}
+	return validator, nil
+}
+
+type locatorFile struct {
+	path string
+	doc  *yaml.Node
+}
+
+// locator finds configuration file lines for the issues reported during configuration validation.
+type locator struct {
+	files []locatorFile
+}
+
+func (l *locator) issuesFor(err error) []Issue {
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	var merr *multierror.Error
+	if errors.As(err, &merr) {
+		errs = merr.Errors
+	}
+
+	var out []Issue
+	for _, e := range errs {
+		issue := Issue{Message: e.Error()}
+		if matches := validationKeyRegex.FindStringSubmatch(issue.Message); len(matches) == 2 {
+			issue.Location = l.locate(yamlPathForNamespace(matches[1]))
+		}
+		out = append(out, issue)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Message < out[j].Message
+	})
+	return out
+}
+
+// locate returns the location of the deepest node defined for a given path.
+// If the node is defined in multiple files, the last one wins, as it overrides the previous ones.
+func (l *locator) locate(path []string) string {
+	var (
+		location  string
+		bestDepth = 0
+	)
+	for _, file := range l.files {
+		node, depth := findNode(file.doc, path)
+		if node == nil || depth < bestDepth {
+			continue
+		}
+		bestDepth = depth
+		location = fmt.Sprintf("%s:%d", file.path, node.Line)
+	}
+	return location
+}
+
+// findNode returns the deepest node matching a given path together with the number of matched path elements.
+func findNode(doc *yaml.Node, path []string) (*yaml.Node, int) {
+	if doc == nil || len(doc.Content) == 0 {
+		return nil, 0
+	}
+
+	current := doc.Content[0]
+	var (
+		found *yaml.Node
+		depth int
+	)
+	for _, elem := range path {
+		next, keyNode := childNode(current, elem)
+		if next == nil {
+			break
+		}
+		found, current = keyNode, next
+		depth++
+	}
+	return found, depth
+}
+
+// childNode returns a child node for a given key together with the node which should be reported as its location.
+func childNode(parent *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	switch parent.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == key {
+				return parent.Content[i+1], parent.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		var idx int
+		if _, err := fmt.Sscanf(key, "%d", &idx); err != nil || idx < 0 || idx >= len(parent.Content) {
+			return nil, nil
+		}
+		return parent.Content[idx], parent.Content[idx]
+	}
+	return nil, nil
+}
@@ -0,0 +1,248 @@
+package configcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var validCfg = heredoc.Doc(`
+	communications:
+	  default-group:
+	    socketSlack:
+	      enabled: true
+	      botToken: xoxb-token
+	      appToken: xapp-token
+	      channels:
+	        default:
+	          name: botkube
+	          bindings:
+	            sources: [k8s-events]
+	sources:
+	  k8s-events:
+	    displayName: Events
+	executors:
+	  gh:
+	    botkube/gh:
+	      enabled: true
+	      config:
+	        github:
+	          token: gh-token
+	`)
+
+const ghIndex = `
+entries:
+  - name: gh
+    type: executor
+    description: GitHub executor
+    version: v1.0.0
+    urls:
+      - url: http://localhost/gh
+        platform:
+          os: linux
+          architecture: amd64
+    jsonSchema:
+      value: |
+        {
+          "type": "object",
+          "properties": {
+            "github": {
+              "type": "object",
+              "properties": {"token": {"type": "string"}, "repository": {"type": "string"}},
+              "required": ["repository"]
+            }
+          }
+        }
+`
+
+func TestRunSuccess(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	opts := Options{
+		Paths: []string{
+			writeFile(t, dir, "_overrides.yaml", "settings:\n  clusterName: from-special-file\n"),
+			writeFile(t, dir, "values.yaml", validCfg),
+			writeFile(t, dir, "cluster.yaml", "settings:\n  clusterName: from-ordinary-file\n"),
+		},
+	}
+
+	// when
+	result, err := Run(context.Background(), opts)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.IsValid())
+	assert.Empty(t, result.Errors)
+	require.NotNil(t, result.Config)
+	// special files are merged at the end
+	assert.Equal(t, "from-special-file", result.Config.Settings.ClusterName)
+}
+
+func TestRunDoesNotResolveValueFromRefs(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	opts := Options{
+		Paths: []string{
+			writeFile(t, dir, "values.yaml", validCfg),
+			writeFile(t, dir, "secrets.yaml", heredoc.Doc(`
+				communications:
+				  default-group:
+				    socketSlack:
+				      botToken:
+				        valueFrom:
+				          secretKeyRef:
+				            name: slack-creds
+				            namespace: botkube
+				            key: botToken
+				      appToken:
+				        valueFrom:
+				          envRef:
+				            name: CONFIGCHECK_TEST_NOT_SET
+				`)),
+		},
+	}
+
+	// when
+	result, err := Run(context.Background(), opts)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.IsValid())
+	require.NotNil(t, result.Config)
+	assert.Equal(t, config.ValueFromPlaceholder, result.Config.Communications["default-group"].SocketSlack.BotToken)
+	assert.Equal(t, config.ValueFromPlaceholder, result.Config.Communications["default-group"].SocketSlack.AppToken)
+}
+
+func TestRunReportsIssuesWithLocation(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	values := writeFile(t, dir, "values.yaml", validCfg)
+	overrides := writeFile(t, dir, "overrides.yaml", heredoc.Doc(`
+		communications:
+		  default-group:
+		    socketSlack:
+		      appToken: wrong-token
+		`))
+
+	// when
+	result, err := Run(context.Background(), Options{Paths: []string{values, overrides}})
+
+	// then
+	require.NoError(t, err)
+	assert.False(t, result.IsValid())
+	assert.Nil(t, result.Config)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, overrides+":4", result.Errors[0].Location)
+	assert.Contains(t, result.Errors[0].Message, "AppToken must have the xapp- prefix")
+}
+
+func TestRunReportsSyntaxErrors(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	invalid := writeFile(t, dir, "invalid.yaml", "settings:\n  clusterName: [\n")
+
+	// when
+	result, err := Run(context.Background(), Options{Paths: []string{invalid}})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, invalid, result.Errors[0].Location)
+	assert.Contains(t, result.Errors[0].Message, "yaml: line")
+}
+
+func TestRunValidatesPluginConfigs(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	opts := Options{
+		Paths: []string{writeFile(t, dir, "values.yaml", validCfg)},
+		PluginIndexes: map[string]string{
+			"botkube": writeFile(t, dir, "index.yaml", ghIndex),
+		},
+	}
+
+	// when
+	result, err := Run(context.Background(), opts)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "executors.gh.botkube/gh.config: github.repository in body is required", result.Errors[0].String())
+}
+
+func TestRunRendersTemplates(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	opts := Options{
+		Paths: []string{
+			writeFile(t, dir, "values.yaml", validCfg),
+			writeFile(t, dir, "cluster.yaml", "# botkube:template\nsettings:\n  clusterName: {{ env \"CONFIGCHECK_TEST_UNSET\" | default \"from-template\" }}\n"),
+		},
+	}
+
+	// when
+	result, err := Run(context.Background(), opts)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, result.IsValid())
+	require.NotNil(t, result.Config)
+	assert.Equal(t, "from-template", result.Config.Settings.ClusterName)
+}
+
+func TestRenderRedactsSecrets(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	result, err := Run(context.Background(), Options{Paths: []string{writeFile(t, dir, "values.yaml", validCfg)}})
+	require.NoError(t, err)
+
+	// when
+	out, err := Render(*result.Config)
+
+	// then
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "xoxb-token")
+	assert.NotContains(t, string(out), "xapp-token")
+	assert.NotContains(t, string(out), "gh-token")
+	assert.Contains(t, string(out), "*** REDACTED ***")
+}
+
+func TestYAMLPathForNamespace(t *testing.T) {
+	tests := []struct {
+		namespace string
+		expected  []string
+	}{
+		{
+			namespace: "Config.Communications[default-group].SocketSlack.AppToken",
+			expected:  []string{"communications", "default-group", "socketSlack", "appToken"},
+		},
+		{
+			namespace: "Config.Executors[gh].Plugins[botkube/gh@v1.0.0].Enabled",
+			expected:  []string{"executors", "gh", "botkube/gh@v1.0.0", "enabled"},
+		},
+		{
+			namespace: "Config.Settings.Unknown.Field",
+			expected:  []string{"settings"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.namespace, func(t *testing.T) {
+			assert.Equal(t, tc.expected, yamlPathForNamespace(tc.namespace))
+		})
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
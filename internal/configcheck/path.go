@@ -0,0 +1,116 @@
+package configcheck
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// yamlPathForNamespace converts the struct namespace reported by the validator, such as `Config.Communications[default-group].SocketSlack.BotToken`,
+// to the path of YAML keys, such as `communications`, `default-group`, `socketSlack`, `botToken`.
+// The conversion stops at the first element which cannot be mapped.
+func yamlPathForNamespace(namespace string) []string {
+	elems := splitNamespace(namespace)
+	if len(elems) > 0 && elems[0].name == reflect.TypeOf(config.Config{}).Name() {
+		elems = elems[1:]
+	}
+
+	var (
+		out []string
+		typ = reflect.TypeOf(config.Config{})
+	)
+	for _, elem := range elems {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+
+		if !elem.isIndex {
+			if typ.Kind() != reflect.Struct {
+				return out
+			}
+			field, found := typ.FieldByName(elem.name)
+			if !found {
+				return out
+			}
+			typ = field.Type
+
+			key, skip := yamlKeyForField(field)
+			if skip {
+				continue
+			}
+			out = append(out, key)
+			continue
+		}
+
+		switch typ.Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array:
+			typ = typ.Elem()
+			out = append(out, elem.name)
+		default:
+			return out
+		}
+	}
+	return out
+}
+
+// yamlKeyForField returns the YAML key for a given struct field. Inlined fields and fields which hold the remaining keys, such as plugins, are skipped.
+func yamlKeyForField(field reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if strings.Contains(opts, "inline") {
+		return "", true
+	}
+	if _, koanfOpts, _ := strings.Cut(field.Tag.Get("koanf"), ","); strings.Contains(koanfOpts, "remain") {
+		return "", true
+	}
+	if name != "" {
+		return name, false
+	}
+
+	// fields without tags are matched case-insensitively, so use the most common lowerCamelCase convention
+	first, size := utf8.DecodeRuneInString(field.Name)
+	return string(unicode.ToLower(first)) + field.Name[size:], false
+}
+
+type namespaceElem struct {
+	name    string
+	isIndex bool
+}
+
+// splitNamespace splits the struct namespace into field names and map keys or slice indexes.
+// Map keys are not split on dots, as they may contain them, e.g. `botkube/helm@v1.0.0`.
+func splitNamespace(namespace string) []namespaceElem {
+	var (
+		out  []namespaceElem
+		curr strings.Builder
+	)
+	flush := func() {
+		if curr.Len() > 0 {
+			out = append(out, namespaceElem{name: curr.String()})
+			curr.Reset()
+		}
+	}
+
+	for i := 0; i < len(namespace); i++ {
+		switch ch := namespace[i]; ch {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(namespace[i:], ']')
+			if end == -1 {
+				out = append(out, namespaceElem{name: namespace[i+1:], isIndex: true})
+				return out
+			}
+			out = append(out, namespaceElem{name: namespace[i+1 : i+end], isIndex: true})
+			i += end
+		default:
+			curr.WriteByte(ch)
+		}
+	}
+	flush()
+
+	return out
+}
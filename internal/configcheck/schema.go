@@ -0,0 +1,328 @@
+package configcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/internal/plugin"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const jsonSchemaDraftURL = "http://json-schema.org/draft-07/schema#"
+
+// enumValues holds allowed values for the configuration types which are not restricted with the `oneof` validation tag.
+var enumValues = map[reflect.Type][]any{
+	reflect.TypeOf(config.EventType("")): {
+		config.CreateEvent, config.UpdateEvent, config.DeleteEvent, config.ErrorEvent,
+		config.WarningEvent, config.NormalEvent, config.InfoEvent, config.AllEvent,
+	},
+	reflect.TypeOf(config.Level("")): {
+		config.Info, config.Warn, config.Debug, config.Error, config.Critical,
+	},
+	reflect.TypeOf(config.PolicySubjectType("")): {
+		config.EmptyPolicySubjectType, config.StaticPolicySubjectType, config.ChannelNamePolicySubjectType,
+	},
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// SchemaResult holds the JSON schema generation result.
+type SchemaResult struct {
+	Schema *spec.Schema
+	// Warnings describe enabled plugins which schemas couldn't be merged.
+	Warnings []Issue
+}
+
+// Schema returns the JSON schema of the Botkube configuration. If both configuration paths and plugin indexes are set,
+// JSON schemas of the plugins enabled in a given configuration are merged into the `config` properties of those plugins.
+func Schema(ctx context.Context, opts Options) (SchemaResult, error) {
+	result := SchemaResult{Schema: ConfigSchema()}
+	if len(opts.Paths) == 0 || len(opts.PluginIndexes) == 0 {
+		return result, nil
+	}
+
+	files, err := intconfig.NewFileSystemProvider(opts.Paths).Configs(ctx)
+	if err != nil {
+		return SchemaResult{}, fmt.Errorf("while loading configuration files: %w", err)
+	}
+	cfg, _, err := config.LoadWithDefaults(files, config.WithValueFromPlaceholders())
+	if err != nil {
+		return SchemaResult{}, fmt.Errorf("while loading configuration: %w", err)
+	}
+
+	validator, err := newPluginSchemaValidator(opts.PluginIndexes)
+	if err != nil {
+		return SchemaResult{}, err
+	}
+	schemas, warns := validator.EnabledPluginSchemas(cfg)
+	result.Warnings = (&locator{}).issuesFor(warns)
+
+	for _, pluginSchema := range schemas {
+		if err := mergePluginSchema(result.Schema, pluginSchema); err != nil {
+			return SchemaResult{}, fmt.Errorf("while merging JSON schema of %s: %w", pluginSchema.Path(), err)
+		}
+	}
+
+	return result, nil
+}
+
+// MarshalSchema returns a given JSON schema in the indented JSON format with the `$schema` keyword set.
+func MarshalSchema(schema *spec.Schema) ([]byte, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling JSON schema: %w", err)
+	}
+
+	// spec.Schema skips the `$schema` keyword during marshaling
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("while unmarshaling JSON schema: %w", err)
+	}
+	out["$schema"] = jsonSchemaDraftURL
+
+	indented, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling JSON schema: %w", err)
+	}
+	return append(indented, '\n'), nil
+}
+
+// ConfigSchema returns the JSON schema generated from the Botkube configuration types.
+//
+// The schema reflects the `yaml` field names and the basic `validate` rules, such as `required`, `min`, `max` and `oneof`.
+// Conditional rules, such as `required_if`, are checked only when the configuration is loaded.
+// Unknown properties are allowed, as they are ignored when the configuration is loaded.
+func ConfigSchema() *spec.Schema {
+	out := schemaForType(reflect.TypeOf(config.Config{}))
+	out.Title = "Botkube configuration"
+	return &out
+}
+
+func schemaForType(typ reflect.Type) spec.Schema {
+	if values, ok := enumValues[typ]; ok {
+		out := *spec.StringProperty()
+		out.Enum = values
+		return out
+	}
+	if typ == durationType {
+		// durations can be also specified in nanoseconds
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string", "integer"}}}
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return schemaForType(typ.Elem())
+	case reflect.Bool:
+		return *spec.BoolProperty()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"integer"}}}
+	case reflect.Float32, reflect.Float64:
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"number"}}}
+	case reflect.String:
+		// numbers are converted to strings when the configuration is loaded, e.g. ports or chat IDs
+		return spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"string", "number"}}}
+	case reflect.Slice, reflect.Array:
+		items := schemaForType(typ.Elem())
+		return *spec.ArrayProperty(&items)
+	case reflect.Map:
+		values := schemaForType(typ.Elem())
+		return *spec.MapProperty(&values)
+	case reflect.Struct:
+		return schemaForStruct(typ)
+	default:
+		// e.g. plugin configuration, which can be of any type
+		return spec.Schema{}
+	}
+}
+
+func schemaForStruct(typ reflect.Type) spec.Schema {
+	out := spec.Schema{SchemaProps: spec.SchemaProps{
+		Type:       []string{"object"},
+		Properties: map[string]spec.Schema{},
+	}}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, inline := fieldName(field)
+		switch {
+		case name == "-":
+			continue
+		case strings.Contains(field.Tag.Get("koanf"), ",remain"):
+			// all properties which are not mapped to other fields, e.g. plugins
+			values := schemaForType(field.Type.Elem())
+			out.AdditionalProperties = &spec.SchemaOrBool{Allows: true, Schema: &values}
+			continue
+		case inline:
+			embedded := schemaForType(field.Type)
+			for propName, prop := range embedded.Properties {
+				out.Properties[propName] = prop
+			}
+			out.Required = append(out.Required, embedded.Required...)
+			continue
+		}
+
+		prop := schemaForType(field.Type)
+		if required := applyValidateTag(&prop, field.Tag.Get("validate")); required {
+			out.Required = append(out.Required, name)
+		}
+		out.Properties[name] = prop
+	}
+
+	return out
+}
+
+// fieldName returns the configuration property name for a given field. Fields without the `yaml` tag
+// are matched case-insensitively when the configuration is loaded, so the lower camel case name is used.
+func fieldName(field reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	inline := strings.Contains(opts, "inline") || strings.Contains(field.Tag.Get("koanf"), ",squash")
+	if name == "" {
+		runes := []rune(field.Name)
+		runes[0] = unicode.ToLower(runes[0])
+		name = string(runes)
+	}
+	return name, inline
+}
+
+// applyValidateTag translates the validation rules into the JSON schema constraints.
+// Rules after `dive` apply to the array items or map values. It returns true if the property is required.
+func applyValidateTag(prop *spec.Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	var (
+		required  bool
+		omitEmpty bool
+		target    = prop
+	)
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			target = elementSchema(target)
+			if target == nil {
+				return required
+			}
+			omitEmpty = false
+		case "omitempty":
+			omitEmpty = true
+		case "required":
+			if target == prop {
+				required = true
+			}
+			setMin(target, 1)
+		case "min":
+			if n, err := strconv.ParseInt(param, 10, 64); err == nil {
+				setMin(target, n)
+			}
+		case "max":
+			if n, err := strconv.ParseInt(param, 10, 64); err == nil {
+				setMax(target, n)
+			}
+		case "oneof":
+			var values []any
+			for _, value := range strings.Fields(param) {
+				values = append(values, value)
+			}
+			if omitEmpty {
+				values = append(values, "")
+			}
+			target.Enum = values
+		}
+	}
+
+	return required
+}
+
+func elementSchema(in *spec.Schema) *spec.Schema {
+	switch {
+	case in.Items != nil && in.Items.Schema != nil:
+		return in.Items.Schema
+	case in.AdditionalProperties != nil && in.AdditionalProperties.Schema != nil:
+		return in.AdditionalProperties.Schema
+	default:
+		return nil
+	}
+}
+
+func setMin(in *spec.Schema, n int64) {
+	switch {
+	case in.Type.Contains("string"):
+		in.MinLength = &n
+	case in.Type.Contains("array"):
+		in.MinItems = &n
+	case in.Type.Contains("object") && len(in.Properties) == 0:
+		in.MinProperties = &n
+	case in.Type.Contains("integer"), in.Type.Contains("number"):
+		min := float64(n)
+		in.Minimum = &min
+	}
+}
+
+func setMax(in *spec.Schema, n int64) {
+	switch {
+	case in.Type.Contains("string"):
+		in.MaxLength = &n
+	case in.Type.Contains("array"):
+		in.MaxItems = &n
+	case in.Type.Contains("object") && len(in.Properties) == 0:
+		in.MaxProperties = &n
+	case in.Type.Contains("integer"), in.Type.Contains("number"):
+		max := float64(n)
+		in.Maximum = &max
+	}
+}
+
+// mergePluginSchema sets the plugin configuration schema for a given plugin in a given executor or source group.
+func mergePluginSchema(root *spec.Schema, pluginSchema plugin.EnabledPluginSchema) error {
+	var cfgSchema spec.Schema
+	if err := json.Unmarshal([]byte(pluginSchema.Value), &cfgSchema); err != nil {
+		return fmt.Errorf("while unmarshaling JSON schema: %w", err)
+	}
+
+	groupsName := fmt.Sprintf("%ss", pluginSchema.Type)
+	groups, ok := root.Properties[groupsName]
+	if !ok || groups.AdditionalProperties == nil || groups.AdditionalProperties.Schema == nil {
+		return fmt.Errorf("%q property not found", groupsName)
+	}
+
+	group, ok := groups.Properties[pluginSchema.Group]
+	if !ok {
+		group = *groups.AdditionalProperties.Schema
+	}
+	if group.AdditionalProperties == nil || group.AdditionalProperties.Schema == nil {
+		return fmt.Errorf("plugins are not supported in %q property", groupsName)
+	}
+
+	pluginProp := withProperty(*group.AdditionalProperties.Schema, "config", cfgSchema)
+	group = withProperty(group, pluginSchema.PluginKey, pluginProp)
+	groups = withProperty(groups, pluginSchema.Group, group)
+	*root = withProperty(*root, groupsName, groups)
+	return nil
+}
+
+// withProperty returns a copy of a given schema with a given property set. The input schema is not modified.
+func withProperty(in spec.Schema, name string, prop spec.Schema) spec.Schema {
+	props := make(map[string]spec.Schema, len(in.Properties)+1)
+	for key, value := range in.Properties {
+		props[key] = value
+	}
+	props[name] = prop
+	in.Properties = props
+	return in
+}
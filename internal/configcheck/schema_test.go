@@ -0,0 +1,113 @@
+package configcheck
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/golden"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// helmValuesSchemaPath is the JSON schema shipped with the Helm chart.
+// If the `-test.update-golden` flag is set, the schema is regenerated.
+const helmValuesSchemaPath = "../../helm/botkube/values.schema.json"
+
+func TestConfigSchema(t *testing.T) {
+	// when
+	schema := ConfigSchema()
+
+	// then
+	assert.Equal(t, []string{"communications"}, schema.Required)
+	communications := schema.Properties["communications"]
+	require.NotNil(t, communications.MinProperties)
+	assert.EqualValues(t, 1, *communications.MinProperties)
+
+	event := schema.Properties["sources"].AdditionalProperties.Schema.Properties["kubernetes"].Properties["event"]
+	assert.Equal(t, []any{"create", "update", "delete", "error", "warning", "normal", "info", "all"}, toJSONValues(t, event.Properties["types"].Items.Schema.Enum))
+
+	alertmanager := schema.Properties["communications"].AdditionalProperties.Schema.Properties["alertmanager"]
+	assert.Equal(t, []any{"info", "warn", "debug", "error", "critical"}, toJSONValues(t, alertmanager.Properties["levels"].Items.Schema.Enum))
+
+	alias := schema.Properties["aliases"].AdditionalProperties.Schema
+	assert.Equal(t, []string{"command"}, alias.Required)
+	require.NotNil(t, alias.Properties["command"].MinLength)
+	assert.EqualValues(t, 1, *alias.Properties["command"].MinLength)
+
+	plugin := schema.Properties["executors"].AdditionalProperties.Schema.AdditionalProperties.Schema
+	assert.Contains(t, plugin.Properties, "enabled")
+	assert.Contains(t, plugin.Properties, "config")
+	assert.Contains(t, plugin.Properties, "context")
+}
+
+func TestConfigSchemaMatchesHelmChart(t *testing.T) {
+	// given
+	absPath, err := filepath.Abs(helmValuesSchemaPath)
+	require.NoError(t, err)
+
+	// when
+	out, err := MarshalSchema(ConfigSchema())
+
+	// then
+	require.NoError(t, err)
+	golden.Assert(t, string(out), absPath)
+
+	rawValues, err := os.ReadFile(filepath.Join(filepath.Dir(absPath), "values.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, validateAgainstSchema(t, ConfigSchema(), rawValues))
+}
+
+func TestSchemaMergesEnabledPluginSchemas(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	opts := Options{
+		Paths: []string{writeFile(t, dir, "values.yaml", validCfg+"  unknown:\n    other/gh:\n      enabled: true\n")},
+		PluginIndexes: map[string]string{
+			"botkube": writeFile(t, dir, "index.yaml", ghIndex),
+		},
+	}
+
+	// when
+	result, err := Schema(context.Background(), opts)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0].String(), `executors.unknown.other/gh: plugin not found in "other" repository index`)
+
+	ghConfig := result.Schema.Properties["executors"].Properties["gh"].Properties["botkube/gh"].Properties["config"]
+	assert.Contains(t, ghConfig.Properties, "github")
+
+	issues := validateAgainstSchema(t, result.Schema, []byte("communications:\n  default-group: {}\nexecutors:\n  gh:\n    botkube/gh:\n      config:\n        github:\n          token: gh-token\n"))
+	require.Len(t, issues, 1)
+	assert.EqualError(t, issues[0], "executors.gh.botkube/gh.config.github.repository in body is required")
+
+	// the base schema is not modified
+	assert.NotContains(t, ConfigSchema().Properties["executors"].Properties, "gh")
+}
+
+func validateAgainstSchema(t *testing.T, schema *spec.Schema, rawYAML []byte) []error {
+	t.Helper()
+
+	var data any
+	require.NoError(t, yaml.Unmarshal(rawYAML, &data))
+	return validate.NewSchemaValidator(schema, nil, "", strfmt.Default).Validate(toJSONValues(t, data)).Errors
+}
+
+// toJSONValues normalizes a given value to JSON types.
+func toJSONValues(t *testing.T, in any) any {
+	t.Helper()
+
+	raw, err := json.Marshal(in)
+	require.NoError(t, err)
+	var out any
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
@@ -0,0 +1,173 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	intconfig "github.com/kubeshop/botkube/internal/config"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// ErrRestartRequired is returned by ApplyConfigFn when the configuration changes cannot be applied in-process.
+var ErrRestartRequired = errors.New("restart required")
+
+// ApplyConfigFn applies a given configuration to the running app. Only the components listed in the diff have to be rebuilt.
+type ApplyConfigFn func(ctx context.Context, cfg *config.Config, diff config.Diff) error
+
+// RestartFn restarts the app because of a given reason.
+type RestartFn func(ctx context.Context, reason string) error
+
+// Reloader watches the configuration sources, together with the values referenced via `valueFrom`, and applies the configuration changes without restarting the app.
+// If the changes cannot be applied in-process, e.g. bot credentials changed, it falls back to the app restart.
+type Reloader struct {
+	log       logrus.FieldLogger
+	provider  intconfig.Provider
+	interval  time.Duration
+	applyFn   ApplyConfigFn
+	restartFn RestartFn
+	trigger   chan struct{}
+
+	mu      sync.Mutex
+	current *config.Config
+	lastRaw []byte
+
+	revisionMu sync.RWMutex
+	revision   string
+}
+
+// NewReloader returns a new Reloader instance. The current configuration must be loaded from the given raw configuration files.
+func NewReloader(log logrus.FieldLogger, provider intconfig.Provider, cfg config.HotReload, current *config.Config, currentRaw intconfig.YAMLFiles, applyFn ApplyConfigFn, restartFn RestartFn) *Reloader {
+	r := &Reloader{
+		log:       log,
+		provider:  provider,
+		interval:  cfg.Interval,
+		applyFn:   applyFn,
+		restartFn: restartFn,
+		trigger:   make(chan struct{}, 1),
+		current:   current,
+		lastRaw:   currentRaw.Merge(),
+	}
+	r.setRevision()
+	return r
+}
+
+// Revision returns the revision of the applied configuration, if the provider reports it. Otherwise, it returns empty string.
+func (r *Reloader) Revision() string {
+	r.revisionMu.RLock()
+	defer r.revisionMu.RUnlock()
+	return r.revision
+}
+
+// Start checks the configuration sources periodically and on demand until the context is cancelled.
+func (r *Reloader) Start(ctx context.Context) error {
+	r.log.Infof("Watching configuration sources every %s...", r.interval)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-r.trigger:
+		}
+
+		if err := r.Reload(ctx); err != nil {
+			r.log.Errorf("while reloading configuration: %s", err.Error())
+		}
+	}
+}
+
+// Trigger schedules the configuration check, e.g. when the Config Watcher detected a change.
+func (r *Reloader) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+		// check is already scheduled
+	}
+}
+
+// Reload loads the configuration and applies the changes, if there are any.
+// If the new configuration is invalid, the current one is kept.
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files, err := r.provider.Configs(ctx)
+	if err != nil {
+		return fmt.Errorf("while loading configuration files: %w", err)
+	}
+
+	raw := files.Merge()
+	// referenced values, e.g. Secrets, may change even if the configuration sources are the same
+	if bytes.Equal(raw, r.lastRaw) && !config.HasValueFromRefs(files) {
+		r.setRevision()
+		return nil
+	}
+	r.lastRaw = raw
+
+	cfg, details, err := config.LoadWithDefaults(files)
+	if err != nil {
+		return fmt.Errorf("while merging app configuration: %w", err)
+	}
+	if details.ValidateWarnings != nil {
+		r.log.Warnf("Configuration validation warnings: %v", details.ValidateWarnings.Error())
+	}
+
+	diff := config.DiffConfigs(*r.current, *cfg)
+	if diff.IsEmpty() {
+		r.log.Debug("Loaded configuration is the same as the current one.")
+		r.current = cfg
+		r.setRevision()
+		return nil
+	}
+
+	if diff.RequiresRestart() {
+		return r.restart(ctx, strings.Join(diff.RestartReasons, ", "))
+	}
+
+	r.log.Infof("Applying configuration changes in-process. Rebuilding components: %v", diff.Components)
+	err = r.applyFn(ctx, cfg, diff)
+	switch {
+	case errors.Is(err, ErrRestartRequired):
+		return r.restart(ctx, err.Error())
+	case err != nil:
+		// retry on the next check
+		r.lastRaw = nil
+		return fmt.Errorf("while applying configuration: %w", err)
+	}
+
+	r.current = cfg
+	r.setRevision()
+	r.log.Info("Configuration reloaded successfully.")
+	return nil
+}
+
+// setRevision stores the revision of the configuration returned by the last provider call. It must be called only once the configuration is applied.
+func (r *Reloader) setRevision() {
+	revProvider, ok := r.provider.(intconfig.RevisionProvider)
+	if !ok {
+		return
+	}
+
+	r.revisionMu.Lock()
+	defer r.revisionMu.Unlock()
+	r.revision = revProvider.Revision()
+}
+
+func (r *Reloader) restart(ctx context.Context, reason string) error {
+	r.log.Infof("Configuration changes cannot be applied in-process (%s). Restarting...", reason)
+	if err := r.restartFn(ctx, reason); err != nil {
+		// retry on the next check
+		r.lastRaw = nil
+		return fmt.Errorf("while restarting app: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package lifecycle
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -24,14 +25,54 @@ const (
 type SendMessageFn func(msg string) error
 
 // NewServer creates a new httpsrv.Server that exposes lifecycle methods as HTTP endpoints.
-func NewServer(log logrus.FieldLogger, k8sCli kubernetes.Interface, cfg config.LifecycleServer, clusterName string, sendMsgFn SendMessageFn) *httpsrv.Server {
+// If reloader is provided, the reload requests are applied in-process instead of restarting the Deployment.
+func NewServer(log logrus.FieldLogger, k8sCli kubernetes.Interface, cfg config.LifecycleServer, clusterName string, sendMsgFn SendMessageFn, reloader *Reloader) *httpsrv.Server {
 	addr := fmt.Sprintf(":%d", cfg.Port)
 	router := mux.NewRouter()
 	reloadHandler := newReloadHandler(log, k8sCli, cfg.Deployment, clusterName, sendMsgFn)
+	if reloader != nil {
+		reloadHandler = newHotReloadHandler(log, reloader)
+	}
 	router.HandleFunc("/reload", reloadHandler)
 	return httpsrv.New(log, addr, router)
 }
 
+// RestartDeployment restarts a given Deployment. This is what `kubectl rollout restart` does.
+func RestartDeployment(ctx context.Context, k8sCli kubernetes.Interface, deploy config.K8sResourceRef) error {
+	restartData := fmt.Sprintf(k8sDeploymentRestartPatchFmt, time.Now().String())
+	_, err := k8sCli.AppsV1().Deployments(deploy.Namespace).Patch(
+		ctx,
+		deploy.Name,
+		types.StrategicMergePatchType,
+		[]byte(restartData),
+		metav1.PatchOptions{FieldManager: "kubectl-rollout"},
+	)
+	return err
+}
+
+// NewDeploymentRestartFn returns RestartFn which sends the reload message and restarts a given Deployment.
+func NewDeploymentRestartFn(k8sCli kubernetes.Interface, deploy config.K8sResourceRef, clusterName string, sendMsgFn SendMessageFn) RestartFn {
+	return func(ctx context.Context, _ string) error {
+		if err := sendMsgFn(fmt.Sprintf(reloadMsgFmt, clusterName)); err != nil {
+			return fmt.Errorf("while sending reload message: %w", err)
+		}
+		return RestartDeployment(ctx, k8sCli, deploy)
+	}
+}
+
+func newHotReloadHandler(log logrus.FieldLogger, reloader *Reloader) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		log.Info("Reload requested. Scheduling in-process configuration reload...")
+		reloader.Trigger()
+
+		writer.WriteHeader(http.StatusOK)
+		_, err := writer.Write([]byte("Configuration reload scheduled."))
+		if err != nil {
+			log.Errorf("while writing success response: %s", err.Error())
+		}
+	}
+}
+
 func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deploy config.K8sResourceRef, clusterName string, sendMsgFn SendMessageFn) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		log.Info("Reload requested. Sending last message before exit...")
@@ -44,16 +85,7 @@ func newReloadHandler(log logrus.FieldLogger, k8sCli kubernetes.Interface, deplo
 		}
 
 		log.Infof(`Reloading te the deployment "%s/%s"...`, deploy.Namespace, deploy.Name)
-		// This is what `kubectl rollout restart` does.
-		restartData := fmt.Sprintf(k8sDeploymentRestartPatchFmt, time.Now().String())
-		ctx := request.Context()
-		_, err = k8sCli.AppsV1().Deployments(deploy.Namespace).Patch(
-			ctx,
-			deploy.Name,
-			types.StrategicMergePatchType,
-			[]byte(restartData),
-			metav1.PatchOptions{FieldManager: "kubectl-rollout"},
-		)
+		err = RestartDeployment(request.Context(), k8sCli, deploy)
 		if err != nil {
 			errMsg := fmt.Sprintf("while restarting the Deployment: %s", err.Error())
 			log.Error(errMsg)
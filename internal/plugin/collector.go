@@ -68,6 +68,39 @@ func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, [
 				}
 			}
 		}
+
+		if commGroupCfg.Telegram.Enabled {
+			for _, bindings := range commGroupCfg.Telegram.Channels {
+				for _, name := range bindings.Bindings.Executors {
+					bindExecutors[name] = struct{}{}
+				}
+				for _, name := range bindings.Bindings.Sources {
+					bindSources[name] = struct{}{}
+				}
+			}
+		}
+
+		if commGroupCfg.GoogleChat.Enabled {
+			for _, bindings := range commGroupCfg.GoogleChat.Channels {
+				for _, name := range bindings.Bindings.Executors {
+					bindExecutors[name] = struct{}{}
+				}
+				for _, name := range bindings.Bindings.Sources {
+					bindSources[name] = struct{}{}
+				}
+			}
+		}
+
+		if commGroupCfg.RocketChat.Enabled {
+			for _, bindings := range commGroupCfg.RocketChat.Channels {
+				for _, name := range bindings.Bindings.Executors {
+					bindExecutors[name] = struct{}{}
+				}
+				for _, name := range bindings.Bindings.Sources {
+					bindSources[name] = struct{}{}
+				}
+			}
+		}
 	}
 
 	// Collect all executors that are both enabled and bind to at least one communicator that is enabled.
@@ -0,0 +1,154 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// SchemaValidator validates plugin configurations against JSON schemas defined in plugin repository indexes.
+type SchemaValidator struct {
+	executorsRepositories storeRepository
+	sourcesRepositories   storeRepository
+}
+
+// NewSchemaValidator returns a new SchemaValidator instance for given plugin indexes. The key is a repository name and the value is raw index data.
+func NewSchemaValidator(indexes map[string][]byte) (*SchemaValidator, error) {
+	executorsRepos, sourcesRepos, err := newStoreRepositories(indexes)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaValidator{
+		executorsRepositories: executorsRepos,
+		sourcesRepositories:   sourcesRepos,
+	}, nil
+}
+
+// EnabledPluginSchema holds the JSON schema of an enabled plugin configuration.
+type EnabledPluginSchema struct {
+	Type Type
+	// Group is the name of the executor or source group which enables the plugin.
+	Group string
+	// PluginKey is the plugin key in the {repo}/{plugin_name}[@{version}] format.
+	PluginKey string
+	// Value is the raw JSON schema.
+	Value string
+	// Config is the plugin configuration.
+	Config any
+}
+
+// Path returns the path of the plugin in the Botkube configuration, e.g. `executors.default.botkube/echo`.
+func (s EnabledPluginSchema) Path() string {
+	return fmt.Sprintf("%ss.%s.%s", s.Type, s.Group, s.PluginKey)
+}
+
+// Validate validates configuration of all enabled plugins. Plugins which are not found in the indexes,
+// or which define only a remote schema reference, are skipped and reported as warnings.
+func (v *SchemaValidator) Validate(cfg *config.Config) (warnings error, criticals error) {
+	schemas, warns := v.EnabledPluginSchemas(cfg)
+
+	issues := multierror.New()
+	for _, schema := range schemas {
+		for _, err := range validateAgainstJSONSchema(schema.Value, schema.Config) {
+			issues = multierror.Append(issues, fmt.Errorf("%s.config: %w", schema.Path(), err))
+		}
+	}
+
+	return warns, issues.ErrorOrNil()
+}
+
+// EnabledPluginSchemas returns JSON schemas of all enabled plugins sorted by their paths. Plugins which are not found in the indexes,
+// or which define only a remote schema reference, are skipped and reported as warnings.
+func (v *SchemaValidator) EnabledPluginSchemas(cfg *config.Config) ([]EnabledPluginSchema, error) {
+	var out []EnabledPluginSchema
+	warns := multierror.New()
+
+	collectGroup := func(repo storeRepository, typ Type, groupName string, plugins config.Plugins) {
+		for key, plugin := range plugins {
+			if !plugin.Enabled {
+				continue
+			}
+			schema := EnabledPluginSchema{Type: typ, Group: groupName, PluginKey: key, Config: plugin.Config}
+
+			entry, err := v.findEntry(repo, key)
+			if err != nil {
+				warns = multierror.Append(warns, fmt.Errorf("%s: %w", schema.Path(), err))
+				continue
+			}
+			if entry.JSONSchema.Value == "" {
+				if entry.JSONSchema.RefURL != "" {
+					warns = multierror.Append(warns, fmt.Errorf("%s: only remote JSON schema %q is defined, skipping", schema.Path(), entry.JSONSchema.RefURL))
+				}
+				continue
+			}
+
+			schema.Value = entry.JSONSchema.Value
+			out = append(out, schema)
+		}
+	}
+
+	for name, executor := range cfg.Executors {
+		collectGroup(v.executorsRepositories, TypeExecutor, name, executor.Plugins)
+	}
+	for name, source := range cfg.Sources {
+		collectGroup(v.sourcesRepositories, TypeSource, name, source.Plugins)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Path() < out[j].Path()
+	})
+	return out, warns.ErrorOrNil()
+}
+
+func (v *SchemaValidator) findEntry(repo storeRepository, pluginKey string) (storeEntry, error) {
+	repoName, pluginName, ver, err := config.DecomposePluginKey(pluginKey)
+	if err != nil {
+		return storeEntry{}, err
+	}
+
+	entries, found := repo.Get(repoName, pluginName)
+	if !found || len(entries) == 0 {
+		return storeEntry{}, fmt.Errorf("plugin not found in %q repository index, skipping", repoName)
+	}
+
+	// entries are sorted by version, the first one is the latest
+	if ver == "" {
+		return entries[0], nil
+	}
+	for _, entry := range entries {
+		if entry.Version == ver {
+			return entry, nil
+		}
+	}
+	return storeEntry{}, fmt.Errorf("plugin version %q not found in %q repository index, skipping", ver, repoName)
+}
+
+// validateAgainstJSONSchema returns all issues found during validation of a given plugin configuration.
+func validateAgainstJSONSchema(rawSchema string, in any) []error {
+	var schema spec.Schema
+	if err := json.Unmarshal([]byte(rawSchema), &schema); err != nil {
+		return []error{fmt.Errorf("while unmarshaling JSON schema: %w", err)}
+	}
+
+	// normalize the configuration to JSON types, e.g. int to float64
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return []error{fmt.Errorf("while marshaling configuration: %w", err)}
+	}
+	var data any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return []error{fmt.Errorf("while unmarshaling configuration: %w", err)}
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	return validate.NewSchemaValidator(&schema, nil, "", strfmt.Default).Validate(data).Errors
+}
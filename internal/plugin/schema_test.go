@@ -0,0 +1,79 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestSchemaValidatorValidate(t *testing.T) {
+	// given
+	index := heredoc.Doc(`
+		entries:
+		  - name: echo
+		    type: executor
+		    version: v1.0.0
+		    urls:
+		      - url: http://localhost/echo
+		        platform: {os: linux, architecture: amd64}
+		    jsonSchema:
+		      value: '{"type": "object", "properties": {"upper": {"type": "boolean"}}}'
+		  - name: echo
+		    type: executor
+		    version: v0.1.0
+		    urls:
+		      - url: http://localhost/echo
+		        platform: {os: linux, architecture: amd64}
+		    jsonSchema:
+		      value: '{"type": "object", "properties": {"upper": {"type": "string"}}}'
+		  - name: cm-watcher
+		    type: source
+		    version: v1.0.0
+		    urls:
+		      - url: http://localhost/cm-watcher
+		        platform: {os: linux, architecture: amd64}
+		    jsonSchema:
+		      refURL: http://localhost/cm-watcher/schema.json
+		`)
+	validator, err := NewSchemaValidator(map[string][]byte{"botkube": []byte(index)})
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Executors: map[string]config.Executors{
+			"latest": {Plugins: config.Plugins{
+				"botkube/echo": {Enabled: true, Config: map[string]any{"upper": "yes"}},
+			}},
+			"pinned": {Plugins: config.Plugins{
+				"botkube/echo@v0.1.0": {Enabled: true, Config: map[string]any{"upper": "yes"}},
+			}},
+			"disabled": {Plugins: config.Plugins{
+				"botkube/echo": {Enabled: false, Config: map[string]any{"upper": "yes"}},
+			}},
+			"unknown": {Plugins: config.Plugins{
+				"other/echo": {Enabled: true},
+			}},
+		},
+		Sources: map[string]config.Sources{
+			"cm": {Plugins: config.Plugins{
+				"botkube/cm-watcher": {Enabled: true},
+			}},
+		},
+	}
+
+	// when
+	warns, criticals := validator.Validate(cfg)
+
+	// then
+	require.Error(t, criticals)
+	assert.Contains(t, criticals.Error(), "executors.latest.botkube/echo.config: upper in body must be of type boolean")
+	assert.NotContains(t, criticals.Error(), "pinned")
+	assert.NotContains(t, criticals.Error(), "disabled")
+
+	require.Error(t, warns)
+	assert.Contains(t, warns.Error(), `executors.unknown.other/echo: plugin not found in "other" repository index`)
+	assert.Contains(t, warns.Error(), `sources.cm.botkube/cm-watcher: only remote JSON schema "http://localhost/cm-watcher/schema.json" is defined`)
+}
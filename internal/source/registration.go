@@ -162,6 +162,12 @@ func (r registration) sourcesForEvent(routes []route, event event.Event) ([]stri
 
 func (r registration) shouldSendEventToRoute(route route, event event.Event) (bool, error) {
 	log := r.log.WithField("route", route)
+	// tenant namespace
+	if route.tenantNamespace != "" && event.Namespace != route.tenantNamespace {
+		log.Debugf("Ignoring as namespace %q is outside the tenant namespace %q", event.Namespace, route.tenantNamespace)
+		return false, nil
+	}
+
 	// event reason
 	if route.event.Reason.AreConstraintsDefined() {
 		match, err := route.event.Reason.IsAllowed(event.Reason)
@@ -305,6 +305,44 @@ func TestSourcesForEvent(t *testing.T) {
 			},
 			ExpectedResult: []string{"success", "success-empty"},
 		},
+		{
+			Name: "Tenant namespace",
+			Routes: []route{
+				{
+					source:          "success",
+					namespaces:      allNsCfg,
+					tenantNamespace: "team-a",
+				},
+				{
+					source:          "fail-other-tenant",
+					namespaces:      allNsCfg,
+					tenantNamespace: "team-b",
+				},
+			},
+			Event: event.Event{
+				Name:      "test-one",
+				Namespace: "team-a",
+			},
+			ExpectedResult: []string{"success"},
+		},
+		{
+			Name: "Tenant namespace - cluster-scoped resource",
+			Routes: []route{
+				{
+					source:          "fail",
+					namespaces:      allNsCfg,
+					tenantNamespace: "team-a",
+				},
+				{
+					source:     "success",
+					namespaces: allNsCfg,
+				},
+			},
+			Event: event.Event{
+				Name: "node-one",
+			},
+			ExpectedResult: []string{"success"},
+		},
 		{
 			Name: "Labels",
 			Routes: []route{
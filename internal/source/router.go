@@ -27,6 +27,8 @@ type route struct {
 	namespaces    config.RegexConstraints
 	updateSetting config.UpdateSetting
 	event         config.KubernetesEvent
+	// tenantNamespace takes precedence over other constraints, so a tenant source never matches resources outside its namespace.
+	tenantNamespace string
 }
 
 func (r route) hasActionableUpdateSetting() bool {
@@ -68,9 +70,20 @@ func (r *Router) AddCommunicationsBindings(c config.Communications) {
 	r.AddBindingsByNameIfConditionTrue(c.Mattermost.Enabled, c.Mattermost.Channels)
 	r.AddBindingsIfConditionTrue(c.Teams.Enabled, c.Teams.Bindings)
 	r.AddBindingsByIDIfConditionTrue(c.Discord.Enabled, c.Discord.Channels)
+	r.AddBindingsByIDIfConditionTrue(c.Telegram.Enabled, c.Telegram.Channels)
+	r.AddBindingsByNameIfConditionTrue(c.RocketChat.Enabled, c.RocketChat.Channels)
+	for _, channel := range c.GoogleChat.Channels {
+		r.AddBindingsIfConditionTrue(c.GoogleChat.Enabled, channel.Bindings)
+	}
 	r.AddElsIndexSinkBindingsIfConditionTrue(c.Elasticsearch.Enabled, c.Elasticsearch.Indices)
 
 	r.AddSinkBindingsIfConditionTrue(c.Webhook.Enabled, c.Webhook.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Kafka.Enabled, c.Kafka.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.NATS.Enabled, c.NATS.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Loki.Enabled, c.Loki.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.OTLPLogs.Enabled, c.OTLPLogs.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.Alertmanager.Enabled, c.Alertmanager.Bindings)
+	r.AddSinkBindingsIfConditionTrue(c.S3.Enabled, c.S3.Bindings)
 }
 
 // AddEnabledActionBindings adds source bindings for enabled Actions.
@@ -293,12 +306,13 @@ func (r *Router) mergeEventRoutes(resource string, sources map[string]config.Sou
 				}
 
 				route := route{
-					source:       srcGroupName,
-					namespaces:   sourceOrResourceNamespaces(srcGroupCfg.Kubernetes.Namespaces, r.Namespaces),
-					annotations:  sourceOrResourceStringMap(srcGroupCfg.Kubernetes.Annotations, r.Annotations),
-					labels:       sourceOrResourceStringMap(srcGroupCfg.Kubernetes.Labels, r.Labels),
-					resourceName: r.Name,
-					event:        sourceOrResourceEvent(srcGroupCfg.Kubernetes.Event, r.Event),
+					source:          srcGroupName,
+					namespaces:      sourceOrResourceNamespaces(srcGroupCfg.Kubernetes.Namespaces, r.Namespaces),
+					annotations:     sourceOrResourceStringMap(srcGroupCfg.Kubernetes.Annotations, r.Annotations),
+					labels:          sourceOrResourceStringMap(srcGroupCfg.Kubernetes.Labels, r.Labels),
+					resourceName:    r.Name,
+					event:           sourceOrResourceEvent(srcGroupCfg.Kubernetes.Event, r.Event),
+					tenantNamespace: srcGroupCfg.Kubernetes.TenantNamespace,
 				}
 				if e == config.UpdateEvent {
 					route.updateSetting = config.UpdateSetting{
@@ -312,13 +326,13 @@ func (r *Router) mergeEventRoutes(resource string, sources map[string]config.Sou
 
 		// add routes related to recommendations
 		resForRecomms := recommendation.ResourceEventsForConfig(srcGroupCfg.Kubernetes.Recommendations)
-		r.setEventRouteForRecommendationsIfShould(&out, resForRecomms, srcGroupName, resource)
+		r.setEventRouteForRecommendationsIfShould(&out, resForRecomms, srcGroupName, srcGroupCfg.Kubernetes.TenantNamespace, resource)
 	}
 
 	return out
 }
 
-func (r *Router) setEventRouteForRecommendationsIfShould(routeMap *map[config.EventType][]route, resForRecomms map[string]config.EventType, srcGroupName, resourceType string) {
+func (r *Router) setEventRouteForRecommendationsIfShould(routeMap *map[config.EventType][]route, resForRecomms map[string]config.EventType, srcGroupName, tenantNamespace, resourceType string) {
 	if routeMap == nil {
 		r.log.Debug("Skipping setting event route for recommendations as the routeMap is nil")
 		return
@@ -334,6 +348,7 @@ func (r *Router) setEventRouteForRecommendationsIfShould(routeMap *map[config.Ev
 		namespaces: config.RegexConstraints{
 			Include: []string{config.AllNamespaceIndicator},
 		},
+		tenantNamespace: tenantNamespace,
 	}
 
 	// Override route and get all these events for all namespaces.
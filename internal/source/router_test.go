@@ -412,7 +412,7 @@ func TestSetEventRouteForRecommendationsIfShould(t *testing.T) {
 			r := &Router{}
 
 			// when
-			r.setEventRouteForRecommendationsIfShould(&tc.Input, resForRecomms, srcGroupName, resourceName)
+			r.setEventRouteForRecommendationsIfShould(&tc.Input, resForRecomms, srcGroupName, "", resourceName)
 
 			// then
 			assert.Equal(t, tc.Expected, tc.Input)
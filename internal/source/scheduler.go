@@ -80,6 +80,30 @@ func (d *Scheduler) Start(ctx context.Context) error {
 				}
 			}
 		}
+
+		if commGroupCfg.Telegram.Enabled {
+			for _, channel := range commGroupCfg.Telegram.Channels {
+				if err := d.schedule(ctx, channel.Bindings.Sources); err != nil {
+					return err
+				}
+			}
+		}
+
+		if commGroupCfg.GoogleChat.Enabled {
+			for _, channel := range commGroupCfg.GoogleChat.Channels {
+				if err := d.schedule(ctx, channel.Bindings.Sources); err != nil {
+					return err
+				}
+			}
+		}
+
+		if commGroupCfg.RocketChat.Enabled {
+			for _, channel := range commGroupCfg.RocketChat.Channels {
+				if err := d.schedule(ctx, channel.Bindings.Sources); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
@@ -93,6 +93,7 @@ func (p *Provider) ExecuteEventAction(ctx context.Context, action event.Action)
 		NotifierHandler: &universalNotifierHandler{},
 		Message:         strings.TrimSpace(strings.TrimPrefix(action.Command, api.MessageBotNamePlaceholder)),
 		User:            fmt.Sprintf("Automation %q", action.DisplayName),
+		ActionName:      action.DisplayName,
 	})
 	response := e.Execute(ctx)
 
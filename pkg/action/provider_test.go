@@ -106,8 +106,9 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 			IsAuthenticated:  true,
 			CommandOrigin:    command.AutomationOrigin,
 		},
-		Message: "kubectl get po foo",
-		User:    `Automation "Test"`,
+		Message:    "kubectl get po foo",
+		User:       `Automation "Test"`,
+		ActionName: "Test",
 	}
 
 	execFactory := &fakeFactory{t: t, expectedInput: expectedExecutorInput}
@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Record describes a single command execution.
+type Record struct {
+	Timestamp     time.Time                      `json:"timestamp"`
+	Cluster       string                         `json:"cluster"`
+	CommGroupName string                         `json:"commGroup"`
+	Platform      config.CommPlatformIntegration `json:"platform"`
+	Channel       string                         `json:"channel,omitempty"`
+	User          string                         `json:"user,omitempty"`
+	UserID        string                         `json:"userID,omitempty"`
+	// Command is the command with expanded aliases.
+	Command          string   `json:"command"`
+	ExecutorBindings []string `json:"executorBindings,omitempty"`
+	// Origin describes how the command was triggered, e.g. typed in a chat, selected from the interactive message or run by an automation.
+	Origin string `json:"origin"`
+	// Action is the name of the automation which triggered the command.
+	Action     string `json:"action,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Sink sends audit records to an external system.
+type Sink interface {
+	SendAuditRecord(ctx context.Context, record Record) error
+	IntegrationName() config.CommPlatformIntegration
+}
+
+var droppedRecords = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "botkube_audit_dropped_records_total",
+	Help: "Number of audit records dropped because the audit queue was full.",
+})
+
+// drainTimeout bounds sending the queued records on shutdown.
+const drainTimeout = 5 * time.Second
+
+// defaultQueueSize is the number of audit records which can wait for delivery before new ones are dropped.
+const defaultQueueSize = 100
+
+// Emitter sends audit records to all registered sinks in the background, so the command execution isn't delayed by slow sinks.
+type Emitter struct {
+	log   logrus.FieldLogger
+	queue chan Record
+
+	mu    sync.RWMutex
+	sinks []Sink
+}
+
+// NewEmitter returns a new Emitter instance.
+func NewEmitter(log logrus.FieldLogger) *Emitter {
+	return &Emitter{
+		log:   log,
+		queue: make(chan Record, defaultQueueSize),
+	}
+}
+
+// Register registers sinks which should receive the audit records.
+func (e *Emitter) Register(sinks ...Sink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sinks = append(e.sinks, sinks...)
+}
+
+// Replace replaces all registered sinks with given ones, e.g. when the sinks are recreated for a reloaded configuration.
+func (e *Emitter) Replace(sinks ...Sink) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sinks = sinks
+}
+
+// Emit queues a given record to be sent to all registered sinks. It doesn't block, and the record is dropped if the queue is full.
+func (e *Emitter) Emit(_ context.Context, record Record) {
+	select {
+	case e.queue <- record:
+	default:
+		droppedRecords.Inc()
+		e.log.Warnf("Audit queue is full. Dropping audit record for command %q...", record.Command)
+	}
+}
+
+// Start sends queued records until the context is canceled. The records which are still queued are sent before it returns.
+func (e *Emitter) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			e.drain()
+			return nil
+		case record := <-e.queue:
+			e.send(ctx, record)
+		}
+	}
+}
+
+func (e *Emitter) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case record := <-e.queue:
+			e.send(ctx, record)
+		default:
+			return
+		}
+	}
+}
+
+// send sends a given record to all registered sinks. Delivery errors are logged, as they shouldn't affect the command execution.
+func (e *Emitter) send(ctx context.Context, record Record) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, sink := range e.sinks {
+		if err := sink.SendAuditRecord(ctx, record); err != nil {
+			e.log.WithField("sink", sink.IntegrationName()).Errorf("while sending audit record: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestEmitter_Emit(t *testing.T) {
+	// given
+	failing := &fakeSink{err: errors.New("destination is down")}
+	working := &fakeSink{}
+
+	emitter := NewEmitter(loggerx.NewNoop())
+	emitter.Register(failing, working)
+
+	record := Record{Command: "kubectl get pods", Success: true}
+
+	// when
+	emitter.Emit(context.Background(), record)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, emitter.Start(ctx))
+
+	// then
+	assert.Equal(t, []Record{record}, failing.records)
+	assert.Equal(t, []Record{record}, working.records)
+}
+
+func TestEmitter_EmitDropsRecordsWhenQueueIsFull(t *testing.T) {
+	// given
+	sink := &fakeSink{}
+	emitter := NewEmitter(loggerx.NewNoop())
+	emitter.Register(sink)
+	droppedBefore := testutil.ToFloat64(droppedRecords)
+
+	// when
+	for i := 0; i < defaultQueueSize+1; i++ {
+		emitter.Emit(context.Background(), Record{Command: "kubectl get pods"})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, emitter.Start(ctx))
+
+	// then
+	assert.Len(t, sink.records, defaultQueueSize)
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(droppedRecords))
+}
+
+type fakeSink struct {
+	err     error
+	records []Record
+}
+
+func (f *fakeSink) SendAuditRecord(_ context.Context, record Record) error {
+	f.records = append(f.records, record)
+	return f.err
+}
+
+func (f *fakeSink) IntegrationName() config.CommPlatformIntegration {
+	return "fake"
+}
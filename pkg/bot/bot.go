@@ -14,6 +14,12 @@ type Bot interface {
 	notifier.Notifier
 }
 
+// ChannelsReloader is implemented by bots which can replace their channel configuration without reconnecting.
+type ChannelsReloader interface {
+	// ReloadChannels replaces the channel configuration with the one from a given communication group.
+	ReloadChannels(cfg config.Communications) error
+}
+
 // ExecutorFactory facilitates creation of execute.Executor instances.
 type ExecutorFactory interface {
 	NewDefault(cfg execute.NewDefaultInput) execute.Executor
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"strings"
+
+	"k8s.io/utils/strings/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// directMessageAuthorizer resolves executor bindings for users allowed to run commands in direct messages.
+type directMessageAuthorizer struct {
+	enabled bool
+	byID    map[string][]string
+	byEmail map[string][]string
+}
+
+func newDirectMessageAuthorizer(cfg config.DirectMessages) *directMessageAuthorizer {
+	out := &directMessageAuthorizer{
+		enabled: cfg.Enabled,
+		byID:    map[string][]string{},
+		byEmail: map[string][]string{},
+	}
+
+	for _, users := range cfg.Users {
+		for _, id := range users.IDs {
+			out.byID[id] = appendUnique(out.byID[id], users.Bindings.Executors...)
+		}
+		for _, email := range users.Emails {
+			key := strings.ToLower(email)
+			out.byEmail[key] = appendUnique(out.byEmail[key], users.Bindings.Executors...)
+		}
+	}
+
+	return out
+}
+
+// Enabled returns true if direct messages support is enabled.
+func (a *directMessageAuthorizer) Enabled() bool {
+	return a != nil && a.enabled
+}
+
+// RequiresEmail returns true if any user is allowed by email, so the email needs to be resolved.
+func (a *directMessageAuthorizer) RequiresEmail() bool {
+	return a.Enabled() && len(a.byEmail) > 0
+}
+
+// ExecutorBindings returns executor bindings for a given user. The email is optional.
+// The second returned value is false if a given user is not allowed to run commands in direct messages.
+func (a *directMessageAuthorizer) ExecutorBindings(userID, email string) ([]string, bool) {
+	if !a.Enabled() {
+		return nil, false
+	}
+
+	var (
+		out     []string
+		allowed bool
+	)
+	if bindings, found := a.byID[userID]; found && userID != "" {
+		out = appendUnique(out, bindings...)
+		allowed = true
+	}
+	if bindings, found := a.byEmail[strings.ToLower(email)]; found && email != "" {
+		out = appendUnique(out, bindings...)
+		allowed = true
+	}
+
+	return out, allowed
+}
+
+func appendUnique(in []string, items ...string) []string {
+	for _, item := range items {
+		if slices.Contains(in, item) {
+			continue
+		}
+		in = append(in, item)
+	}
+	return in
+}
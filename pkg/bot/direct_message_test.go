@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestDirectMessageAuthorizer_ExecutorBindings(t *testing.T) {
+	// given
+	cfg := config.DirectMessages{
+		Enabled: true,
+		Users: map[string]config.DirectMessageUsers{
+			"on-call": {
+				IDs:    []string{"U123"},
+				Emails: []string{"John@example.com"},
+				Bindings: config.DirectMessageBindings{
+					Executors: []string{"kubectl-read-only"},
+				},
+			},
+			"admins": {
+				IDs: []string{"U123"},
+				Bindings: config.DirectMessageBindings{
+					Executors: []string{"kubectl-read-only", "helm"},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name              string
+		Config            config.DirectMessages
+		UserID            string
+		Email             string
+		ExpectedExecutors []string
+		ExpectedAllowed   bool
+	}{
+		{
+			Name:              "Allowed by ID in multiple groups",
+			Config:            cfg,
+			UserID:            "U123",
+			ExpectedExecutors: []string{"kubectl-read-only", "helm"},
+			ExpectedAllowed:   true,
+		},
+		{
+			Name:              "Allowed by email with different casing",
+			Config:            cfg,
+			UserID:            "U456",
+			Email:             "john@example.com",
+			ExpectedExecutors: []string{"kubectl-read-only"},
+			ExpectedAllowed:   true,
+		},
+		{
+			Name:            "Not allowed",
+			Config:          cfg,
+			UserID:          "U456",
+			Email:           "jane@example.com",
+			ExpectedAllowed: false,
+		},
+		{
+			Name:            "Disabled",
+			Config:          config.DirectMessages{Enabled: false, Users: cfg.Users},
+			UserID:          "U123",
+			ExpectedAllowed: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			authorizer := newDirectMessageAuthorizer(tc.Config)
+
+			// when
+			executors, allowed := authorizer.ExecutorBindings(tc.UserID, tc.Email)
+
+			// then
+			assert.Equal(t, tc.ExpectedAllowed, allowed)
+			assert.ElementsMatch(t, tc.ExpectedExecutors, executors)
+		})
+	}
+}
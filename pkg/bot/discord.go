@@ -265,8 +265,10 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 			IsAuthenticated:  isAuthChannel,
 			CommandOrigin:    command.TypedOrigin,
 		},
-		Message: req,
-		User:    fmt.Sprintf("<@%s>", dm.Event.Author.ID),
+		Message:          req,
+		User:             fmt.Sprintf("<@%s>", dm.Event.Author.ID),
+		UserID:           dm.Event.Author.ID,
+		UserInfoProvider: discordUserInfoProvider(dm.Event.Member),
 	})
 
 	response := e.Execute(ctx)
@@ -334,6 +336,13 @@ func (b *Discord) setChannels(channels map[string]channelConfigByID) {
 	b.channels = channels
 }
 
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *Discord) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(discordChannelsConfigFrom(cfg.Discord.Channels))
+	return nil
+}
+
 func (b *Discord) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
@@ -363,3 +372,14 @@ func discordBotMentionRegex(botID string) (*regexp.Regexp, error) {
 
 	return botMentionRegex, nil
 }
+
+// discordUserInfoProvider returns the user details based on a given guild member. The member roles IDs are used as groups.
+func discordUserInfoProvider(member *discordgo.Member) execute.UserInfoProvider {
+	return execute.UserInfoProviderFunc(func(_ context.Context, userID string, _ execute.UserInfoOptions) (execute.UserInfo, error) {
+		out := execute.UserInfo{ID: userID}
+		if member != nil {
+			out.Groups = member.Roles
+		}
+		return out, nil
+	})
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
 )
 
 func TestDiscord_FindAndTrimBotMention(t *testing.T) {
@@ -55,3 +57,31 @@ func TestDiscord_FindAndTrimBotMention(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscord_ReloadChannels(t *testing.T) {
+	// given
+	b := &Discord{
+		channels: discordChannelsConfigFrom(config.IdentifiableMap[config.ChannelBindingsByID]{
+			"default": {ID: "123", Notification: config.ChannelNotification{Disabled: true}},
+		}),
+	}
+
+	// when
+	err := b.ReloadChannels(config.Communications{
+		Discord: config.Discord{
+			Channels: config.IdentifiableMap[config.ChannelBindingsByID]{
+				"default": {ID: "123", Bindings: config.BotBindings{Sources: []string{"k8s-err-events"}}},
+				"other":   {ID: "456", Notification: config.ChannelNotification{Disabled: true}},
+			},
+		},
+	})
+
+	// then
+	require.NoError(t, err)
+	channels := b.getChannels()
+	require.Len(t, channels, 2)
+	assert.Equal(t, []string{"k8s-err-events"}, channels["123"].Bindings.Sources)
+	assert.True(t, channels["123"].notify)
+	assert.Equal(t, "other", channels["456"].alias)
+	assert.False(t, channels["456"].notify)
+}
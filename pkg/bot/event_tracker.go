@@ -0,0 +1,249 @@
+package bot
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+const (
+	// maxTrackedEventHistory is the maximum number of history entries kept for a single Kubernetes object.
+	maxTrackedEventHistory = 10
+	// maxTrackedObjects is the maximum number of tracked Kubernetes objects. The least recently updated objects are removed first.
+	maxTrackedObjects = 1000
+	// trackedObjectTTL is the time after which an object without new events is no longer tracked.
+	trackedObjectTTL = 24 * time.Hour
+)
+
+// trackedMessage is a reference to the already sent event message.
+type trackedMessage struct {
+	ChannelID string
+	MessageID string
+}
+
+// trackedEventStatus describes the current state of a tracked Kubernetes object.
+type trackedEventStatus struct {
+	// UID is the UID of the tracked object. For core Events, it's the UID of the involved object.
+	UID      types.UID
+	History  []string
+	Resolved bool
+}
+
+type trackedObject struct {
+	uid      types.UID
+	messages map[string]trackedMessage
+	history  []string
+	lastSeen time.Time
+}
+
+// eventMessageTracker keeps references to event messages sent for a given Kubernetes object UID.
+// It allows bots to update the original message instead of posting a new one for every change.
+// Objects are forgotten once deleted, after trackedObjectTTL without new events, or when over maxTrackedObjects are tracked.
+type eventMessageTracker struct {
+	mu      sync.Mutex
+	objects map[types.UID]*list.Element
+	// lru holds tracked objects from the most to the least recently updated one.
+	lru *list.List
+	now func() time.Time
+}
+
+func newEventMessageTracker() *eventMessageTracker {
+	return &eventMessageTracker{
+		objects: map[types.UID]*list.Element{},
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Observe records a given event in the object history and returns the current object status.
+// The second returned value is false if the event cannot be tracked, e.g. it doesn't have an object UID.
+func (t *eventMessageTracker) Observe(e event.Event) (trackedEventStatus, bool) {
+	uid := trackedObjectUID(e)
+	if uid == "" {
+		return trackedEventStatus{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	obj := t.touch(uid)
+	obj.history = append(obj.history, historyEntryForEvent(e))
+	if len(obj.history) > maxTrackedEventHistory {
+		obj.history = obj.history[len(obj.history)-maxTrackedEventHistory:]
+	}
+
+	history := make([]string, len(obj.history))
+	copy(history, obj.history)
+
+	return trackedEventStatus{
+		UID:      uid,
+		History:  history,
+		Resolved: isEventResolved(e),
+	}, true
+}
+
+// Get returns the message reference for a given object UID and channel.
+func (t *eventMessageTracker) Get(uid types.UID, channel string) (trackedMessage, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elem, exists := t.objects[uid]
+	if !exists {
+		return trackedMessage{}, false
+	}
+	obj := elem.Value.(*trackedObject)
+	if t.isExpired(obj) {
+		t.remove(elem)
+		return trackedMessage{}, false
+	}
+
+	msg, exists := obj.messages[channel]
+	return msg, exists
+}
+
+// Set stores the message reference for a given object UID and channel.
+func (t *eventMessageTracker) Set(uid types.UID, channel string, msg trackedMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.touch(uid).messages[channel] = msg
+}
+
+// Forget removes all references for a given object UID.
+func (t *eventMessageTracker) Forget(uid types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if elem, exists := t.objects[uid]; exists {
+		t.remove(elem)
+	}
+}
+
+// touch returns the tracked object for a given UID, marked as the most recently updated one.
+// It creates the object if it doesn't exist and removes the expired and the least recently updated objects.
+func (t *eventMessageTracker) touch(uid types.UID) *trackedObject {
+	elem, exists := t.objects[uid]
+	if exists && t.isExpired(elem.Value.(*trackedObject)) {
+		t.remove(elem)
+		exists = false
+	}
+
+	if !exists {
+		elem = t.lru.PushFront(&trackedObject{uid: uid, messages: map[string]trackedMessage{}})
+		t.objects[uid] = elem
+	}
+	t.lru.MoveToFront(elem)
+
+	obj := elem.Value.(*trackedObject)
+	obj.lastSeen = t.now()
+
+	for oldest := t.lru.Back(); oldest != nil && oldest != elem; oldest = t.lru.Back() {
+		if t.lru.Len() <= maxTrackedObjects && !t.isExpired(oldest.Value.(*trackedObject)) {
+			break
+		}
+		t.remove(oldest)
+	}
+
+	return obj
+}
+
+func (t *eventMessageTracker) isExpired(obj *trackedObject) bool {
+	return t.now().Sub(obj.lastSeen) > trackedObjectTTL
+}
+
+func (t *eventMessageTracker) remove(elem *list.Element) {
+	t.lru.Remove(elem)
+	delete(t.objects, elem.Value.(*trackedObject).uid)
+}
+
+// trackedObjectUID returns the UID of the object which a given event relates to.
+// Notifications about core Events are grouped by the involved object, as every Event has its own UID.
+func trackedObjectUID(e event.Event) types.UID {
+	unstrObj, isCoreEvent := coreEventObject(e)
+	if !isCoreEvent {
+		return e.ObjectMeta.UID
+	}
+
+	uid, _, _ := unstructured.NestedString(unstrObj.Object, "involvedObject", "uid")
+	return types.UID(uid)
+}
+
+// coreEventObject returns the object of a given event if it's a core Event.
+func coreEventObject(e event.Event) (*unstructured.Unstructured, bool) {
+	unstrObj, ok := e.Object.(*unstructured.Unstructured)
+	if !ok || unstrObj == nil || unstrObj.GetKind() != "Event" {
+		return nil, false
+	}
+	return unstrObj, true
+}
+
+func historyEntryForEvent(e event.Event) string {
+	ts := e.TimeStamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	entry := fmt.Sprintf("%s %s", ts.UTC().Format(time.RFC3339), e.Title)
+	if msg := formatx.JoinMessages(e.Messages); msg != "" {
+		entry = fmt.Sprintf("%s: %s", entry, strings.TrimSpace(msg))
+	}
+	return entry
+}
+
+// isEventResolved returns true if the object related to a given event was deleted or became healthy.
+// A deleted core Event doesn't resolve the involved object, as Events are routinely removed once they expire.
+func isEventResolved(e event.Event) bool {
+	switch e.Type {
+	case config.DeleteEvent:
+		_, isCoreEvent := coreEventObject(e)
+		return !isCoreEvent
+	case config.ErrorEvent, config.WarningEvent:
+		return false
+	}
+
+	unstrObj, ok := e.Object.(*unstructured.Unstructured)
+	if !ok || unstrObj == nil {
+		return false
+	}
+
+	return isObjectHealthy(unstrObj)
+}
+
+// isObjectHealthy checks the `Ready` or `Available` status conditions and the status phase of a given object.
+func isObjectHealthy(obj *unstructured.Unstructured) bool {
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err == nil && found {
+		for _, item := range conditions {
+			cond, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condType, _ := cond["type"].(string)
+			if condType != "Ready" && condType != "Available" {
+				continue
+			}
+			status, _ := cond["status"].(string)
+			return status == "True"
+		}
+	}
+
+	phase, found, err := unstructured.NestedString(obj.Object, "status", "phase")
+	if err != nil || !found {
+		return false
+	}
+
+	switch phase {
+	case "Running", "Succeeded", "Active", "Bound":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestEventMessageTracker(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	created := fixPodEvent(config.CreateEvent, "Pending", ts)
+	updated := fixPodEvent(config.UpdateEvent, "Running", ts.Add(time.Minute))
+	deleted := fixPodEvent(config.DeleteEvent, "Running", ts.Add(2*time.Minute))
+
+	// when
+	status, tracked := tracker.Observe(created)
+
+	// then
+	require.True(t, tracked)
+	assert.False(t, status.Resolved)
+	assert.Equal(t, []string{"2022-10-10T12:00:00Z v1/pods created"}, status.History)
+
+	// when
+	_, found := tracker.Get(created.ObjectMeta.UID, "test")
+	assert.False(t, found)
+
+	tracker.Set(created.ObjectMeta.UID, "test", trackedMessage{ChannelID: "C01", MessageID: "123.456"})
+	status, tracked = tracker.Observe(updated)
+
+	// then
+	require.True(t, tracked)
+	assert.True(t, status.Resolved)
+	assert.Len(t, status.History, 2)
+
+	ref, found := tracker.Get(updated.ObjectMeta.UID, "test")
+	require.True(t, found)
+	assert.Equal(t, trackedMessage{ChannelID: "C01", MessageID: "123.456"}, ref)
+
+	// when
+	status, tracked = tracker.Observe(deleted)
+	tracker.Forget(deleted.ObjectMeta.UID)
+
+	// then
+	require.True(t, tracked)
+	assert.True(t, status.Resolved)
+	assert.Len(t, status.History, 3)
+
+	_, found = tracker.Get(deleted.ObjectMeta.UID, "test")
+	assert.False(t, found)
+}
+
+func TestEventMessageTracker_BoundedHistory(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	// when
+	var status trackedEventStatus
+	for i := 0; i < maxTrackedEventHistory+5; i++ {
+		status, _ = tracker.Observe(fixPodEvent(config.UpdateEvent, "Pending", ts.Add(time.Duration(i)*time.Minute)))
+	}
+
+	// then
+	require.Len(t, status.History, maxTrackedEventHistory)
+	assert.Equal(t, "2022-10-10T12:05:00Z v1/pods updated", status.History[0])
+}
+
+func TestEventMessageTracker_SkipsEventsWithoutUID(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+
+	// when
+	_, tracked := tracker.Observe(event.Event{Title: "v1/pods created"})
+
+	// then
+	assert.False(t, tracked)
+}
+
+func TestEventMessageTracker_ExpiresObjects(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	pod := fixPodEvent(config.CreateEvent, "Pending", now)
+	tracker.Observe(pod)
+	tracker.Set(pod.ObjectMeta.UID, "test", trackedMessage{ChannelID: "C01", MessageID: "123.456"})
+
+	// when
+	now = now.Add(trackedObjectTTL + time.Second)
+	_, found := tracker.Get(pod.ObjectMeta.UID, "test")
+
+	// then
+	assert.False(t, found)
+	assert.Empty(t, tracker.objects)
+}
+
+func TestEventMessageTracker_EvictsLeastRecentlyUpdatedObjects(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	ts := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	first := fixPodEvent(config.CreateEvent, "Pending", ts)
+	first.ObjectMeta.UID = "first"
+	tracker.Observe(first)
+	tracker.Set(first.ObjectMeta.UID, "test", trackedMessage{ChannelID: "C01", MessageID: "1"})
+
+	// when
+	for i := 0; i < maxTrackedObjects; i++ {
+		pod := fixPodEvent(config.CreateEvent, "Pending", ts)
+		pod.ObjectMeta.UID = types.UID(fmt.Sprintf("pod-%d", i))
+		tracker.Observe(pod)
+	}
+
+	// then
+	assert.Len(t, tracker.objects, maxTrackedObjects)
+	assert.Equal(t, maxTrackedObjects, tracker.lru.Len())
+	_, found := tracker.Get(first.ObjectMeta.UID, "test")
+	assert.False(t, found)
+}
+
+func TestEventMessageTracker_GroupsCoreEventsByInvolvedObject(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	fixCoreEvent := func(uid, reason string) event.Event {
+		return event.Event{
+			Title:      "v1/pods error",
+			Type:       config.ErrorEvent,
+			ObjectMeta: metaV1.ObjectMeta{UID: types.UID(uid)},
+			Object: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind":           "Event",
+					"reason":         reason,
+					"involvedObject": map[string]interface{}{"uid": "pod-uid"},
+				},
+			},
+		}
+	}
+
+	// when
+	first, tracked := tracker.Observe(fixCoreEvent("event-1", "BackOff"))
+	require.True(t, tracked)
+	second, tracked := tracker.Observe(fixCoreEvent("event-2", "Failed"))
+	require.True(t, tracked)
+
+	// then
+	assert.Equal(t, types.UID("pod-uid"), first.UID)
+	assert.Equal(t, types.UID("pod-uid"), second.UID)
+	assert.Len(t, second.History, 2)
+}
+
+func TestEventMessageTracker_DeletedCoreEventDoesNotResolveInvolvedObject(t *testing.T) {
+	// given
+	tracker := newEventMessageTracker()
+	coreEvent := event.Event{
+		Title:      "v1/events deleted",
+		Type:       config.DeleteEvent,
+		ObjectMeta: metaV1.ObjectMeta{UID: "event-uid"},
+		Object: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":           "Event",
+				"involvedObject": map[string]interface{}{"uid": "pod-uid"},
+			},
+		},
+	}
+
+	// when
+	status, tracked := tracker.Observe(coreEvent)
+
+	// then
+	require.True(t, tracked)
+	assert.Equal(t, types.UID("pod-uid"), status.UID)
+	assert.False(t, status.Resolved)
+}
+
+func fixPodEvent(eventType config.EventType, phase string, ts time.Time) event.Event {
+	return event.Event{
+		Title:     "v1/pods " + eventType.String() + "d",
+		Type:      eventType,
+		TimeStamp: ts,
+		ObjectMeta: metaV1.ObjectMeta{
+			Name: "nginx",
+			UID:  "6ee3b1d6-4a62-4b0d-9e0c-1d35b1f2c6a1",
+		},
+		Object: &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"phase": phase,
+				},
+			},
+		},
+	}
+}
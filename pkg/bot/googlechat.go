@@ -0,0 +1,392 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &GoogleChat{}
+
+const (
+	defaultGoogleChatPort           = "3979"
+	defaultGoogleChatHTTPCliTimeout = 30 * time.Second
+
+	// googleChatMaxMessageSize max size of the message text.
+	googleChatMaxMessageSize = 4096
+
+	googleChatEventMessage     = "MESSAGE"
+	googleChatEventCardClicked = "CARD_CLICKED"
+	googleChatBotMentionFmt    = "^@(?i)%s"
+)
+
+// GoogleChat listens for user's message, execute commands and sends back the response.
+// Incoming messages are handled by the HTTP endpoint configured for a given Google Chat app,
+// while notifications are sent with incoming webhooks configured per space.
+type GoogleChat struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	httpCli         *http.Client
+	verifier        *googleChatTokenVerifier
+	notification    config.Notification
+	botName         string
+	port            string
+	messagePath     string
+	channelsMutex   sync.RWMutex
+	channels        map[string]googleChatChannelConfig
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	commGroupName   string
+	mdFormatter     interactive.MDFormatter
+}
+
+type googleChatChannelConfig struct {
+	channelConfigByID
+
+	webhookURL string
+}
+
+// NewGoogleChat creates a new GoogleChat instance.
+func NewGoogleChat(log logrus.FieldLogger, commGroupName string, cfg config.GoogleChat, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*GoogleChat, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(googleChatBotMentionFmt, regexp.QuoteMeta(cfg.BotName)))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = defaultGoogleChatPort
+	}
+	msgPath := cfg.MessagePath
+	if msgPath == "" {
+		msgPath = "/"
+	}
+
+	if cfg.ProjectNumber == "" {
+		return nil, errors.New("Google Chat project number is required to verify the incoming requests")
+	}
+	verifier := newGoogleChatTokenVerifier(cfg.ProjectNumber)
+
+	return &GoogleChat{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		httpCli:         &http.Client{Timeout: defaultGoogleChatHTTPCliTimeout},
+		verifier:        verifier,
+		notification:    cfg.Notification,
+		botName:         cfg.BotName,
+		port:            port,
+		messagePath:     msgPath,
+		channels:        googleChatChannelsConfigFrom(cfg.Channels),
+		botMentionRegex: botMentionRegex,
+		commGroupName:   commGroupName,
+		mdFormatter:     interactive.NewMDFormatter(interactive.NewlineFormatter, googleChatHeaderFormatter),
+	}, nil
+}
+
+// Start starts the HTTP server which handles the Google Chat app events.
+func (b *GoogleChat) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	router := mux.NewRouter()
+	router.PathPrefix(b.messagePath).HandlerFunc(b.handleEvent).Methods(http.MethodPost)
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	srv := httpsrv.New(b.log, fmt.Sprintf(":%s", b.port), router)
+	err = srv.Serve(ctx)
+	if err != nil {
+		return fmt.Errorf("while running Google Chat server: %w", err)
+	}
+
+	return nil
+}
+
+// SendEvent sends event notification to Google Chat spaces.
+func (b *GoogleChat) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Google Chat: %+v", event)
+
+	msg := b.renderEventMessage(event)
+
+	errs := multierror.New()
+	for _, channel := range b.getChannelsToNotify(eventSources) {
+		if err := b.postWebhook(ctx, channel.webhookURL, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Google Chat message to space %q: %w", channel.Identifier(), err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to space %q", channel.Identifier())
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessage sends interactive message to selected Google Chat spaces.
+func (b *GoogleChat) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannelsToNotify(sourceBindings) {
+		if err := b.postWebhook(ctx, channel.webhookURL, b.renderMessage(msg)); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Google Chat message to space %q: %w", channel.Identifier(), err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends interactive message to all Google Chat spaces.
+func (b *GoogleChat) SendMessageToAll(ctx context.Context, msg interactive.CoreMessage) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		if err := b.postWebhook(ctx, channel.webhookURL, b.renderMessage(msg)); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Google Chat message to space %q: %w", channel.Identifier(), err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// IntegrationName describes the integration name.
+func (b *GoogleChat) IntegrationName() config.CommPlatformIntegration {
+	return config.GoogleChatCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (b *GoogleChat) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given space name.
+func (b *GoogleChat) NotificationsEnabled(spaceName string) bool {
+	channel, exists := b.getChannels()[spaceName]
+	if !exists {
+		return false
+	}
+
+	return channel.notify
+}
+
+// SetNotificationsEnabled sets a new notification status for a given space name.
+func (b *GoogleChat) SetNotificationsEnabled(spaceName string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[spaceName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channels[spaceName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// BotName returns the Bot name.
+func (b *GoogleChat) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+func (b *GoogleChat) handleEvent(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	if err := b.verifier.Verify(ctx, req.Header.Get("Authorization")); err != nil {
+		b.log.Errorf("while verifying Google Chat request: %s", err.Error())
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var ev googleChatEvent
+	if err := json.NewDecoder(req.Body).Decode(&ev); err != nil {
+		b.log.Errorf("while decoding Google Chat event: %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, ok := b.processEvent(ctx, ev)
+	if !ok {
+		// Google Chat expects a valid JSON in response
+		resp = googleChatMessage{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		b.log.Errorf("while writing Google Chat response: %s", err.Error())
+	}
+}
+
+func (b *GoogleChat) processEvent(ctx context.Context, ev googleChatEvent) (googleChatMessage, bool) {
+	var (
+		req    string
+		origin command.Origin
+	)
+	switch ev.Type {
+	case googleChatEventMessage:
+		if ev.Message == nil {
+			return googleChatMessage{}, false
+		}
+		req = ev.Message.ArgumentText
+		if req == "" {
+			req = ev.Message.Text
+		}
+		origin = command.TypedOrigin
+	case googleChatEventCardClicked:
+		cmd, found := ev.commandParameter()
+		if !found {
+			return googleChatMessage{}, false
+		}
+		req = cmd
+		origin = command.ButtonClickOrigin
+	default:
+		b.log.Debugf("Ignoring Google Chat event type %q", ev.Type)
+		return googleChatMessage{}, false
+	}
+
+	// Messages sent in spaces contain the bot mention, so trim it if argument text is not available.
+	req = strings.TrimSpace(b.botMentionRegex.ReplaceAllString(strings.TrimSpace(req), ""))
+	b.log.Debugf("Google Chat incoming Request: %s", req)
+
+	channel, isAuthChannel := b.getChannels()[ev.Space.Name]
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               channel.Identifier(),
+			ExecutorBindings: channel.Bindings.Executors,
+			SourceBindings:   channel.Bindings.Sources,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    origin,
+		},
+		Message: " " + req,
+		User:    ev.User.mention(),
+		UserID:  ev.User.Name,
+		UserInfoProvider: execute.UserInfoProviderFunc(func(_ context.Context, userID string, _ execute.UserInfoOptions) (execute.UserInfo, error) {
+			return execute.UserInfo{ID: userID, Email: ev.User.Email}, nil
+		}),
+	})
+
+	response := e.Execute(ctx)
+	msg := b.renderMessage(response)
+	if ev.Type == googleChatEventCardClicked {
+		msg.ActionResponse = &googleChatActionResponse{Type: "NEW_MESSAGE"}
+	}
+	return msg, true
+}
+
+func (b *GoogleChat) postWebhook(ctx context.Context, webhookURL string, msg googleChatMessage) (err error) {
+	if webhookURL == "" {
+		return errors.New("webhook URL is not configured")
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("while marshaling message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	resp, err := b.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (b *GoogleChat) getChannelsToNotify(sourceBindings []string) []googleChatChannelConfig {
+	var out []googleChatChannelConfig
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notify:
+			b.log.Infof("Skipping notification for space %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg)
+			}
+		}
+	}
+	return out
+}
+
+func (b *GoogleChat) getChannels() map[string]googleChatChannelConfig {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *GoogleChat) setChannels(channels map[string]googleChatChannelConfig) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *GoogleChat) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(googleChatChannelsConfigFrom(cfg.GoogleChat.Channels))
+	return nil
+}
+
+func googleChatChannelsConfigFrom(channelsCfg config.IdentifiableMap[config.GoogleChatChannelBindings]) map[string]googleChatChannelConfig {
+	res := make(map[string]googleChatChannelConfig)
+	for channAlias, channCfg := range channelsCfg {
+		res[channCfg.Identifier()] = googleChatChannelConfig{
+			channelConfigByID: channelConfigByID{
+				ChannelBindingsByID: channCfg.ChannelBindingsByID,
+				alias:               channAlias,
+				notify:              !channCfg.Notification.Disabled,
+			},
+			webhookURL: channCfg.WebhookURL,
+		}
+	}
+
+	return res
+}
+
+func googleChatHeaderFormatter(msg string) string {
+	return fmt.Sprintf("*%s*", msg)
+}
@@ -0,0 +1,160 @@
+package bot
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	// googleChatIssuer is the issuer of the bearer tokens sent by Google Chat.
+	googleChatIssuer = "chat@system.gserviceaccount.com"
+	// googleChatCertsURL returns public certificates used to sign the Google Chat bearer tokens.
+	googleChatCertsURL = "https://www.googleapis.com/service_accounts/v1/metadata/x509/" + googleChatIssuer
+	// googleChatCertsTTL defines how long the fetched certificates are cached.
+	googleChatCertsTTL = time.Hour
+	// googleChatCertsMinRefetchInterval limits how often the certificates are fetched for unknown key IDs.
+	// Otherwise, unauthenticated requests with random key IDs could make Botkube fetch the certificates on every request.
+	googleChatCertsMinRefetchInterval = time.Minute
+)
+
+// googleChatTokenVerifier verifies that incoming requests were sent by Google Chat to a given app.
+// See: https://developers.google.com/chat/api/guides/message-formats#verify_bearer_token
+type googleChatTokenVerifier struct {
+	httpCli  *http.Client
+	certsURL string
+	audience string
+
+	mu          sync.Mutex
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	now         func() time.Time
+}
+
+func newGoogleChatTokenVerifier(projectNumber string) *googleChatTokenVerifier {
+	return &googleChatTokenVerifier{
+		httpCli:  &http.Client{Timeout: defaultGoogleChatHTTPCliTimeout},
+		certsURL: googleChatCertsURL,
+		audience: projectNumber,
+		now:      time.Now,
+	}
+}
+
+// Verify validates the bearer token from a given Authorization header value.
+func (v *googleChatTokenVerifier) Verify(ctx context.Context, authHeader string) error {
+	rawToken := strings.TrimPrefix(authHeader, "Bearer ")
+	if rawToken == "" || rawToken == authHeader {
+		return errors.New("missing bearer token")
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %q", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.publicKey(ctx, kid)
+	})
+	if err != nil {
+		return fmt.Errorf("while parsing bearer token: %w", err)
+	}
+
+	if !claims.VerifyIssuer(googleChatIssuer, true) {
+		return fmt.Errorf("invalid token issuer %q", claims.Issuer)
+	}
+	if !claims.VerifyAudience(v.audience, true) {
+		return fmt.Errorf("invalid token audience %q", claims.Audience)
+	}
+
+	return nil
+}
+
+func (v *googleChatTokenVerifier) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	key, found := v.keys[kid]
+	if found && now.Sub(v.fetchedAt) < googleChatCertsTTL {
+		return key, nil
+	}
+	if !v.attemptedAt.IsZero() && now.Sub(v.attemptedAt) < googleChatCertsMinRefetchInterval {
+		if found {
+			return key, nil
+		}
+		return nil, fmt.Errorf("certificate with key ID %q not found", kid)
+	}
+
+	v.attemptedAt = now
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while fetching Google Chat certificates: %w", err)
+	}
+	v.keys = keys
+	v.fetchedAt = now
+
+	key, found = v.keys[kid]
+	if !found {
+		return nil, fmt.Errorf("certificate with key ID %q not found", kid)
+	}
+	return key, nil
+}
+
+func (v *googleChatTokenVerifier) fetchKeys(ctx context.Context) (_ map[string]*rsa.PublicKey, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.certsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := v.httpCli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var certs map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&certs); err != nil {
+		return nil, fmt.Errorf("while decoding certificates: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(certs))
+	for kid, rawCert := range certs {
+		block, _ := pem.Decode([]byte(rawCert))
+		if block == nil {
+			return nil, fmt.Errorf("while decoding certificate %q: no PEM data found", kid)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing certificate %q: %w", kid, err)
+		}
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("certificate %q doesn't contain RSA public key", kid)
+		}
+		keys[kid] = key
+	}
+
+	return keys, nil
+}
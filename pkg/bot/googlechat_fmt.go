@@ -0,0 +1,298 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+const (
+	googleChatCommandFunction  = "botkube"
+	googleChatCommandParameter = "command"
+	googleChatLongRespNotice   = "Response is too long. Sending last few lines."
+)
+
+// googleChatEvent is an event sent by Google Chat to the app endpoint.
+// See: https://developers.google.com/chat/api/reference/rest/v1/Event
+type googleChatEvent struct {
+	Type    string                 `json:"type"`
+	Message *googleChatEventMsg    `json:"message,omitempty"`
+	User    googleChatUser         `json:"user"`
+	Space   googleChatSpace        `json:"space"`
+	Action  *googleChatEventAction `json:"action,omitempty"`
+	Common  *googleChatEventCommon `json:"common,omitempty"`
+}
+
+type googleChatEventMsg struct {
+	Text         string `json:"text"`
+	ArgumentText string `json:"argumentText"`
+}
+
+type googleChatUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email,omitempty"`
+}
+
+type googleChatSpace struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type googleChatEventAction struct {
+	ActionMethodName string                  `json:"actionMethodName"`
+	Parameters       []googleChatActionParam `json:"parameters,omitempty"`
+}
+
+type googleChatEventCommon struct {
+	InvokedFunction string            `json:"invokedFunction,omitempty"`
+	Parameters      map[string]string `json:"parameters,omitempty"`
+}
+
+type googleChatActionParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// commandParameter returns the command assigned to the clicked card button.
+func (e googleChatEvent) commandParameter() (string, bool) {
+	if e.Common != nil {
+		if cmd, found := e.Common.Parameters[googleChatCommandParameter]; found {
+			return cmd, true
+		}
+	}
+	if e.Action != nil {
+		for _, param := range e.Action.Parameters {
+			if param.Key == googleChatCommandParameter {
+				return param.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (u googleChatUser) mention() string {
+	if u.Name != "" {
+		return fmt.Sprintf("<%s>", u.Name)
+	}
+	return u.DisplayName
+}
+
+// googleChatMessage is a message sent to Google Chat.
+// See: https://developers.google.com/chat/api/reference/rest/v1/spaces.messages
+type googleChatMessage struct {
+	Text           string                    `json:"text,omitempty"`
+	CardsV2        []googleChatCardWithID    `json:"cardsV2,omitempty"`
+	ActionResponse *googleChatActionResponse `json:"actionResponse,omitempty"`
+}
+
+type googleChatActionResponse struct {
+	Type string `json:"type"`
+}
+
+type googleChatCardWithID struct {
+	CardID string         `json:"cardId"`
+	Card   googleChatCard `json:"card"`
+}
+
+type googleChatCard struct {
+	Header   *googleChatCardHeader   `json:"header,omitempty"`
+	Sections []googleChatCardSection `json:"sections"`
+}
+
+type googleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type googleChatCardSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+type googleChatWidget struct {
+	TextParagraph *googleChatTextParagraph `json:"textParagraph,omitempty"`
+	DecoratedText *googleChatDecoratedText `json:"decoratedText,omitempty"`
+	ButtonList    *googleChatButtonList    `json:"buttonList,omitempty"`
+}
+
+type googleChatTextParagraph struct {
+	Text string `json:"text"`
+}
+
+type googleChatDecoratedText struct {
+	TopLabel string `json:"topLabel,omitempty"`
+	Text     string `json:"text"`
+	WrapText bool   `json:"wrapText,omitempty"`
+}
+
+type googleChatButtonList struct {
+	Buttons []googleChatButton `json:"buttons"`
+}
+
+type googleChatButton struct {
+	Text    string            `json:"text"`
+	OnClick googleChatOnClick `json:"onClick"`
+}
+
+type googleChatOnClick struct {
+	Action   *googleChatOnClickAction `json:"action,omitempty"`
+	OpenLink *googleChatOpenLink      `json:"openLink,omitempty"`
+}
+
+type googleChatOnClickAction struct {
+	Function   string                  `json:"function"`
+	Parameters []googleChatActionParam `json:"parameters,omitempty"`
+}
+
+type googleChatOpenLink struct {
+	URL string `json:"url"`
+}
+
+// renderMessage renders a given message. The message content is sent as a text, as cards don't support
+// code blocks, and interactive buttons are rendered as card widgets.
+func (b *GoogleChat) renderMessage(msg interactive.CoreMessage) googleChatMessage {
+	msg.ReplaceBotNamePlaceholder(b.BotName())
+
+	text := interactive.RenderMessage(b.mdFormatter, withoutButtons(msg))
+	if len(text) >= googleChatMaxMessageSize {
+		tail := text[len(text)-googleChatMaxMessageSize+len(googleChatLongRespNotice)+1:]
+		text = fmt.Sprintf("%s\n%s", googleChatLongRespNotice, tail)
+	}
+
+	out := googleChatMessage{Text: text}
+
+	var sections []googleChatCardSection
+	for _, section := range msg.Sections {
+		buttons := b.renderButtons(section.Buttons)
+		if len(buttons) == 0 {
+			continue
+		}
+		sections = append(sections, googleChatCardSection{
+			Header: section.Header,
+			Widgets: []googleChatWidget{
+				{ButtonList: &googleChatButtonList{Buttons: buttons}},
+			},
+		})
+	}
+
+	if len(sections) > 0 {
+		out.CardsV2 = []googleChatCardWithID{
+			{
+				CardID: uuid.New().String(),
+				Card:   googleChatCard{Sections: sections},
+			},
+		}
+	}
+
+	return out
+}
+
+func (b *GoogleChat) renderButtons(in api.Buttons) []googleChatButton {
+	var out []googleChatButton
+	for _, btn := range in {
+		switch {
+		case btn.URL != "":
+			out = append(out, googleChatButton{
+				Text:    btn.Name,
+				OnClick: googleChatOnClick{OpenLink: &googleChatOpenLink{URL: btn.URL}},
+			})
+		case btn.Command != "":
+			out = append(out, googleChatButton{
+				Text: btn.Name,
+				OnClick: googleChatOnClick{Action: &googleChatOnClickAction{
+					Function: googleChatCommandFunction,
+					Parameters: []googleChatActionParam{
+						{Key: googleChatCommandParameter, Value: btn.Command},
+					},
+				}},
+			})
+		}
+	}
+	return out
+}
+
+// renderEventMessage renders a card for a given event.
+func (b *GoogleChat) renderEventMessage(event event.Event) googleChatMessage {
+	var widgets []googleChatWidget
+
+	switch b.notification.Type {
+	case config.LongNotification:
+		widgets = b.longNotification(event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		widgets = b.shortNotification(event)
+	}
+
+	header := &googleChatCardHeader{
+		Title: fmt.Sprintf("%s %s", unicodeEmojiForLevel[event.Level], event.Title),
+	}
+	if !event.TimeStamp.IsZero() {
+		header.Subtitle = event.TimeStamp.UTC().Format(time.RFC1123)
+	}
+
+	return googleChatMessage{
+		CardsV2: []googleChatCardWithID{
+			{
+				CardID: uuid.New().String(),
+				Card: googleChatCard{
+					Header:   header,
+					Sections: []googleChatCardSection{{Widgets: widgets}},
+				},
+			},
+		},
+	}
+}
+
+func (b *GoogleChat) longNotification(event event.Event) []googleChatWidget {
+	var widgets []googleChatWidget
+	widgets = b.appendIfNotEmpty(widgets, "Kind", event.Kind)
+	widgets = b.appendIfNotEmpty(widgets, "Name", event.Name)
+	widgets = b.appendIfNotEmpty(widgets, "Namespace", event.Namespace)
+	widgets = b.appendIfNotEmpty(widgets, "Reason", event.Reason)
+	widgets = b.appendIfNotEmpty(widgets, "Message", formatx.JoinMessages(event.Messages))
+	widgets = b.appendIfNotEmpty(widgets, "Action", event.Action)
+	widgets = b.appendIfNotEmpty(widgets, "Recommendations", formatx.JoinMessages(event.Recommendations))
+	widgets = b.appendIfNotEmpty(widgets, "Warnings", formatx.JoinMessages(event.Warnings))
+	widgets = b.appendIfNotEmpty(widgets, "Cluster", event.Cluster)
+	return widgets
+}
+
+func (b *GoogleChat) shortNotification(event event.Event) []googleChatWidget {
+	// Card text supports only a subset of HTML, so the Markdown formatting is replaced.
+	header := formatx.ShortNotificationHeader(event)
+	for strings.Count(header, "*") >= 2 {
+		header = strings.Replace(header, "*", "<b>", 1)
+		header = strings.Replace(header, "*", "</b>", 1)
+	}
+
+	widgets := []googleChatWidget{
+		{TextParagraph: &googleChatTextParagraph{Text: header}},
+	}
+	widgets = b.appendIfNotEmpty(widgets, "Messages", formatx.JoinMessages(event.Messages))
+	widgets = b.appendIfNotEmpty(widgets, "Recommendations", formatx.JoinMessages(event.Recommendations))
+	widgets = b.appendIfNotEmpty(widgets, "Warnings", formatx.JoinMessages(event.Warnings))
+	return widgets
+}
+
+func (b *GoogleChat) appendIfNotEmpty(widgets []googleChatWidget, title, in string) []googleChatWidget {
+	if in == "" {
+		return widgets
+	}
+	return append(widgets, googleChatWidget{
+		DecoratedText: &googleChatDecoratedText{
+			TopLabel: title,
+			Text:     strings.TrimSpace(in),
+			WrapText: true,
+		},
+	})
+}
@@ -0,0 +1,239 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestGoogleChat_ProcessEvent(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name            string
+		Event           googleChatEvent
+		ExpectedMessage string
+		ExpectedOrigin  command.Origin
+		ExpectedAction  *googleChatActionResponse
+	}{
+		{
+			Name: "Message with argument text",
+			Event: googleChatEvent{
+				Type:    googleChatEventMessage,
+				Message: &googleChatEventMsg{Text: "@Botkube kubectl get pods", ArgumentText: " kubectl get pods"},
+				Space:   googleChatSpace{Name: "spaces/AAA"},
+				User:    googleChatUser{Name: "users/42"},
+			},
+			ExpectedMessage: " kubectl get pods",
+			ExpectedOrigin:  command.TypedOrigin,
+		},
+		{
+			Name: "Message without argument text",
+			Event: googleChatEvent{
+				Type:    googleChatEventMessage,
+				Message: &googleChatEventMsg{Text: "@botkube kubectl get pods"},
+				Space:   googleChatSpace{Name: "spaces/AAA"},
+				User:    googleChatUser{Name: "users/42"},
+			},
+			ExpectedMessage: " kubectl get pods",
+			ExpectedOrigin:  command.TypedOrigin,
+		},
+		{
+			Name: "Card clicked",
+			Event: googleChatEvent{
+				Type:   googleChatEventCardClicked,
+				Common: &googleChatEventCommon{Parameters: map[string]string{"command": "@Botkube kubectl get pods"}},
+				Space:  googleChatSpace{Name: "spaces/AAA"},
+				User:   googleChatUser{Name: "users/42"},
+			},
+			ExpectedMessage: " kubectl get pods",
+			ExpectedOrigin:  command.ButtonClickOrigin,
+			ExpectedAction:  &googleChatActionResponse{Type: "NEW_MESSAGE"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			factory := &fakeExecutorFactory{}
+			b, err := NewGoogleChat(logrus.New(), "default", fixGoogleChatConfig(""), factory, nil)
+			require.NoError(t, err)
+
+			// when
+			msg, ok := b.processEvent(context.Background(), tc.Event)
+
+			// then
+			require.True(t, ok)
+			require.Len(t, factory.inputs, 1)
+			in := factory.inputs[0]
+			assert.Equal(t, tc.ExpectedMessage, in.Message)
+			assert.Equal(t, "<users/42>", in.User)
+			assert.Equal(t, tc.ExpectedOrigin, in.Conversation.CommandOrigin)
+			assert.True(t, in.Conversation.IsAuthenticated)
+			assert.Equal(t, "ops", in.Conversation.Alias)
+			assert.Equal(t, []string{"kubectl-read-only"}, in.Conversation.ExecutorBindings)
+
+			assert.Contains(t, msg.Text, "pong")
+			assert.Equal(t, tc.ExpectedAction, msg.ActionResponse)
+		})
+	}
+}
+
+func TestGoogleChat_RenderMessageButtons(t *testing.T) {
+	// given
+	b, err := NewGoogleChat(logrus.New(), "default", fixGoogleChatConfig(""), &fakeExecutorFactory{}, nil)
+	require.NoError(t, err)
+
+	msg := interactive.CoreMessage{
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{Header: "Actions"},
+					Buttons: api.Buttons{
+						{Name: "Get pods", Command: "@Botkube kubectl get pods"},
+						{Name: "Docs", URL: "https://docs.botkube.io"},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	out := b.renderMessage(msg)
+
+	// then
+	require.Len(t, out.CardsV2, 1)
+	require.Len(t, out.CardsV2[0].Card.Sections, 1)
+	section := out.CardsV2[0].Card.Sections[0]
+	assert.Equal(t, "Actions", section.Header)
+	require.Len(t, section.Widgets, 1)
+	buttons := section.Widgets[0].ButtonList.Buttons
+	require.Len(t, buttons, 2)
+
+	cmd, found := googleChatEvent{Action: &googleChatEventAction{Parameters: buttons[0].OnClick.Action.Parameters}}.commandParameter()
+	assert.True(t, found)
+	assert.Equal(t, "@Botkube kubectl get pods", cmd)
+	assert.Equal(t, "https://docs.botkube.io", buttons[1].OnClick.OpenLink.URL)
+}
+
+func TestGoogleChat_SendMessageToAll(t *testing.T) {
+	// given
+	var sent []googleChatMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg googleChatMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		sent = append(sent, msg)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	b, err := NewGoogleChat(logrus.New(), "default", fixGoogleChatConfig(srv.URL), &fakeExecutorFactory{}, nil)
+	require.NoError(t, err)
+
+	msg := interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{Plaintext: "Botkube is up"},
+		},
+	}
+
+	// when
+	err = b.SendMessageToAll(context.Background(), msg)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Contains(t, sent[0].Text, "Botkube is up")
+}
+
+func TestGoogleChat_RejectsUnverifiedRequests(t *testing.T) {
+	// given
+	factory := &fakeExecutorFactory{}
+	b, err := NewGoogleChat(logrus.New(), "default", fixGoogleChatConfig(""), factory, nil)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(googleChatEvent{
+		Type:    googleChatEventMessage,
+		Message: &googleChatEventMsg{Text: "@Botkube kubectl get pods"},
+		Space:   googleChatSpace{Name: "spaces/AAA"},
+	})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+
+	// when
+	b.handleEvent(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, factory.inputs)
+}
+
+func TestGoogleChatTokenVerifier_LimitsRefetchesForUnknownKeyIDs(t *testing.T) {
+	// given
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	v := newGoogleChatTokenVerifier("123456789")
+	v.certsURL = srv.URL
+	v.now = func() time.Time { return now }
+
+	// when
+	_, firstErr := v.publicKey(context.Background(), "unknown-1")
+	_, secondErr := v.publicKey(context.Background(), "unknown-2")
+	fetchesWithinInterval := fetches
+
+	now = now.Add(googleChatCertsMinRefetchInterval)
+	_, thirdErr := v.publicKey(context.Background(), "unknown-3")
+
+	// then
+	assert.EqualError(t, firstErr, `certificate with key ID "unknown-1" not found`)
+	assert.EqualError(t, secondErr, `certificate with key ID "unknown-2" not found`)
+	assert.EqualError(t, thirdErr, `certificate with key ID "unknown-3" not found`)
+	assert.Equal(t, 1, fetchesWithinInterval)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestNewGoogleChat_RequiresProjectNumber(t *testing.T) {
+	// given
+	cfg := fixGoogleChatConfig("")
+	cfg.ProjectNumber = ""
+
+	// when
+	_, err := NewGoogleChat(logrus.New(), "default", cfg, &fakeExecutorFactory{}, nil)
+
+	// then
+	assert.EqualError(t, err, "Google Chat project number is required to verify the incoming requests")
+}
+
+func fixGoogleChatConfig(webhookURL string) config.GoogleChat {
+	return config.GoogleChat{
+		BotName:       "Botkube",
+		ProjectNumber: "123456789",
+		Channels: config.IdentifiableMap[config.GoogleChatChannelBindings]{
+			"ops": {
+				ChannelBindingsByID: config.ChannelBindingsByID{
+					ID: "spaces/AAA",
+					Bindings: config.BotBindings{
+						Executors: []string{"kubectl-read-only"},
+					},
+				},
+				WebhookURL: webhookURL,
+			},
+		},
+	}
+}
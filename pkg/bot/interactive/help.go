@@ -17,6 +17,7 @@ type HelpMessage struct {
 	platform               config.CommPlatformIntegration
 	clusterName            string
 	enabledPluginExecutors []string
+	configRevision         string
 }
 
 // NewHelpMessage return a new instance of HelpMessage.
@@ -29,11 +30,20 @@ func NewHelpMessage(platform config.CommPlatformIntegration, clusterName string,
 	}
 }
 
+// WithConfigRevision sets the revision of the applied configuration, such as a Git commit SHA, which is reported in the message description.
+func (h *HelpMessage) WithConfigRevision(revision string) *HelpMessage {
+	h.configRevision = revision
+	return h
+}
+
 // Build returns help message with interactive sections.
 func (h *HelpMessage) Build() CoreMessage {
 	msg := CoreMessage{
 		Description: fmt.Sprintf("Botkube is now active for %q cluster :rocket:", h.clusterName),
 	}
+	if h.configRevision != "" {
+		msg.Description += fmt.Sprintf("\nApplied configuration revision: %s", h.configRevision)
+	}
 
 	type getter func() []api.Section
 	var sections = []getter{
@@ -57,7 +67,8 @@ func (h *HelpMessage) Build() CoreMessage {
 
 func (h *HelpMessage) cluster() []api.Section {
 	switch h.platform {
-	case config.SlackCommPlatformIntegration, config.DiscordCommPlatformIntegration, config.MattermostCommPlatformIntegration:
+	case config.SlackCommPlatformIntegration, config.DiscordCommPlatformIntegration, config.MattermostCommPlatformIntegration, config.TelegramCommPlatformIntegration,
+		config.GoogleChatCommPlatformIntegration, config.RocketChatCommPlatformIntegration:
 		return []api.Section{
 			{
 				Base: api.Base{
@@ -182,6 +193,18 @@ func (h *HelpMessage) configSections() []api.Section {
 				h.btnBuilder.ForCommandWithoutDesc("Display configuration", "show config"),
 			},
 		},
+		{
+			Base: api.Base{
+				Header:      "Review and revert configuration changes",
+				Description: "Changes made from chat, such as edited source bindings, are recorded together with their authors. Channel changes can be reverted only from the changed channel.",
+				Body: api.Body{
+					CodeBlock: fmt.Sprintf("%s rollback config [change ID]\n", api.MessageBotNamePlaceholder),
+				},
+			},
+			Buttons: []api.Button{
+				h.btnBuilder.ForCommandWithoutDesc("Display change history", "show config history"),
+			},
+		},
 	}
 }
 
@@ -53,6 +53,7 @@ type Mattermost struct {
 	serverURL       string
 	botName         string
 	teamName        string
+	teamID          string
 	webSocketURL    string
 	wsClient        *model.WebSocketClient
 	apiClient       *model.Client4
@@ -62,6 +63,8 @@ type Mattermost struct {
 	notifyMutex     sync.Mutex
 	botMentionRegex *regexp.Regexp
 	mdFormatter     interactive.MDFormatter
+	msgTracker      *eventMessageTracker
+	directMessages  *directMessageAuthorizer
 }
 
 // mattermostMessage contains message details to execute command and send back the result
@@ -118,12 +121,15 @@ func NewMattermost(log logrus.FieldLogger, commGroupName string, cfg config.Matt
 		serverURL:       cfg.URL,
 		botName:         cfg.BotName,
 		teamName:        cfg.Team,
+		teamID:          botTeam.Id,
 		apiClient:       client,
 		webSocketURL:    webSocketURL,
 		commGroupName:   commGroupName,
 		channels:        channelsByIDCfg,
 		botMentionRegex: botMentionRegex,
 		mdFormatter:     interactive.DefaultMDFormatter(),
+		msgTracker:      newEventMessageTracker(),
+		directMessages:  newDirectMessageAuthorizer(cfg.DirectMessages),
 	}, nil
 }
 
@@ -208,9 +214,15 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm *mattermostMessage) e
 		return fmt.Errorf("while getting post from event: %w", err)
 	}
 
-	// Handle message only if starts with mention
+	isDirectMessage := b.directMessages.Enabled() && mm.Event.GetData()["channel_type"] == string(model.ChannelTypeDirect)
+
+	// Handle message only if starts with mention, unless it's a direct message
 	trimmedMsg, found := b.findAndTrimBotMention(post.Message)
-	if !found {
+	switch {
+	case found:
+	case isDirectMessage:
+		trimmedMsg = " " + strings.TrimSpace(post.Message)
+	default:
 		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botName)
 		return nil
 	}
@@ -221,19 +233,33 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm *mattermostMessage) e
 	channel, exists := b.getChannels()[channelID]
 	mm.IsAuthChannel = exists
 
-	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
-		CommGroupName:   b.commGroupName,
-		Platform:        b.IntegrationName(),
-		NotifierHandler: b,
-		Conversation: execute.Conversation{
-			Alias:            channel.alias,
-			ID:               channel.Identifier(),
-			ExecutorBindings: channel.Bindings.Executors,
-			SourceBindings:   channel.Bindings.Sources,
-			IsAuthenticated:  mm.IsAuthChannel,
+	conversation := execute.Conversation{
+		Alias:            channel.alias,
+		ID:               channel.Identifier(),
+		ExecutorBindings: channel.Bindings.Executors,
+		SourceBindings:   channel.Bindings.Sources,
+		IsAuthenticated:  mm.IsAuthChannel,
+		CommandOrigin:    command.TypedOrigin,
+	}
+	if isDirectMessage {
+		executors, allowed := b.directMessages.ExecutorBindings(post.UserId, b.getUserEmailIfNeeded(post.UserId))
+		conversation = execute.Conversation{
+			ID:               channelID,
+			ExecutorBindings: executors,
+			IsAuthenticated:  allowed,
+			IsDirectMessage:  true,
 			CommandOrigin:    command.TypedOrigin,
-		},
-		Message: req,
+		}
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:    b.commGroupName,
+		Platform:         b.IntegrationName(),
+		NotifierHandler:  b,
+		Conversation:     conversation,
+		Message:          req,
+		UserID:           post.UserId,
+		UserInfoProvider: b,
 	})
 	response := e.Execute(ctx)
 	err = b.send(channelID, response)
@@ -375,7 +401,19 @@ func (b *Mattermost) listen(ctx context.Context) {
 // SendEvent sends event notification to Mattermost
 func (b *Mattermost) SendEvent(_ context.Context, event event.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Mattermost: %+v", event)
+
+	var (
+		status    trackedEventStatus
+		isTracked bool
+	)
+	if b.notification.UpdateExisting {
+		status, isTracked = b.msgTracker.Observe(event)
+	}
+
 	attachment := b.formatAttachments(event)
+	if isTracked {
+		attachment = b.appendEventHistory(attachment, status)
+	}
 
 	errs := multierror.New()
 	for _, channelID := range b.getChannelsToNotifyForEvent(event, eventSources) {
@@ -386,15 +424,36 @@ func (b *Mattermost) SendEvent(_ context.Context, event event.Event, eventSource
 			ChannelId: channelID,
 		}
 
-		_, _, err := b.apiClient.CreatePost(post)
+		if isTracked {
+			if ref, found := b.msgTracker.Get(status.UID, channelID); found {
+				post.Id = ref.MessageID
+				if _, _, err := b.apiClient.UpdatePost(ref.MessageID, post); err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("while updating message in channel %q: %w", channelID, err))
+					continue
+				}
+
+				b.log.Debugf("Event message %q successfully updated in channel %q", ref.MessageID, channelID)
+				continue
+			}
+		}
+
+		createdPost, _, err := b.apiClient.CreatePost(post)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while posting message to channel %q: %w", channelID, err))
 			continue
 		}
 
+		if isTracked {
+			b.msgTracker.Set(status.UID, channelID, trackedMessage{ChannelID: channelID, MessageID: createdPost.Id})
+		}
+
 		b.log.Debugf("Event successfully sent to channel %q", post.ChannelId)
 	}
 
+	if isTracked && event.Type == config.DeleteEvent {
+		b.msgTracker.Forget(status.UID)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -455,6 +514,50 @@ func (b *Mattermost) BotName() string {
 	return fmt.Sprintf("@%s", b.botName)
 }
 
+// GetUserInfo returns details about a given user. Both group IDs and names are returned.
+func (b *Mattermost) GetUserInfo(_ context.Context, userID string, opts execute.UserInfoOptions) (execute.UserInfo, error) {
+	user, _, err := b.apiClient.GetUser(userID, "")
+	if err != nil {
+		return execute.UserInfo{}, fmt.Errorf("while getting user: %w", err)
+	}
+
+	out := execute.UserInfo{
+		ID:    userID,
+		Email: user.Email,
+	}
+	if !opts.WithGroups {
+		return out, nil
+	}
+
+	groups, _, err := b.apiClient.GetGroupsByUserId(userID)
+	if err != nil {
+		return execute.UserInfo{}, fmt.Errorf("while getting user groups: %w", err)
+	}
+	for _, group := range groups {
+		out.Groups = append(out.Groups, group.Id)
+		if group.Name != nil {
+			out.Groups = append(out.Groups, *group.Name)
+		}
+	}
+
+	return out, nil
+}
+
+// getUserEmailIfNeeded returns the user email if any direct messages user is allowed by email.
+func (b *Mattermost) getUserEmailIfNeeded(userID string) string {
+	if !b.directMessages.RequiresEmail() {
+		return ""
+	}
+
+	user, _, err := b.apiClient.GetUser(userID, "")
+	if err != nil {
+		b.log.Errorf("while getting user %q: %s", userID, err.Error())
+		return ""
+	}
+
+	return user.Email
+}
+
 func (b *Mattermost) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
@@ -475,6 +578,17 @@ func (b *Mattermost) setChannels(channels map[string]channelConfigByID) {
 	b.channels = channels
 }
 
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *Mattermost) ReloadChannels(cfg config.Communications) error {
+	channels, err := mattermostChannelsCfgFrom(b.apiClient, b.teamID, cfg.Mattermost.Channels)
+	if err != nil {
+		return fmt.Errorf("while producing channels configuration map by ID: %w", err)
+	}
+	b.setChannels(channels)
+	return nil
+}
+
 func mattermostChannelsCfgFrom(client *model.Client4, teamID string, channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) (map[string]channelConfigByID, error) {
 	res := make(map[string]channelConfigByID)
 	for channAlias, channCfg := range channelsCfg {
@@ -2,7 +2,9 @@ package bot
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/v5/model"
 
@@ -34,6 +36,20 @@ func (b *Mattermost) formatAttachments(event event.Event) []*model.SlackAttachme
 	}
 }
 
+func (b *Mattermost) appendEventHistory(attachments []*model.SlackAttachment, status trackedEventStatus) []*model.SlackAttachment {
+	state := "Unresolved"
+	if status.Resolved {
+		state = "Resolved"
+	}
+
+	for _, attachment := range attachments {
+		attachment.Title = fmt.Sprintf("[%s] %s", state, attachment.Title)
+		attachment.Fields = b.appendIfNotEmpty(attachment.Fields, strings.Join(status.History, "\n"), "History", false)
+	}
+
+	return attachments
+}
+
 func (b *Mattermost) longNotification(event event.Event) []*model.SlackAttachmentField {
 	fields := []*model.SlackAttachmentField{
 		{
@@ -1,10 +1,19 @@
 package bot
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
 )
 
 func TestMattermost_FindAndTrimBotMention(t *testing.T) {
@@ -62,3 +71,112 @@ func TestMattermost_FindAndTrimBotMention(t *testing.T) {
 		})
 	}
 }
+
+func TestMattermost_HandleDirectMessage(t *testing.T) {
+	// given
+	dmCfg := config.DirectMessages{
+		Enabled: true,
+		Users: map[string]config.DirectMessageUsers{
+			"on-call": {
+				IDs:      []string{"user-1"},
+				Bindings: config.DirectMessageBindings{Executors: []string{"kubectl-read-only"}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name                  string
+		Config                config.DirectMessages
+		UserID                string
+		Message               string
+		ExpectSkip            bool
+		ExpectedMessage       string
+		ExpectedExecutors     []string
+		ExpectedAuthenticated bool
+	}{
+		{
+			Name:                  "Allowed user without mention",
+			Config:                dmCfg,
+			UserID:                "user-1",
+			Message:               "kubectl get pods",
+			ExpectedMessage:       " kubectl get pods",
+			ExpectedExecutors:     []string{"kubectl-read-only"},
+			ExpectedAuthenticated: true,
+		},
+		{
+			Name:                  "Not allowed user",
+			Config:                dmCfg,
+			UserID:                "user-2",
+			Message:               "@Botkube kubectl get pods",
+			ExpectedMessage:       " kubectl get pods",
+			ExpectedAuthenticated: false,
+		},
+		{
+			Name:       "Direct messages disabled",
+			Config:     config.DirectMessages{},
+			UserID:     "user-1",
+			Message:    "kubectl get pods",
+			ExpectSkip: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var postedChannels []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v4/posts", r.URL.Path)
+				var post model.Post
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&post))
+				postedChannels = append(postedChannels, post.ChannelId)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			botMentionRegex, err := mattermostBotMentionRegex("Botkube")
+			require.NoError(t, err)
+			factory := &fakeExecutorFactory{}
+			b := &Mattermost{
+				log:             logrus.New(),
+				executorFactory: factory,
+				botName:         "Botkube",
+				apiClient:       model.NewAPIv4Client(srv.URL),
+				channels:        map[string]channelConfigByID{},
+				botMentionRegex: botMentionRegex,
+				mdFormatter:     interactive.DefaultMDFormatter(),
+				msgTracker:      newEventMessageTracker(),
+				directMessages:  newDirectMessageAuthorizer(tc.Config),
+			}
+
+			rawPost, err := json.Marshal(&model.Post{UserId: tc.UserID, ChannelId: "dm-channel", Message: tc.Message})
+			require.NoError(t, err)
+			event := model.NewWebSocketEvent(model.WebsocketEventPosted, "", "dm-channel", "", nil).
+				SetData(map[string]any{
+					"post":         string(rawPost),
+					"channel_type": string(model.ChannelTypeDirect),
+				})
+
+			// when
+			err = b.handleMessage(context.Background(), &mattermostMessage{Event: event})
+
+			// then
+			require.NoError(t, err)
+			if tc.ExpectSkip {
+				assert.Empty(t, factory.inputs)
+				assert.Empty(t, postedChannels)
+				return
+			}
+
+			require.Len(t, factory.inputs, 1)
+			conversation := factory.inputs[0].Conversation
+			assert.Equal(t, tc.ExpectedMessage, factory.inputs[0].Message)
+			assert.Equal(t, "dm-channel", conversation.ID)
+			assert.True(t, conversation.IsDirectMessage)
+			assert.Equal(t, tc.ExpectedAuthenticated, conversation.IsAuthenticated)
+			assert.Equal(t, tc.ExpectedExecutors, conversation.ExecutorBindings)
+			assert.Empty(t, conversation.Alias)
+
+			assert.Equal(t, []string{"dm-channel"}, postedChannels)
+		})
+	}
+}
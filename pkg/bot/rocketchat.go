@@ -0,0 +1,461 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/httpsrv"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &RocketChat{}
+
+const (
+	defaultRocketChatPort           = "3980"
+	defaultRocketChatHTTPCliTimeout = 30 * time.Second
+
+	// rocketChatMaxMessageSize max size of the message text.
+	rocketChatMaxMessageSize = 5000
+
+	rocketChatBotMentionFmt = "^@(?i)%s"
+	rocketChatLongRespNote  = "Response is too long. Sending last few lines."
+
+	// rocketChatMaxConcurrentMessages is the maximum number of messages handled at the same time.
+	rocketChatMaxConcurrentMessages = 10
+)
+
+// RocketChat listens for user's message, execute commands and sends back the response.
+// Incoming messages are delivered by the Rocket.Chat outgoing webhook integration,
+// while responses and notifications are sent with the Rocket.Chat REST API.
+type RocketChat struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	apiCli          *rocketChatAPIClient
+	notification    config.Notification
+	botName         string
+	webhookToken    string
+	port            string
+	messagePath     string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByName
+	notifyMutex     sync.Mutex
+	botMentionRegex *regexp.Regexp
+	commGroupName   string
+	mdFormatter     interactive.MDFormatter
+	workers         chan struct{}
+	workersWG       sync.WaitGroup
+}
+
+// rocketChatWebhookRequest is a payload sent by the Rocket.Chat outgoing webhook.
+type rocketChatWebhookRequest struct {
+	Token       string `json:"token"`
+	Bot         bool   `json:"bot"`
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name"`
+	UserID      string `json:"user_id"`
+	UserName    string `json:"user_name"`
+	Text        string `json:"text"`
+}
+
+// NewRocketChat creates a new RocketChat instance.
+func NewRocketChat(log logrus.FieldLogger, commGroupName string, cfg config.RocketChat, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*RocketChat, error) {
+	botMentionRegex, err := regexp.Compile(fmt.Sprintf(rocketChatBotMentionFmt, regexp.QuoteMeta(cfg.BotName)))
+	if err != nil {
+		return nil, fmt.Errorf("while compiling bot mention regex: %w", err)
+	}
+
+	if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+		return nil, fmt.Errorf("while parsing Rocket.Chat URL %q: %w", cfg.URL, err)
+	}
+
+	port := cfg.Port
+	if port == "" {
+		port = defaultRocketChatPort
+	}
+	msgPath := cfg.MessagePath
+	if msgPath == "" {
+		msgPath = "/"
+	}
+
+	if cfg.WebhookToken == "" {
+		return nil, errors.New("Rocket.Chat webhook token is required to verify the incoming requests")
+	}
+
+	return &RocketChat{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		apiCli:          newRocketChatAPIClient(cfg.URL, cfg.UserID, cfg.Token),
+		notification:    cfg.Notification,
+		botName:         cfg.BotName,
+		webhookToken:    cfg.WebhookToken,
+		port:            port,
+		messagePath:     msgPath,
+		channels:        slackChannelsConfigFrom(cfg.Channels),
+		botMentionRegex: botMentionRegex,
+		commGroupName:   commGroupName,
+		mdFormatter:     interactive.DefaultMDFormatter(),
+		workers:         make(chan struct{}, rocketChatMaxConcurrentMessages),
+	}, nil
+}
+
+// Start starts the HTTP server which handles the Rocket.Chat outgoing webhook requests.
+func (b *RocketChat) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	if err := b.apiCli.Me(ctx); err != nil {
+		return fmt.Errorf("while checking Rocket.Chat credentials: %w", err)
+	}
+
+	// commands are executed after the webhook request is handled, so wait for them before returning
+	defer b.workersWG.Wait()
+
+	router := mux.NewRouter()
+	router.PathPrefix(b.messagePath).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b.handleWebhook(ctx, w, req)
+	}).Methods(http.MethodPost)
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	srv := httpsrv.New(b.log, fmt.Sprintf(":%s", b.port), router)
+	err = srv.Serve(ctx)
+	if err != nil {
+		return fmt.Errorf("while running Rocket.Chat server: %w", err)
+	}
+
+	return nil
+}
+
+// SendEvent sends event notification to Rocket.Chat channels.
+func (b *RocketChat) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Rocket.Chat: %+v", event)
+
+	msg := b.renderEventMessage(event)
+
+	errs := multierror.New()
+	for _, channelName := range b.getChannelsToNotify(event, eventSources) {
+		msg.Channel = rocketChatChannel(channelName)
+		if err := b.apiCli.PostMessage(ctx, msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Rocket.Chat message to channel %q: %w", channelName, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to channel %q", channelName)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessage sends interactive message to selected Rocket.Chat channels.
+func (b *RocketChat) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	errs := multierror.New()
+	for _, channelName := range b.getChannelsToNotify(event.Event{}, sourceBindings) {
+		if err := b.send(ctx, rocketChatChannel(channelName), msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Rocket.Chat message to channel %q: %w", channelName, err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends interactive message to all Rocket.Chat channels.
+func (b *RocketChat) SendMessageToAll(ctx context.Context, msg interactive.CoreMessage) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		channelName := channel.Identifier()
+		if err := b.send(ctx, rocketChatChannel(channelName), msg); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Rocket.Chat message to channel %q: %w", channelName, err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// IntegrationName describes the integration name.
+func (b *RocketChat) IntegrationName() config.CommPlatformIntegration {
+	return config.RocketChatCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (b *RocketChat) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given channel name.
+func (b *RocketChat) NotificationsEnabled(channelName string) bool {
+	channel, exists := b.getChannels()[channelName]
+	if !exists {
+		return false
+	}
+
+	return channel.notify
+}
+
+// SetNotificationsEnabled sets a new notification status for a given channel name.
+func (b *RocketChat) SetNotificationsEnabled(channelName string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[channelName]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channels[channelName] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// BotName returns the Bot name.
+func (b *RocketChat) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+// handleWebhook responds to the outgoing webhook request immediately and handles the message in the background.
+// Otherwise, long-running commands would exceed the outgoing webhook timeout, and Rocket.Chat would retry them.
+func (b *RocketChat) handleWebhook(ctx context.Context, w http.ResponseWriter, req *http.Request) {
+	var in rocketChatWebhookRequest
+	if err := json.NewDecoder(req.Body).Decode(&in); err != nil {
+		b.log.Errorf("while decoding Rocket.Chat webhook request: %s", err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if in.Token == "" || subtle.ConstantTimeCompare([]byte(in.Token), []byte(b.webhookToken)) != 1 {
+		b.log.Error("while verifying Rocket.Chat webhook request: missing or invalid token")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	select {
+	case b.workers <- struct{}{}:
+	default:
+		b.log.Error("while handling Rocket.Chat message: too many messages are being handled")
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+		return
+	}
+	b.workersWG.Add(1)
+	go func() {
+		defer func() {
+			<-b.workers
+			b.workersWG.Done()
+		}()
+
+		if err := b.handleMessage(ctx, in); err != nil {
+			b.log.Errorf("while handling Rocket.Chat message: %s", err.Error())
+		}
+	}()
+
+	// The outgoing webhook expects a response, but the command output is sent with the REST API,
+	// so the message can be sent with the bot identity.
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte("{}"))
+}
+
+func (b *RocketChat) handleMessage(ctx context.Context, in rocketChatWebhookRequest) error {
+	if in.Bot || strings.EqualFold(in.UserName, b.botName) {
+		return nil
+	}
+
+	text := strings.TrimSpace(in.Text)
+	if !b.botMentionRegex.MatchString(text) {
+		return nil
+	}
+	req := b.botMentionRegex.ReplaceAllString(text, "")
+	b.log.Debugf("Rocket.Chat incoming Request: %s", req)
+
+	channel, isAuthChannel := b.getChannels()[in.ChannelName]
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               channel.Identifier(),
+			ExecutorBindings: channel.Bindings.Executors,
+			SourceBindings:   channel.Bindings.Sources,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    command.TypedOrigin,
+		},
+		Message: req,
+		User:    fmt.Sprintf("@%s", in.UserName),
+		UserID:  in.UserID,
+	})
+	response := e.Execute(ctx)
+
+	if err := b.send(ctx, in.ChannelID, response); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *RocketChat) send(ctx context.Context, channel string, resp interactive.CoreMessage) error {
+	b.log.Debugf("Sending message to channel %q: %+v", channel, resp)
+
+	msg := b.renderMessage(resp)
+	msg.Channel = channel
+
+	return b.apiCli.PostMessage(ctx, msg)
+}
+
+func (b *RocketChat) getChannelsToNotify(event event.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	var out []string
+	for _, cfg := range b.getChannels() {
+		if !cfg.notify {
+			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
+			continue
+		}
+
+		if !sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+			continue
+		}
+
+		out = append(out, cfg.Identifier())
+	}
+	return out
+}
+
+func (b *RocketChat) getChannels() map[string]channelConfigByName {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *RocketChat) setChannels(channels map[string]channelConfigByName) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *RocketChat) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(slackChannelsConfigFrom(cfg.RocketChat.Channels))
+	return nil
+}
+
+// rocketChatChannel returns the channel reference accepted by the Rocket.Chat REST API.
+func rocketChatChannel(name string) string {
+	if strings.HasPrefix(name, "#") {
+		return name
+	}
+	return fmt.Sprintf("#%s", name)
+}
+
+// rocketChatAPIClient is a minimal Rocket.Chat REST API client.
+// See: https://developer.rocket.chat/reference/api/rest-api
+type rocketChatAPIClient struct {
+	httpCli *http.Client
+	baseURL string
+	userID  string
+	token   string
+}
+
+type rocketChatAPIResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+func newRocketChatAPIClient(baseURL, userID, token string) *rocketChatAPIClient {
+	return &rocketChatAPIClient{
+		httpCli: &http.Client{Timeout: defaultRocketChatHTTPCliTimeout},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		userID:  userID,
+		token:   token,
+	}
+}
+
+// Me checks whether the configured credentials are valid.
+func (c *rocketChatAPIClient) Me(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "me", nil)
+}
+
+// PostMessage posts a given message.
+func (c *rocketChatAPIClient) PostMessage(ctx context.Context, msg rocketChatMessage) error {
+	return c.do(ctx, http.MethodPost, "chat.postMessage", msg)
+}
+
+func (c *rocketChatAPIClient) do(ctx context.Context, method, endpoint string, in interface{}) (err error) {
+	var body io.Reader
+	if in != nil {
+		raw, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("while marshaling request: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/api/v1/%s", c.baseURL, endpoint), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-Id", c.userID)
+	req.Header.Set("X-Auth-Token", c.token)
+
+	resp, err := c.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiResp rocketChatAPIResponse
+		if decodeErr := json.NewDecoder(resp.Body).Decode(&apiResp); decodeErr == nil && apiResp.Error != "" {
+			return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, apiResp.Error)
+		}
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var apiResp rocketChatAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("while decoding response: %w", err)
+	}
+	if !apiResp.Success {
+		return errors.New(apiResp.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+// rocketChatMessage is a message sent with the Rocket.Chat REST API.
+// See: https://developer.rocket.chat/reference/api/rest-api/endpoints/core-endpoints/chat-endpoints/postmessage
+type rocketChatMessage struct {
+	Channel     string                 `json:"channel"`
+	Text        string                 `json:"text,omitempty"`
+	Attachments []rocketChatAttachment `json:"attachments,omitempty"`
+}
+
+type rocketChatAttachment struct {
+	Title   string             `json:"title,omitempty"`
+	Text    string             `json:"text,omitempty"`
+	Color   string             `json:"color,omitempty"`
+	TS      string             `json:"ts,omitempty"`
+	Fields  []rocketChatField  `json:"fields,omitempty"`
+	Actions []rocketChatAction `json:"actions,omitempty"`
+}
+
+type rocketChatField struct {
+	Short bool   `json:"short"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type rocketChatAction struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	// URL opens a given link when the button is clicked.
+	URL string `json:"url,omitempty"`
+	// Msg is sent to the chat on behalf of the user when the button is clicked.
+	Msg             string `json:"msg,omitempty"`
+	MsgInChatWindow bool   `json:"msg_in_chat_window,omitempty"`
+}
+
+// renderMessage renders a given message. Buttons are rendered as attachment actions, which
+// post the assigned command to the chat, so it is handled in the same way as a typed one.
+func (b *RocketChat) renderMessage(msg interactive.CoreMessage) rocketChatMessage {
+	msg.ReplaceBotNamePlaceholder(b.BotName())
+
+	text := interactive.RenderMessage(b.mdFormatter, withoutButtons(msg))
+	if len(text) >= rocketChatMaxMessageSize {
+		tail := text[len(text)-rocketChatMaxMessageSize+len(rocketChatLongRespNote)+1:]
+		text = fmt.Sprintf("%s\n%s", rocketChatLongRespNote, tail)
+	}
+
+	out := rocketChatMessage{Text: text}
+	for _, section := range msg.Sections {
+		actions := b.renderActions(section.Buttons)
+		if len(actions) == 0 {
+			continue
+		}
+		out.Attachments = append(out.Attachments, rocketChatAttachment{
+			Title:   section.Header,
+			Actions: actions,
+		})
+	}
+
+	return out
+}
+
+func (b *RocketChat) renderActions(in api.Buttons) []rocketChatAction {
+	var out []rocketChatAction
+	for _, btn := range in {
+		switch {
+		case btn.URL != "":
+			out = append(out, rocketChatAction{Type: "button", Text: btn.Name, URL: btn.URL})
+		case btn.Command != "":
+			out = append(out, rocketChatAction{Type: "button", Text: btn.Name, Msg: btn.Command, MsgInChatWindow: true})
+		}
+	}
+	return out
+}
+
+// renderEventMessage renders an attachment for a given event.
+func (b *RocketChat) renderEventMessage(event event.Event) rocketChatMessage {
+	var fields []rocketChatField
+	switch b.notification.Type {
+	case config.LongNotification:
+		fields = b.longNotification(event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		fields = b.shortNotification(event)
+	}
+
+	attachment := rocketChatAttachment{
+		Title:  event.Title,
+		Color:  attachmentColor[event.Level],
+		Fields: fields,
+	}
+	if !event.TimeStamp.IsZero() {
+		attachment.TS = event.TimeStamp.UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+
+	return rocketChatMessage{
+		Attachments: []rocketChatAttachment{attachment},
+	}
+}
+
+func (b *RocketChat) longNotification(event event.Event) []rocketChatField {
+	var fields []rocketChatField
+	fields = b.appendIfNotEmpty(fields, event.Kind, "Kind", true)
+	fields = b.appendIfNotEmpty(fields, event.Name, "Name", true)
+	fields = b.appendIfNotEmpty(fields, event.Namespace, "Namespace", true)
+	fields = b.appendIfNotEmpty(fields, event.Reason, "Reason", true)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Messages), "Message", false)
+	fields = b.appendIfNotEmpty(fields, event.Action, "Action", true)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	fields = b.appendIfNotEmpty(fields, event.Cluster, "Cluster", false)
+	return fields
+}
+
+func (b *RocketChat) shortNotification(event event.Event) []rocketChatField {
+	fields := []rocketChatField{
+		{
+			Value: formatx.ShortNotificationHeader(event),
+		},
+	}
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Messages), "Messages", false)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Recommendations), "Recommendations", false)
+	fields = b.appendIfNotEmpty(fields, formatx.JoinMessages(event.Warnings), "Warnings", false)
+	return fields
+}
+
+func (b *RocketChat) appendIfNotEmpty(fields []rocketChatField, in string, title string, short bool) []rocketChatField {
+	if in == "" {
+		return fields
+	}
+	return append(fields, rocketChatField{
+		Title: title,
+		Value: strings.TrimSpace(in),
+		Short: short,
+	})
+}
@@ -0,0 +1,194 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestRocketChat_HandleWebhook(t *testing.T) {
+	// given
+	testCases := []struct {
+		Name               string
+		Request            rocketChatWebhookRequest
+		ExpectedStatusCode int
+		ExpectedMessage    string
+	}{
+		{
+			Name: "Mention",
+			Request: rocketChatWebhookRequest{
+				Token:       "webhook-token",
+				ChannelID:   "room-id",
+				ChannelName: "ops",
+				UserName:    "john",
+				Text:        "@botkube kubectl get pods",
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedMessage:    " kubectl get pods",
+		},
+		{
+			Name: "No mention",
+			Request: rocketChatWebhookRequest{
+				Token:       "webhook-token",
+				ChannelID:   "room-id",
+				ChannelName: "ops",
+				UserName:    "john",
+				Text:        "kubectl get pods",
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "Message from bot",
+			Request: rocketChatWebhookRequest{
+				Token:       "webhook-token",
+				ChannelID:   "room-id",
+				ChannelName: "ops",
+				UserName:    "botkube",
+				Text:        "@botkube kubectl get pods",
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		{
+			Name: "Invalid token",
+			Request: rocketChatWebhookRequest{
+				Token:       "other-token",
+				ChannelID:   "room-id",
+				ChannelName: "ops",
+				UserName:    "john",
+				Text:        "@botkube kubectl get pods",
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			Name: "Missing token",
+			Request: rocketChatWebhookRequest{
+				ChannelID:   "room-id",
+				ChannelName: "ops",
+				UserName:    "john",
+				Text:        "@botkube kubectl get pods",
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var sent []rocketChatMessage
+			apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/chat.postMessage", r.URL.Path)
+				assert.Equal(t, "user-id", r.Header.Get("X-User-Id"))
+				assert.Equal(t, "token", r.Header.Get("X-Auth-Token"))
+
+				var msg rocketChatMessage
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+				sent = append(sent, msg)
+				_, _ = w.Write([]byte(`{"success":true}`))
+			}))
+			defer apiSrv.Close()
+
+			factory := &fakeExecutorFactory{}
+			b, err := NewRocketChat(logrus.New(), "default", fixRocketChatConfig(apiSrv.URL), factory, nil)
+			require.NoError(t, err)
+
+			body, err := json.Marshal(tc.Request)
+			require.NoError(t, err)
+			rec := httptest.NewRecorder()
+
+			// when
+			b.handleWebhook(context.Background(), rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+			b.workersWG.Wait()
+
+			// then
+			assert.Equal(t, tc.ExpectedStatusCode, rec.Code)
+			if tc.ExpectedMessage == "" {
+				assert.Empty(t, factory.inputs)
+				assert.Empty(t, sent)
+				return
+			}
+
+			require.Len(t, factory.inputs, 1)
+			in := factory.inputs[0]
+			assert.Equal(t, tc.ExpectedMessage, in.Message)
+			assert.Equal(t, "@john", in.User)
+			assert.True(t, in.Conversation.IsAuthenticated)
+			assert.Equal(t, "default", in.Conversation.Alias)
+			assert.Equal(t, []string{"kubectl-read-only"}, in.Conversation.ExecutorBindings)
+
+			require.Len(t, sent, 1)
+			assert.Equal(t, "room-id", sent[0].Channel)
+			assert.Contains(t, sent[0].Text, "pong")
+		})
+	}
+}
+
+func TestRocketChat_HandleWebhookRespondsBeforeCommandFinishes(t *testing.T) {
+	// given
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer apiSrv.Close()
+
+	release := make(chan struct{})
+	b, err := NewRocketChat(logrus.New(), "default", fixRocketChatConfig(apiSrv.URL), &blockingExecutorFactory{release: release}, nil)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(rocketChatWebhookRequest{
+		Token:       "webhook-token",
+		ChannelID:   "room-id",
+		ChannelName: "ops",
+		UserName:    "john",
+		Text:        "@botkube kubectl slow",
+	})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+
+	// when
+	b.handleWebhook(context.Background(), rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, b.workers, 1, "command should be still running")
+
+	close(release)
+	b.workersWG.Wait()
+	assert.Len(t, b.workers, 0)
+}
+
+func TestNewRocketChat_RequiresWebhookToken(t *testing.T) {
+	// given
+	cfg := fixRocketChatConfig("http://localhost")
+	cfg.WebhookToken = ""
+
+	// when
+	_, err := NewRocketChat(logrus.New(), "default", cfg, &fakeExecutorFactory{}, nil)
+
+	// then
+	assert.EqualError(t, err, "Rocket.Chat webhook token is required to verify the incoming requests")
+}
+
+func fixRocketChatConfig(url string) config.RocketChat {
+	return config.RocketChat{
+		BotName:      "botkube",
+		URL:          url,
+		UserID:       "user-id",
+		Token:        "token",
+		WebhookToken: "webhook-token",
+		Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+			"default": {
+				Name: "ops",
+				Bindings: config.BotBindings{
+					Executors: []string{"kubectl-read-only"},
+				},
+			},
+		},
+	}
+}
@@ -59,6 +59,7 @@ type Slack struct {
 	commGroupName   string
 	renderer        *SlackRenderer
 	mdFormatter     interactive.MDFormatter
+	userGroups      *slackUserGroupsCache
 }
 
 // slackMessage contains message details to execute command and send back the result
@@ -102,6 +103,7 @@ func NewSlack(log logrus.FieldLogger, commGroupName string, cfg config.Slack, ex
 		renderer:        NewSlackRenderer(cfg.Notification),
 		botMentionRegex: botMentionRegex,
 		mdFormatter:     mdFormatter,
+		userGroups:      newSlackUserGroupsCache(client),
 	}, nil
 }
 
@@ -248,8 +250,10 @@ func (b *Slack) handleMessage(ctx context.Context, msg slackMessage) error {
 			IsAuthenticated:  isAuthChannel,
 			CommandOrigin:    command.TypedOrigin,
 		},
-		Message: request,
-		User:    fmt.Sprintf("<@%s>", msg.User),
+		Message:          request,
+		User:             fmt.Sprintf("<@%s>", msg.User),
+		UserID:           msg.User,
+		UserInfoProvider: b,
 	})
 	response := e.Execute(ctx)
 	err = b.send(ctx, msg, response, response.OnlyVisibleForYou)
@@ -300,6 +304,11 @@ func (b *Slack) send(ctx context.Context, msg slackMessage, resp interactive.Cor
 	return nil
 }
 
+// GetUserInfo returns details about a given user.
+func (b *Slack) GetUserInfo(ctx context.Context, userID string, opts execute.UserInfoOptions) (execute.UserInfo, error) {
+	return slackUserInfo(ctx, b.client, b.userGroups, userID, opts)
+}
+
 // SendEvent sends event notification to slack
 func (b *Slack) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Slack: %+v", event)
@@ -399,6 +408,13 @@ func (b *Slack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *Slack) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(slackChannelsConfigFrom(cfg.Slack.Channels))
+	return nil
+}
+
 func (b *Slack) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
@@ -85,6 +85,26 @@ func (b *SlackRenderer) RenderEventMessage(event event.Event, additionalSections
 	}
 }
 
+// RenderEventHistorySection returns section with the status and history of a tracked Kubernetes object.
+func (b *SlackRenderer) RenderEventHistorySection(status trackedEventStatus) api.Section {
+	header := ":hourglass_flowing_sand: Unresolved"
+	if status.Resolved {
+		header = ":white_check_mark: Resolved"
+	}
+
+	var items []api.ContextItem
+	for _, entry := range status.History {
+		items = append(items, api.ContextItem{Text: entry})
+	}
+
+	return api.Section{
+		Base: api.Base{
+			Header: header,
+		},
+		Context: items,
+	}
+}
+
 // RenderModal returns a modal request view based on a given message.
 func (b *SlackRenderer) RenderModal(msg interactive.CoreMessage) slack.ModalViewRequest {
 	title := msg.Header
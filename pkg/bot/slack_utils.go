@@ -1,13 +1,23 @@
 package bot
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
 
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
 )
 
-const slackBotMentionPrefixFmt = "^<@%s>"
+const (
+	slackBotMentionPrefixFmt = "^<@%s>"
+	// slackUserGroupsCacheTTL defines how long the user groups membership is cached.
+	slackUserGroupsCacheTTL = 5 * time.Minute
+)
 
 func slackChannelsConfigFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByName]) map[string]channelConfigByName {
 	channels := make(map[string]channelConfigByName)
@@ -30,3 +40,72 @@ func slackBotMentionRegex(botID string) (*regexp.Regexp, error) {
 
 	return botMentionRegex, nil
 }
+
+// slackUserInfo returns the user email and, if requested, user groups. Both user group IDs and handles are returned.
+// It requires the `users:read.email` and `usergroups:read` scopes.
+func slackUserInfo(ctx context.Context, client *slack.Client, groupsCache *slackUserGroupsCache, userID string, opts execute.UserInfoOptions) (execute.UserInfo, error) {
+	user, err := client.GetUserInfoContext(ctx, userID)
+	if err != nil {
+		return execute.UserInfo{}, fmt.Errorf("while getting user info: %w", err)
+	}
+
+	out := execute.UserInfo{
+		ID:    userID,
+		Email: user.Profile.Email,
+	}
+	if !opts.WithGroups {
+		return out, nil
+	}
+
+	groups, err := groupsCache.UserGroups(ctx, userID)
+	if err != nil {
+		return execute.UserInfo{}, err
+	}
+	out.Groups = groups
+
+	return out, nil
+}
+
+// slackUserGroupsCache caches the user groups membership. Slack doesn't provide an API to get groups of a given user,
+// so all user groups with their members are listed, which is expensive for large workspaces.
+type slackUserGroupsCache struct {
+	client *slack.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	fetchedAt time.Time
+	byUser    map[string][]string
+}
+
+func newSlackUserGroupsCache(client *slack.Client) *slackUserGroupsCache {
+	return &slackUserGroupsCache{
+		client: client,
+		ttl:    slackUserGroupsCacheTTL,
+		now:    time.Now,
+	}
+}
+
+// UserGroups returns IDs and handles of user groups that a given user is a member of.
+func (c *slackUserGroupsCache) UserGroups(ctx context.Context, userID string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byUser == nil || c.now().Sub(c.fetchedAt) >= c.ttl {
+		groups, err := c.client.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeUsers(true))
+		if err != nil {
+			return nil, fmt.Errorf("while getting user groups: %w", err)
+		}
+
+		byUser := make(map[string][]string)
+		for _, group := range groups {
+			for _, member := range group.Users {
+				byUser[member] = append(byUser[member], group.ID, group.Handle)
+			}
+		}
+		c.byUser = byUser
+		c.fetchedAt = c.now()
+	}
+
+	return c.byUser[userID], nil
+}
@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+func TestSlackUserInfo(t *testing.T) {
+	// given
+	var groupsCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.info":
+			_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U123","profile":{"email":"john@example.com"}}}`))
+		case "/usergroups.list":
+			groupsCalls++
+			_, _ = w.Write([]byte(`{"ok":true,"usergroups":[{"id":"S1","handle":"sre","users":["U123"]},{"id":"S2","handle":"dev","users":["U456"]}]}`))
+		default:
+			t.Errorf("unexpected request to %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := slack.New("token", slack.OptionAPIURL(srv.URL+"/"))
+	now := time.Now()
+	cache := newSlackUserGroupsCache(client)
+	cache.now = func() time.Time { return now }
+
+	// when
+	withoutGroups, err := slackUserInfo(context.Background(), client, cache, "U123", execute.UserInfoOptions{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, execute.UserInfo{ID: "U123", Email: "john@example.com"}, withoutGroups)
+	assert.Zero(t, groupsCalls)
+
+	// when
+	for i := 0; i < 2; i++ {
+		withGroups, err := slackUserInfo(context.Background(), client, cache, "U123", execute.UserInfoOptions{WithGroups: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"S1", "sre"}, withGroups.Groups)
+	}
+
+	// then
+	assert.Equal(t, 1, groupsCalls)
+
+	// when
+	now = now.Add(slackUserGroupsCacheTTL)
+	_, err = slackUserInfo(context.Background(), client, cache, "U123", execute.UserInfoOptions{WithGroups: true})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, groupsCalls)
+}
@@ -54,6 +54,10 @@ type SocketSlack struct {
 	commGroupName    string
 	renderer         *SlackRenderer
 	mdFormatter      interactive.MDFormatter
+	updateExisting   bool
+	msgTracker       *eventMessageTracker
+	directMessages   *directMessageAuthorizer
+	userGroups       *slackUserGroupsCache
 }
 
 type socketSlackMessage struct {
@@ -66,6 +70,8 @@ type socketSlackMessage struct {
 	State           *slack.BlockActionStates
 	ResponseURL     string
 	BlockID         string
+	// IsDirectMessage is true if the message was sent in a direct message, where the bot mention is optional.
+	IsDirectMessage bool
 }
 
 // socketSlackAnalyticsReporter defines a reporter that collects analytics data.
@@ -107,6 +113,10 @@ func NewSocketSlack(log logrus.FieldLogger, commGroupName string, cfg config.Soc
 		renderer:         NewSlackRenderer(cfg.Notification),
 		botMentionRegex:  botMentionRegex,
 		mdFormatter:      mdFormatter,
+		updateExisting:   cfg.Notification.UpdateExisting,
+		msgTracker:       newEventMessageTracker(),
+		directMessages:   newDirectMessageAuthorizer(cfg.DirectMessages),
+		userGroups:       newSlackUserGroupsCache(client),
 	}, nil
 }
 
@@ -164,6 +174,22 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 						if err := b.handleMessage(ctx, msg); err != nil {
 							b.log.Errorf("Message handling error: %s", err.Error())
 						}
+					case *slackevents.MessageEvent:
+						if !b.shouldHandleDirectMessage(ev) {
+							continue
+						}
+						b.log.Debugf("Got direct message %s", format.StructDumper().Sdump(innerEvent))
+						msg := socketSlackMessage{
+							Text:            ev.Text,
+							Channel:         ev.Channel,
+							ThreadTimeStamp: ev.ThreadTimeStamp,
+							User:            ev.User,
+							CommandOrigin:   command.TypedOrigin,
+							IsDirectMessage: true,
+						}
+						if err := b.handleMessage(ctx, msg); err != nil {
+							b.log.Errorf("Message handling error: %s", err.Error())
+						}
 					}
 				}
 			case socketmode.EventTypeInteractive:
@@ -321,9 +347,13 @@ func (b *SocketSlack) SetNotificationsEnabled(channelName string, enabled bool)
 }
 
 func (b *SocketSlack) handleMessage(ctx context.Context, event socketSlackMessage) error {
-	// Handle message only if starts with mention
+	// Handle message only if starts with mention, unless it's a direct message
 	request, found := b.findAndTrimBotMention(event.Text)
-	if !found {
+	switch {
+	case found:
+	case event.IsDirectMessage:
+		request = " " + strings.TrimSpace(event.Text)
+	default:
 		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.botID)
 		return nil
 	}
@@ -340,22 +370,36 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event socketSlackMessag
 	}
 
 	channel, isAuthChannel := b.getChannels()[info.Name]
-
-	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
-		CommGroupName:   b.commGroupName,
-		Platform:        b.IntegrationName(),
-		NotifierHandler: b,
-		Conversation: execute.Conversation{
-			Alias:            channel.alias,
-			ID:               channel.Identifier(),
-			ExecutorBindings: channel.Bindings.Executors,
-			SourceBindings:   channel.Bindings.Sources,
-			IsAuthenticated:  isAuthChannel,
+	conversation := execute.Conversation{
+		Alias:            channel.alias,
+		ID:               channel.Identifier(),
+		ExecutorBindings: channel.Bindings.Executors,
+		SourceBindings:   channel.Bindings.Sources,
+		IsAuthenticated:  isAuthChannel,
+		CommandOrigin:    event.CommandOrigin,
+		SlackState:       event.State,
+	}
+	if info.IsIM && b.directMessages.Enabled() {
+		executors, allowed := b.directMessages.ExecutorBindings(event.User, b.getUserEmailIfNeeded(ctx, event.User))
+		conversation = execute.Conversation{
+			ID:               event.Channel,
+			ExecutorBindings: executors,
+			IsAuthenticated:  allowed,
+			IsDirectMessage:  true,
 			CommandOrigin:    event.CommandOrigin,
 			SlackState:       event.State,
-		},
-		Message: request,
-		User:    fmt.Sprintf("<@%s>", event.User),
+		}
+	}
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:    b.commGroupName,
+		Platform:         b.IntegrationName(),
+		NotifierHandler:  b,
+		Conversation:     conversation,
+		Message:          request,
+		User:             fmt.Sprintf("<@%s>", event.User),
+		UserID:           event.User,
+		UserInfoProvider: b,
 	})
 	response := e.Execute(ctx)
 	err = b.send(ctx, event, response)
@@ -432,6 +476,14 @@ func (b *SocketSlack) send(ctx context.Context, event socketSlackMessage, resp i
 func (b *SocketSlack) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
 	b.log.Debugf("Sending to Slack: %+v", event)
 
+	var (
+		status    trackedEventStatus
+		isTracked bool
+	)
+	if b.updateExisting {
+		status, isTracked = b.msgTracker.Observe(event)
+	}
+
 	errs := multierror.New()
 	for _, channelName := range b.getChannelsToNotifyForEvent(event, eventSources) {
 		additionalSection := b.getInteractiveEventSectionIfShould(event, channelName)
@@ -440,21 +492,45 @@ func (b *SocketSlack) SendEvent(ctx context.Context, event event.Event, eventSou
 		if additionalSection != nil {
 			additionalSections = append(additionalSections, *additionalSection)
 		}
+		if isTracked {
+			additionalSections = append(additionalSections, b.renderer.RenderEventHistorySection(status))
+		}
 		msg := b.renderer.RenderEventMessage(event, additionalSections...)
 
 		options := []slack.MsgOption{
 			b.renderer.RenderInteractiveMessage(msg),
 		}
 
+		if isTracked {
+			if ref, found := b.msgTracker.Get(status.UID, channelName); found {
+				_, _, _, err := b.client.UpdateMessageContext(ctx, ref.ChannelID, ref.MessageID, options...)
+				if err != nil {
+					errs = multierror.Append(errs, fmt.Errorf("while updating message in channel %q: %w", channelName, err))
+					continue
+				}
+
+				b.log.Debugf("Event message successfully updated in channel %q (ID: %q) at %s", channelName, ref.ChannelID, ref.MessageID)
+				continue
+			}
+		}
+
 		channelID, timestamp, err := b.client.PostMessageContext(ctx, channelName, options...)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while posting message to channel %q: %w", channelName, err))
 			continue
 		}
 
+		if isTracked {
+			b.msgTracker.Set(status.UID, channelName, trackedMessage{ChannelID: channelID, MessageID: timestamp})
+		}
+
 		b.log.Debugf("Event successfully sent to channel %q (ID: %q) at %b", channelName, channelID, timestamp)
 	}
 
+	if isTracked && event.Type == config.DeleteEvent {
+		b.msgTracker.Forget(status.UID)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -568,6 +644,44 @@ func (b *SocketSlack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *SocketSlack) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(slackChannelsConfigFrom(cfg.SocketSlack.Channels))
+	return nil
+}
+
+// GetUserInfo returns details about a given user.
+func (b *SocketSlack) GetUserInfo(ctx context.Context, userID string, opts execute.UserInfoOptions) (execute.UserInfo, error) {
+	return slackUserInfo(ctx, b.client, b.userGroups, userID, opts)
+}
+
+// shouldHandleDirectMessage returns true if a given message was sent by user in a direct message with the bot.
+func (b *SocketSlack) shouldHandleDirectMessage(ev *slackevents.MessageEvent) bool {
+	if !b.directMessages.Enabled() || ev.ChannelType != slack.TYPE_IM {
+		return false
+	}
+
+	// skip messages sent by bots, including Botkube itself, and message updates
+	return ev.BotID == "" && ev.User != b.botID && ev.SubType == ""
+}
+
+// getUserEmailIfNeeded returns the user email if any direct messages user is allowed by email.
+// It requires the `users:read.email` scope.
+func (b *SocketSlack) getUserEmailIfNeeded(ctx context.Context, userID string) string {
+	if !b.directMessages.RequiresEmail() {
+		return ""
+	}
+
+	user, err := b.client.GetUserInfoContext(ctx, userID)
+	if err != nil {
+		b.log.Errorf("while getting user %q info: %s", userID, err.Error())
+		return ""
+	}
+
+	return user.Profile.Email
+}
+
 func (b *SocketSlack) findAndTrimBotMention(msg string) (string, bool) {
 	if !b.botMentionRegex.MatchString(msg) {
 		return "", false
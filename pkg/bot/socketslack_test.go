@@ -1,10 +1,19 @@
 package bot
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
 )
 
 func TestNormalizeState(t *testing.T) {
@@ -59,3 +68,94 @@ func TestNormalizeState(t *testing.T) {
 	// then
 	assert.Equal(t, exp, out)
 }
+
+func TestSocketSlack_HandleDirectMessage(t *testing.T) {
+	// given
+	dmCfg := config.DirectMessages{
+		Enabled: true,
+		Users: map[string]config.DirectMessageUsers{
+			"on-call": {
+				IDs:      []string{"U123"},
+				Bindings: config.DirectMessageBindings{Executors: []string{"kubectl-read-only"}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name                  string
+		Config                config.DirectMessages
+		UserID                string
+		ExpectedExecutors     []string
+		ExpectedAuthenticated bool
+	}{
+		{
+			Name:                  "Allowed user",
+			Config:                dmCfg,
+			UserID:                "U123",
+			ExpectedExecutors:     []string{"kubectl-read-only"},
+			ExpectedAuthenticated: true,
+		},
+		{
+			Name:                  "Not allowed user",
+			Config:                dmCfg,
+			UserID:                "U456",
+			ExpectedAuthenticated: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var postedChannels []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, r.ParseForm())
+				switch r.URL.Path {
+				case "/conversations.info":
+					_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"D123","is_im":true}}`))
+				case "/chat.postMessage":
+					postedChannels = append(postedChannels, r.Form.Get("channel"))
+					_, _ = w.Write([]byte(`{"ok":true,"channel":"D123","ts":"1"}`))
+				default:
+					t.Errorf("unexpected request to %q", r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			botMentionRegex, err := slackBotMentionRegex("UBOT")
+			require.NoError(t, err)
+			factory := &fakeExecutorFactory{}
+			b := &SocketSlack{
+				log:             logrus.New(),
+				executorFactory: factory,
+				botID:           "UBOT",
+				client:          slack.New("token", slack.OptionAPIURL(srv.URL+"/")),
+				channels:        map[string]channelConfigByName{},
+				botMentionRegex: botMentionRegex,
+				renderer:        NewSlackRenderer(config.Notification{}),
+				mdFormatter:     interactive.NewMDFormatter(interactive.NewlineFormatter, mdHeaderFormatter),
+				msgTracker:      newEventMessageTracker(),
+				directMessages:  newDirectMessageAuthorizer(tc.Config),
+			}
+
+			// when
+			err = b.handleMessage(context.Background(), socketSlackMessage{
+				Text:            "kubectl get pods",
+				Channel:         "D123",
+				User:            tc.UserID,
+				CommandOrigin:   command.TypedOrigin,
+				IsDirectMessage: true,
+			})
+
+			// then
+			require.NoError(t, err)
+			require.Len(t, factory.inputs, 1)
+			in := factory.inputs[0]
+			assert.Equal(t, " kubectl get pods", in.Message)
+			assert.Equal(t, "D123", in.Conversation.ID)
+			assert.True(t, in.Conversation.IsDirectMessage)
+			assert.Equal(t, tc.ExpectedAuthenticated, in.Conversation.IsAuthenticated)
+			assert.Equal(t, tc.ExpectedExecutors, in.Conversation.ExecutorBindings)
+			assert.Empty(t, in.Conversation.Alias)
+			assert.Equal(t, []string{"D123"}, postedChannels)
+		})
+	}
+}
@@ -301,6 +301,7 @@ func (b *Teams) processMessage(ctx context.Context, activity schema.Activity) (i
 			CommandOrigin:    command.TypedOrigin,
 		},
 		Message: trimmedMsg,
+		UserID:  activity.From.AadObjectID,
 	})
 	return b.convertInteractiveMessage(e.Execute(ctx), false)
 }
@@ -0,0 +1,537 @@
+package bot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Bot = &Telegram{}
+
+const (
+	// telegramMaxMessageSize max size before a message should be uploaded as a file.
+	telegramMaxMessageSize = 4096
+
+	// telegramMaxCallbackDataSize is the maximum size of the inline keyboard button callback data.
+	telegramMaxCallbackDataSize = 64
+
+	// telegramMaxStoredCallbacks is the maximum number of long button commands kept in memory.
+	telegramMaxStoredCallbacks = 1000
+
+	telegramCallbackIDPrefix = "cb:"
+	telegramPollTimeout      = 30 * time.Second
+	telegramRetryInterval    = 5 * time.Second
+
+	// telegramMaxConcurrentUpdates is the maximum number of updates handled at the same time.
+	telegramMaxConcurrentUpdates = 10
+)
+
+// Telegram listens for user's message, execute commands and sends back the response.
+type Telegram struct {
+	log             logrus.FieldLogger
+	executorFactory ExecutorFactory
+	reporter        AnalyticsReporter
+	api             *telegramAPIClient
+	notification    config.Notification
+	botName         string
+	channelsMutex   sync.RWMutex
+	channels        map[string]channelConfigByID
+	notifyMutex     sync.Mutex
+	commGroupName   string
+	mdFormatter     interactive.MDFormatter
+	callbacksMutex  sync.Mutex
+	callbacks       map[string]string
+}
+
+// telegramIncomingMessage contains message details to execute command and send back the result.
+type telegramIncomingMessage struct {
+	ChatID        string
+	Text          string
+	User          telegramUser
+	CommandOrigin command.Origin
+}
+
+// NewTelegram creates a new Telegram instance.
+func NewTelegram(log logrus.FieldLogger, commGroupName string, cfg config.Telegram, executorFactory ExecutorFactory, reporter AnalyticsReporter) (*Telegram, error) {
+	return newTelegram(log, commGroupName, cfg, executorFactory, reporter, newTelegramAPIClient(telegramAPIURL, cfg.Token))
+}
+
+func newTelegram(log logrus.FieldLogger, commGroupName string, cfg config.Telegram, executorFactory ExecutorFactory, reporter AnalyticsReporter, apiCli *telegramAPIClient) (*Telegram, error) {
+	me, err := apiCli.GetMe(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("while getting Telegram bot details: %w", err)
+	}
+
+	return &Telegram{
+		log:             log,
+		executorFactory: executorFactory,
+		reporter:        reporter,
+		api:             apiCli,
+		notification:    cfg.Notification,
+		botName:         me.Username,
+		commGroupName:   commGroupName,
+		channels:        telegramChannelsConfigFrom(cfg.Channels),
+		mdFormatter:     interactive.NewMDFormatter(interactive.NewlineFormatter, interactive.NoFormatting),
+		callbacks:       map[string]string{},
+	}, nil
+}
+
+// Start starts polling Telegram for updates.
+func (b *Telegram) Start(ctx context.Context) error {
+	b.log.Info("Starting bot")
+
+	err := b.reporter.ReportBotEnabled(b.IntegrationName())
+	if err != nil {
+		return fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	b.log.Info("Botkube connected to Telegram!")
+
+	// updates are handled by workers, so a long-running command doesn't block other chats
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	workers := make(chan struct{}, telegramMaxConcurrentUpdates)
+
+	var offset int64
+	for {
+		select {
+		case <-ctx.Done():
+			b.log.Info("Shutdown requested. Finishing...")
+			return nil
+		default:
+		}
+
+		updates, err := b.api.GetUpdates(ctx, offset, telegramPollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			b.log.Errorf("while getting updates: %s", err.Error())
+			select {
+			case <-ctx.Done():
+			case <-time.After(telegramRetryInterval):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+
+			msg, ok := b.incomingMessageFromUpdate(ctx, update)
+			if !ok {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				b.log.Info("Shutdown requested. Finishing...")
+				return nil
+			case workers <- struct{}{}:
+			}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+
+				if err := b.handleMessage(ctx, msg); err != nil {
+					b.log.Errorf("Message handling error: %s", err.Error())
+				}
+			}()
+		}
+	}
+}
+
+// SendEvent sends event notification to Telegram chats.
+func (b *Telegram) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	b.log.Debugf("Sending to Telegram: %+v", event)
+
+	msg := b.formatMessage(event)
+
+	errs := multierror.New()
+	for _, chatID := range b.getChannelsToNotifyForEvent(event, eventSources) {
+		if err := b.api.SendMessage(ctx, chatID, msg, nil); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Telegram message to chat %q: %w", chatID, err))
+			continue
+		}
+
+		b.log.Debugf("Event successfully sent to chat %q", chatID)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessage sends interactive message to selected Telegram chats.
+func (b *Telegram) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+	errs := multierror.New()
+	for _, chatID := range b.getChannelsToNotify(sourceBindings) {
+		err := b.send(ctx, chatID, msg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Telegram message to chat %q: %w", chatID, err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendMessageToAll sends interactive message to all Telegram chats.
+func (b *Telegram) SendMessageToAll(ctx context.Context, msg interactive.CoreMessage) error {
+	errs := multierror.New()
+	for _, channel := range b.getChannels() {
+		chatID := channel.ID
+
+		err := b.send(ctx, chatID, msg)
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while sending Telegram message to chat %q: %w", chatID, err))
+			continue
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// IntegrationName describes the integration name.
+func (b *Telegram) IntegrationName() config.CommPlatformIntegration {
+	return config.TelegramCommPlatformIntegration
+}
+
+// Type describes the integration type.
+func (b *Telegram) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// NotificationsEnabled returns current notification status for a given chat ID.
+func (b *Telegram) NotificationsEnabled(chatID string) bool {
+	channel, exists := b.getChannels()[chatID]
+	if !exists {
+		return false
+	}
+
+	return channel.notify
+}
+
+// SetNotificationsEnabled sets a new notification status for a given chat ID.
+func (b *Telegram) SetNotificationsEnabled(chatID string, enabled bool) error {
+	// avoid race conditions with using the setter concurrently, as we set whole map
+	b.notifyMutex.Lock()
+	defer b.notifyMutex.Unlock()
+
+	channels := b.getChannels()
+	channel, exists := channels[chatID]
+	if !exists {
+		return execute.ErrNotificationsNotConfigured
+	}
+
+	channel.notify = enabled
+	channels[chatID] = channel
+	b.setChannels(channels)
+
+	return nil
+}
+
+// BotName returns the Bot name.
+func (b *Telegram) BotName() string {
+	return fmt.Sprintf("@%s", b.botName)
+}
+
+func (b *Telegram) incomingMessageFromUpdate(ctx context.Context, update telegramUpdate) (telegramIncomingMessage, bool) {
+	switch {
+	case update.Message != nil:
+		msg := update.Message
+		if msg.From == nil || msg.From.IsBot || msg.Text == "" {
+			return telegramIncomingMessage{}, false
+		}
+		return telegramIncomingMessage{
+			ChatID:        strconv.FormatInt(msg.Chat.ID, 10),
+			Text:          msg.Text,
+			User:          *msg.From,
+			CommandOrigin: command.TypedOrigin,
+		}, true
+	case update.CallbackQuery != nil:
+		query := update.CallbackQuery
+		if err := b.api.AnswerCallbackQuery(ctx, query.ID); err != nil {
+			b.log.Errorf("while answering callback query: %s", err.Error())
+		}
+		if query.Message == nil {
+			return telegramIncomingMessage{}, false
+		}
+
+		cmd, found := b.resolveCallbackData(query.Data)
+		if !found {
+			b.log.Debugf("Ignoring callback query as the command for %q is no longer available", query.Data)
+			return telegramIncomingMessage{}, false
+		}
+
+		return telegramIncomingMessage{
+			ChatID:        strconv.FormatInt(query.Message.Chat.ID, 10),
+			Text:          cmd,
+			User:          query.From,
+			CommandOrigin: command.ButtonClickOrigin,
+		}, true
+	}
+
+	return telegramIncomingMessage{}, false
+}
+
+func (b *Telegram) handleMessage(ctx context.Context, tm telegramIncomingMessage) error {
+	// Handle message only if starts with mention or a bot command
+	req, found := b.findAndTrimBotMention(tm.Text)
+	if !found {
+		b.log.Debugf("Ignoring message as it doesn't contain %q mention", b.BotName())
+		return nil
+	}
+
+	b.log.Debugf("Telegram incoming Request: %s", req)
+
+	channel, isAuthChannel := b.getChannels()[tm.ChatID]
+
+	e := b.executorFactory.NewDefault(execute.NewDefaultInput{
+		CommGroupName:   b.commGroupName,
+		Platform:        b.IntegrationName(),
+		NotifierHandler: b,
+		Conversation: execute.Conversation{
+			Alias:            channel.alias,
+			ID:               channel.Identifier(),
+			ExecutorBindings: channel.Bindings.Executors,
+			SourceBindings:   channel.Bindings.Sources,
+			IsAuthenticated:  isAuthChannel,
+			CommandOrigin:    tm.CommandOrigin,
+		},
+		Message: req,
+		User:    telegramUserMention(tm.User),
+		UserID:  strconv.FormatInt(tm.User.ID, 10),
+	})
+
+	response := e.Execute(ctx)
+	err := b.send(ctx, tm.ChatID, response)
+	if err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	return nil
+}
+
+func (b *Telegram) send(ctx context.Context, chatID string, resp interactive.CoreMessage) error {
+	b.log.Debugf("Sending message to chat %q: %+v", chatID, resp)
+
+	resp.ReplaceBotNamePlaceholder(b.BotName())
+	keyboard := b.renderInlineKeyboard(resp)
+	text := interactive.RenderMessage(b.mdFormatter, withoutButtons(resp))
+
+	if len(text) == 0 {
+		return errors.New("while reading Telegram response: empty response")
+	}
+
+	// Upload message as a file if too long
+	if len(text) >= telegramMaxMessageSize {
+		content := interactive.MessageToPlaintext(resp, interactive.NewlineFormatter)
+		if err := b.api.SendDocument(ctx, chatID, responseFileName, resp.Description, []byte(content)); err != nil {
+			return fmt.Errorf("while uploading file: %w", err)
+		}
+		return nil
+	}
+
+	if err := b.api.SendMessage(ctx, chatID, text, keyboard); err != nil {
+		return fmt.Errorf("while sending message: %w", err)
+	}
+
+	b.log.Debugf("Message successfully sent to chat %q", chatID)
+	return nil
+}
+
+// renderInlineKeyboard returns Telegram inline keyboard with one row per message section buttons.
+func (b *Telegram) renderInlineKeyboard(msg interactive.CoreMessage) *telegramInlineKeyboardMarkup {
+	var rows [][]telegramInlineKeyboardButton
+	for _, section := range msg.Sections {
+		var row []telegramInlineKeyboardButton
+		for _, btn := range section.Buttons {
+			item, ok := b.renderInlineKeyboardButton(btn)
+			if !ok {
+				continue
+			}
+			row = append(row, item)
+		}
+
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return &telegramInlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func (b *Telegram) renderInlineKeyboardButton(btn api.Button) (telegramInlineKeyboardButton, bool) {
+	switch {
+	case btn.URL != "":
+		return telegramInlineKeyboardButton{Text: btn.Name, URL: btn.URL}, true
+	case btn.Command != "":
+		return telegramInlineKeyboardButton{Text: btn.Name, CallbackData: b.storeCallbackData(btn.Command)}, true
+	}
+
+	return telegramInlineKeyboardButton{}, false
+}
+
+// storeCallbackData returns callback data for a given command.
+// Telegram limits the callback data size, so longer commands are stored in memory and referenced by their hash.
+func (b *Telegram) storeCallbackData(cmd string) string {
+	if len(cmd) <= telegramMaxCallbackDataSize && !strings.HasPrefix(cmd, telegramCallbackIDPrefix) {
+		return cmd
+	}
+
+	sum := sha256.Sum256([]byte(cmd))
+	id := telegramCallbackIDPrefix + hex.EncodeToString(sum[:16])
+
+	b.callbacksMutex.Lock()
+	defer b.callbacksMutex.Unlock()
+
+	if len(b.callbacks) >= telegramMaxStoredCallbacks {
+		b.callbacks = map[string]string{}
+	}
+	b.callbacks[id] = cmd
+
+	return id
+}
+
+func (b *Telegram) resolveCallbackData(data string) (string, bool) {
+	if !strings.HasPrefix(data, telegramCallbackIDPrefix) {
+		return data, true
+	}
+
+	b.callbacksMutex.Lock()
+	defer b.callbacksMutex.Unlock()
+
+	cmd, found := b.callbacks[data]
+	return cmd, found
+}
+
+// TODO: Support custom routing via annotations for Telegram as well
+func (b *Telegram) getChannelsToNotifyForEvent(event event.Event, sourceBindings []string) []string {
+	// support custom event routing
+	if event.Channel != "" {
+		return []string{event.Channel}
+	}
+
+	return b.getChannelsToNotify(sourceBindings)
+}
+
+func (b *Telegram) getChannelsToNotify(sourceBindings []string) []string {
+	var out []string
+	for _, cfg := range b.getChannels() {
+		switch {
+		case !cfg.notify:
+			b.log.Infof("Skipping notification for chat %q as notifications are disabled.", cfg.Identifier())
+		default:
+			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+				out = append(out, cfg.Identifier())
+			}
+		}
+	}
+	return out
+}
+
+func (b *Telegram) getChannels() map[string]channelConfigByID {
+	b.channelsMutex.RLock()
+	defer b.channelsMutex.RUnlock()
+	return b.channels
+}
+
+func (b *Telegram) setChannels(channels map[string]channelConfigByID) {
+	b.channelsMutex.Lock()
+	defer b.channelsMutex.Unlock()
+	b.channels = channels
+}
+
+// ReloadChannels replaces the channel configuration with the one from a given communication group.
+// Notifications toggled at runtime are reset to the new configuration.
+func (b *Telegram) ReloadChannels(cfg config.Communications) error {
+	b.setChannels(telegramChannelsConfigFrom(cfg.Telegram.Channels))
+	return nil
+}
+
+// findAndTrimBotMention supports the following message formats:
+//   - @botkube_bot kubectl get pods
+//   - /kubectl@botkube_bot get pods
+//   - /kubectl get pods
+func (b *Telegram) findAndTrimBotMention(msg string) (string, bool) {
+	msg = strings.TrimSpace(msg)
+	mention := strings.ToLower(b.BotName())
+
+	if strings.HasPrefix(strings.ToLower(msg), mention) {
+		rest := msg[len(mention):]
+		if rest == "" || rest[0] == ' ' || rest[0] == '\n' {
+			return rest, true
+		}
+		return "", false
+	}
+
+	if !strings.HasPrefix(msg, "/") {
+		return "", false
+	}
+
+	cmd, args, _ := strings.Cut(strings.TrimPrefix(msg, "/"), " ")
+	if name, target, found := strings.Cut(cmd, "@"); found {
+		if !strings.EqualFold(target, b.botName) {
+			// command addressed to a different bot
+			return "", false
+		}
+		cmd = name
+	}
+
+	if args == "" {
+		return " " + cmd, true
+	}
+	return fmt.Sprintf(" %s %s", cmd, args), true
+}
+
+func withoutButtons(msg interactive.CoreMessage) interactive.CoreMessage {
+	sections := make([]api.Section, 0, len(msg.Sections))
+	for _, section := range msg.Sections {
+		section.Buttons = nil
+		sections = append(sections, section)
+	}
+	msg.Sections = sections
+	return msg
+}
+
+func telegramUserMention(user telegramUser) string {
+	if user.Username != "" {
+		return fmt.Sprintf("@%s", user.Username)
+	}
+	return strconv.FormatInt(user.ID, 10)
+}
+
+func telegramChannelsConfigFrom(channelsCfg config.IdentifiableMap[config.ChannelBindingsByID]) map[string]channelConfigByID {
+	res := make(map[string]channelConfigByID)
+	for channAlias, channCfg := range channelsCfg {
+		res[channCfg.Identifier()] = channelConfigByID{
+			ChannelBindingsByID: channCfg,
+			alias:               channAlias,
+			notify:              !channCfg.Notification.Disabled,
+		}
+	}
+
+	return res
+}
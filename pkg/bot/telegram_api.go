@@ -0,0 +1,217 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	telegramAPIURL = "https://api.telegram.org"
+
+	// telegramHTTPCliTimeout needs to be longer than the long polling timeout.
+	telegramHTTPCliTimeout = telegramPollTimeout + 30*time.Second
+)
+
+// telegramAPIClient is a minimal client for the Telegram Bot API.
+// See: https://core.telegram.org/bots/api
+type telegramAPIClient struct {
+	httpCli *http.Client
+	baseURL string
+}
+
+type telegramResponse struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+}
+
+type telegramUpdate struct {
+	UpdateID      int64                  `json:"update_id"`
+	Message       *telegramMessage       `json:"message,omitempty"`
+	CallbackQuery *telegramCallbackQuery `json:"callback_query,omitempty"`
+}
+
+type telegramMessage struct {
+	MessageID int64         `json:"message_id"`
+	From      *telegramUser `json:"from,omitempty"`
+	Chat      telegramChat  `json:"chat"`
+	Text      string        `json:"text,omitempty"`
+}
+
+type telegramUser struct {
+	ID       int64  `json:"id"`
+	IsBot    bool   `json:"is_bot"`
+	Username string `json:"username,omitempty"`
+}
+
+type telegramChat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type telegramCallbackQuery struct {
+	ID      string           `json:"id"`
+	From    telegramUser     `json:"from"`
+	Message *telegramMessage `json:"message,omitempty"`
+	Data    string           `json:"data,omitempty"`
+}
+
+type telegramInlineKeyboardMarkup struct {
+	InlineKeyboard [][]telegramInlineKeyboardButton `json:"inline_keyboard"`
+}
+
+type telegramInlineKeyboardButton struct {
+	Text         string `json:"text"`
+	URL          string `json:"url,omitempty"`
+	CallbackData string `json:"callback_data,omitempty"`
+}
+
+func newTelegramAPIClient(apiURL, token string) *telegramAPIClient {
+	return &telegramAPIClient{
+		httpCli: &http.Client{Timeout: telegramHTTPCliTimeout},
+		baseURL: fmt.Sprintf("%s/bot%s", strings.TrimSuffix(apiURL, "/"), token),
+	}
+}
+
+// GetMe returns basic information about the bot.
+func (c *telegramAPIClient) GetMe(ctx context.Context) (telegramUser, error) {
+	var out telegramUser
+	err := c.call(ctx, "getMe", nil, &out)
+	return out, err
+}
+
+// GetUpdates receives incoming updates using long polling.
+func (c *telegramAPIClient) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]telegramUpdate, error) {
+	body := map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}
+
+	var out []telegramUpdate
+	err := c.call(ctx, "getUpdates", body, &out)
+	return out, err
+}
+
+// SendMessage sends a text message to a given chat.
+func (c *telegramAPIClient) SendMessage(ctx context.Context, chatID, text string, keyboard *telegramInlineKeyboardMarkup) error {
+	body := map[string]interface{}{
+		"chat_id":                  chatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	}
+	if keyboard != nil {
+		body["reply_markup"] = keyboard
+	}
+
+	return c.call(ctx, "sendMessage", body, nil)
+}
+
+// AnswerCallbackQuery acknowledges the callback query sent from the inline keyboard.
+func (c *telegramAPIClient) AnswerCallbackQuery(ctx context.Context, queryID string) error {
+	return c.call(ctx, "answerCallbackQuery", map[string]interface{}{
+		"callback_query_id": queryID,
+	}, nil)
+}
+
+// SendDocument uploads a given content as a file to a given chat.
+func (c *telegramAPIClient) SendDocument(ctx context.Context, chatID, fileName, caption string, content []byte) (err error) {
+	var buff bytes.Buffer
+	w := multipart.NewWriter(&buff)
+
+	if err := w.WriteField("chat_id", chatID); err != nil {
+		return err
+	}
+	if caption != "" {
+		if err := w.WriteField("caption", caption); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("document", fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.methodURL("sendDocument"), &buff)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return c.do(req, nil)
+}
+
+func (c *telegramAPIClient) call(ctx context.Context, method string, body interface{}, out interface{}) error {
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("while marshaling request body: %w", err)
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.methodURL(method), reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, out)
+}
+
+func (c *telegramAPIClient) do(req *http.Request, out interface{}) (err error) {
+	resp, err := c.httpCli.Do(req)
+	if err != nil {
+		// do not leak the bot token which is a part of the URL
+		if urlErr, ok := err.(*url.Error); ok {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	var apiResp telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return fmt.Errorf("while decoding response with status code %d: %w", resp.StatusCode, err)
+	}
+
+	if !apiResp.OK {
+		return fmt.Errorf("telegram API error (status code %s): %s", strconv.Itoa(resp.StatusCode), apiResp.Description)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(apiResp.Result, out); err != nil {
+		return fmt.Errorf("while decoding response result: %w", err)
+	}
+	return nil
+}
+
+func (c *telegramAPIClient) methodURL(method string) string {
+	return fmt.Sprintf("%s/%s", c.baseURL, method)
+}
@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	formatx "github.com/kubeshop/botkube/pkg/format"
+)
+
+var unicodeEmojiForLevel = map[config.Level]string{
+	config.Info:     "🟢",
+	config.Warn:     "⚠️",
+	config.Debug:    "ℹ️",
+	config.Error:    "❌",
+	config.Critical: "❌",
+}
+
+// formatMessage returns the plaintext event message. Telegram Markdown requires escaping of many characters
+// that are common in Kubernetes object names and messages, so the notifications are sent without formatting.
+func (b *Telegram) formatMessage(event event.Event) string {
+	var out strings.Builder
+
+	out.WriteString(fmt.Sprintf("%s %s\n", unicodeEmojiForLevel[event.Level], event.Title))
+
+	switch b.notification.Type {
+	case config.LongNotification:
+		b.longNotification(&out, event)
+	case config.ShortNotification:
+		fallthrough
+	default:
+		b.shortNotification(&out, event)
+	}
+
+	if !event.TimeStamp.IsZero() {
+		out.WriteString(fmt.Sprintf("\n%s", event.TimeStamp.UTC().Format(time.RFC1123)))
+	}
+
+	return out.String()
+}
+
+func (b *Telegram) longNotification(out *strings.Builder, event event.Event) {
+	b.writeFieldIfNotEmpty(out, "Kind", event.Kind)
+	b.writeFieldIfNotEmpty(out, "Name", event.Name)
+	b.writeFieldIfNotEmpty(out, "Namespace", event.Namespace)
+	b.writeFieldIfNotEmpty(out, "Reason", event.Reason)
+	b.writeFieldIfNotEmpty(out, "Action", event.Action)
+	b.writeFieldIfNotEmpty(out, "Cluster", event.Cluster)
+	b.writeListIfNotEmpty(out, "Messages", event.Messages)
+	b.writeListIfNotEmpty(out, "Recommendations", event.Recommendations)
+	b.writeListIfNotEmpty(out, "Warnings", event.Warnings)
+}
+
+func (b *Telegram) shortNotification(out *strings.Builder, event event.Event) {
+	header := strings.ReplaceAll(formatx.ShortNotificationHeader(event), "*", "")
+	out.WriteString(fmt.Sprintf("%s\n", header))
+	b.writeListIfNotEmpty(out, "Messages", event.Messages)
+	b.writeListIfNotEmpty(out, "Recommendations", event.Recommendations)
+	b.writeListIfNotEmpty(out, "Warnings", event.Warnings)
+}
+
+func (b *Telegram) writeFieldIfNotEmpty(out *strings.Builder, title, in string) {
+	if in == "" {
+		return
+	}
+	out.WriteString(fmt.Sprintf("%s: %s\n", title, in))
+}
+
+func (b *Telegram) writeListIfNotEmpty(out *strings.Builder, title string, in []string) {
+	if len(in) == 0 {
+		return
+	}
+	out.WriteString(fmt.Sprintf("%s:\n%s", title, formatx.BulletPointListFromMessages(in)))
+}
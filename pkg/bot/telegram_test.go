@@ -0,0 +1,318 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+)
+
+func TestTelegram_FindAndTrimBotMention(t *testing.T) {
+	/// given
+	testCases := []struct {
+		Name               string
+		Input              string
+		ExpectedTrimmedMsg string
+		ExpectedFound      bool
+	}{
+		{
+			Name:               "Mention",
+			Input:              "@botkube_bot get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " get pods",
+		},
+		{
+			Name:               "Different casing",
+			Input:              "@BotKube_Bot get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " get pods",
+		},
+		{
+			Name:               "Command",
+			Input:              "/kubectl get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " kubectl get pods",
+		},
+		{
+			Name:               "Command without arguments",
+			Input:              "/ping",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " ping",
+		},
+		{
+			Name:               "Command addressed to bot",
+			Input:              "/kubectl@botkube_bot get pods",
+			ExpectedFound:      true,
+			ExpectedTrimmedMsg: " kubectl get pods",
+		},
+		{
+			Name:          "Command addressed to different bot",
+			Input:         "/kubectl@other_bot get pods",
+			ExpectedFound: false,
+		},
+		{
+			Name:          "Mention with suffix",
+			Input:         "@botkube_bot2 get pods",
+			ExpectedFound: false,
+		},
+		{
+			Name:          "Not at the beginning",
+			Input:         "Not at the beginning @botkube_bot get pods",
+			ExpectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			b := &Telegram{botName: "botkube_bot"}
+
+			// when
+			actualTrimmedMsg, actualFound := b.findAndTrimBotMention(tc.Input)
+
+			// then
+			assert.Equal(t, tc.ExpectedFound, actualFound)
+			assert.Equal(t, tc.ExpectedTrimmedMsg, actualTrimmedMsg)
+		})
+	}
+}
+
+func TestTelegram_RenderInlineKeyboard(t *testing.T) {
+	// given
+	b := &Telegram{botName: "botkube_bot", callbacks: map[string]string{}}
+	longCmd := "@botkube_bot kubectl get pods -n default --selector app.kubernetes.io/name=very-long-application-name"
+
+	msg := interactive.CoreMessage{
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Buttons: api.Buttons{
+						{Name: "Get pods", Command: "@botkube_bot kubectl get pods"},
+						{Name: "Long", Command: longCmd},
+					},
+				},
+				{
+					Buttons: api.Buttons{
+						{Name: "Docs", URL: "https://docs.botkube.io"},
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	keyboard := b.renderInlineKeyboard(msg)
+
+	// then
+	require.NotNil(t, keyboard)
+	require.Len(t, keyboard.InlineKeyboard, 2)
+	require.Len(t, keyboard.InlineKeyboard[0], 2)
+
+	assert.Equal(t, "@botkube_bot kubectl get pods", keyboard.InlineKeyboard[0][0].CallbackData)
+	assert.Equal(t, telegramInlineKeyboardButton{Text: "Docs", URL: "https://docs.botkube.io"}, keyboard.InlineKeyboard[1][0])
+
+	longData := keyboard.InlineKeyboard[0][1].CallbackData
+	assert.LessOrEqual(t, len(longData), telegramMaxCallbackDataSize)
+
+	resolved, found := b.resolveCallbackData(longData)
+	require.True(t, found)
+	assert.Equal(t, longCmd, resolved)
+}
+
+func TestTelegram_HandleMessage(t *testing.T) {
+	// given
+	var sent []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"username":"botkube_bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			sent = append(sent, body)
+			_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Not Found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := config.Telegram{
+		Channels: config.IdentifiableMap[config.ChannelBindingsByID]{
+			"ops": {
+				ID: "-1001",
+				Bindings: config.BotBindings{
+					Executors: []string{"kubectl-read-only"},
+				},
+			},
+		},
+	}
+	factory := &fakeExecutorFactory{}
+
+	b, err := newTelegram(logrus.New(), "default", cfg, factory, nil, newTelegramAPIClient(srv.URL, "token"))
+	require.NoError(t, err)
+
+	// when
+	err = b.handleMessage(context.Background(), telegramIncomingMessage{
+		ChatID: "-1001",
+		Text:   "/kubectl get pods",
+		User:   telegramUser{ID: 42, Username: "john"},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, factory.inputs, 1)
+	in := factory.inputs[0]
+	assert.Equal(t, " kubectl get pods", in.Message)
+	assert.Equal(t, "@john", in.User)
+	assert.True(t, in.Conversation.IsAuthenticated)
+	assert.Equal(t, "ops", in.Conversation.Alias)
+	assert.Equal(t, []string{"kubectl-read-only"}, in.Conversation.ExecutorBindings)
+
+	require.Len(t, sent, 1)
+	assert.Equal(t, "-1001", sent[0]["chat_id"])
+	assert.Contains(t, sent[0]["text"], "pong")
+}
+
+func TestTelegram_StartHandlesUpdatesConcurrently(t *testing.T) {
+	// given
+	var (
+		mu         sync.Mutex
+		polls      int
+		lastOffset float64
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/getMe"):
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1,"is_bot":true,"username":"botkube_bot"}}`))
+		case strings.HasSuffix(r.URL.Path, "/getUpdates"):
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			polls++
+			first := polls == 1
+			lastOffset, _ = body["offset"].(float64)
+			mu.Unlock()
+
+			if !first {
+				_, _ = w.Write([]byte(`{"ok":true,"result":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"result":[
+				{"update_id":1,"message":{"message_id":1,"from":{"id":42,"username":"john"},"chat":{"id":-1001},"text":"/kubectl slow"}},
+				{"update_id":2,"message":{"message_id":2,"from":{"id":42,"username":"john"},"chat":{"id":-1001},"text":"/kubectl fast"}}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+		}
+	}))
+	defer srv.Close()
+
+	release := make(chan struct{})
+	fastDone := make(chan struct{})
+	factory := &blockingExecutorFactory{release: release, fastDone: fastDone}
+	cfg := config.Telegram{
+		Channels: config.IdentifiableMap[config.ChannelBindingsByID]{
+			"ops": {ID: "-1001"},
+		},
+	}
+	b, err := newTelegram(logrus.New(), "default", cfg, factory, &fakeBotReporter{}, newTelegramAPIClient(srv.URL, "token"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- b.Start(ctx)
+	}()
+
+	// when
+	select {
+	case <-fastDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the fast command was blocked by the slow one")
+	}
+
+	// then
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return lastOffset == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(release)
+	cancel()
+	select {
+	case err := <-startErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the bot shutdown")
+	}
+}
+
+type blockingExecutorFactory struct {
+	release  chan struct{}
+	fastDone chan struct{}
+}
+
+func (f *blockingExecutorFactory) NewDefault(in execute.NewDefaultInput) execute.Executor {
+	if strings.Contains(in.Message, "slow") {
+		return &blockingExecutor{wait: f.release}
+	}
+	return &blockingExecutor{done: f.fastDone}
+}
+
+type blockingExecutor struct {
+	wait chan struct{}
+	done chan struct{}
+}
+
+func (e *blockingExecutor) Execute(context.Context) interactive.CoreMessage {
+	if e.wait != nil {
+		<-e.wait
+	}
+	if e.done != nil {
+		close(e.done)
+	}
+	return interactive.CoreMessage{}
+}
+
+type fakeBotReporter struct{}
+
+func (*fakeBotReporter) ReportBotEnabled(config.CommPlatformIntegration) error {
+	return nil
+}
+
+type fakeExecutorFactory struct {
+	inputs []execute.NewDefaultInput
+}
+
+func (f *fakeExecutorFactory) NewDefault(in execute.NewDefaultInput) execute.Executor {
+	f.inputs = append(f.inputs, in)
+	return &fakeExecutor{}
+}
+
+type fakeExecutor struct{}
+
+func (*fakeExecutor) Execute(context.Context) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: "pong",
+			},
+		},
+	}
+}
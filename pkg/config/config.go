@@ -99,11 +99,38 @@ const (
 	// DiscordCommPlatformIntegration defines Discord integration.
 	DiscordCommPlatformIntegration CommPlatformIntegration = "discord"
 
+	// TelegramCommPlatformIntegration defines Telegram integration.
+	TelegramCommPlatformIntegration CommPlatformIntegration = "telegram"
+
+	// GoogleChatCommPlatformIntegration defines Google Chat integration.
+	GoogleChatCommPlatformIntegration CommPlatformIntegration = "googleChat"
+
+	// RocketChatCommPlatformIntegration defines Rocket.Chat integration.
+	RocketChatCommPlatformIntegration CommPlatformIntegration = "rocketChat"
+
 	//ElasticsearchCommPlatformIntegration defines Elasticsearch integration.
 	ElasticsearchCommPlatformIntegration CommPlatformIntegration = "elasticsearch"
 
 	// WebhookCommPlatformIntegration defines an outgoing webhook integration.
 	WebhookCommPlatformIntegration CommPlatformIntegration = "webhook"
+
+	// KafkaCommPlatformIntegration defines Kafka integration.
+	KafkaCommPlatformIntegration CommPlatformIntegration = "kafka"
+
+	// NATSCommPlatformIntegration defines NATS integration.
+	NATSCommPlatformIntegration CommPlatformIntegration = "nats"
+
+	// LokiCommPlatformIntegration defines Grafana Loki integration.
+	LokiCommPlatformIntegration CommPlatformIntegration = "loki"
+
+	// OTLPLogsCommPlatformIntegration defines OpenTelemetry logs integration.
+	OTLPLogsCommPlatformIntegration CommPlatformIntegration = "otlpLogs"
+
+	// AlertmanagerCommPlatformIntegration defines Prometheus Alertmanager integration.
+	AlertmanagerCommPlatformIntegration CommPlatformIntegration = "alertmanager"
+
+	// S3CommPlatformIntegration defines S3-compatible object storage integration.
+	S3CommPlatformIntegration CommPlatformIntegration = "s3"
 )
 
 func (c CommPlatformIntegration) IsInteractive() bool {
@@ -174,6 +201,27 @@ func (c ChannelBindingsByName) Identifier() string {
 	return c.Name
 }
 
+// DirectMessages contains configuration for commands sent to the bot in direct messages.
+type DirectMessages struct {
+	Enabled bool `yaml:"enabled"`
+	// Users holds users allowed to run commands in direct messages. The property name is an alias for a given configuration.
+	Users map[string]DirectMessageUsers `yaml:"users,omitempty"`
+}
+
+// DirectMessageUsers contains executor bindings for a given list of users.
+type DirectMessageUsers struct {
+	// IDs holds platform-specific user IDs.
+	IDs []string `yaml:"ids,omitempty"`
+	// Emails holds user emails.
+	Emails   []string              `yaml:"emails,omitempty"`
+	Bindings DirectMessageBindings `yaml:"bindings"`
+}
+
+// DirectMessageBindings contains executor bindings for direct messages.
+type DirectMessageBindings struct {
+	Executors []string `yaml:"executors"`
+}
+
 // ChannelBindingsByID contains configuration bindings per channel.
 type ChannelBindingsByID struct {
 	ID           string              `yaml:"id"`
@@ -195,6 +243,8 @@ type BotBindings struct {
 // SinkBindings contains configuration for possible Sink bindings.
 type SinkBindings struct {
 	Sources []string `yaml:"sources"`
+	// Audit enables sending the command execution audit records.
+	Audit bool `yaml:"audit,omitempty"`
 }
 
 // Actions contains configuration for Botkube app event automations.
@@ -234,6 +284,14 @@ type KubernetesSource struct {
 	Namespaces      RegexConstraints  `yaml:"namespaces"`
 	Annotations     map[string]string `yaml:"annotations"`
 	Labels          map[string]string `yaml:"labels"`
+	// TenantNamespace limits the source to events from a single namespace, regardless of other namespace constraints.
+	// It is set for sources defined by tenant teams, and cluster-scoped resources are never matched in such case.
+	TenantNamespace string `yaml:"tenantNamespace,omitempty"`
+}
+
+// IsTenantNamespaceAllowed returns false if the source is limited to a tenant namespace, and a given namespace is different.
+func (r KubernetesSource) IsTenantNamespaceAllowed(namespace string) bool {
+	return r.TenantNamespace == "" || r.TenantNamespace == namespace
 }
 
 // KubernetesEvent contains configuration for Kubernetes events.
@@ -250,7 +308,7 @@ func (e KubernetesEvent) AreConstraintsDefined() bool {
 
 // IsAllowed checks if a given resource event is allowed according to the configuration.
 func (r *KubernetesSource) IsAllowed(resourceType, namespace string, eventType EventType) bool {
-	if r == nil || len(r.Resources) == 0 {
+	if r == nil || len(r.Resources) == 0 || !r.IsTenantNamespaceAllowed(namespace) {
 		return false
 	}
 
@@ -380,7 +438,23 @@ const (
 // Executors contains executors configuration parameters.
 type Executors struct {
 	Kubectl Kubectl `yaml:"kubectl"`
-	Plugins Plugins `koanf:",remain"`
+	// Authorization restricts which users can run commands with a given executor binding.
+	Authorization ExecutorAuthorization `yaml:"authorization,omitempty"`
+	Plugins       Plugins               `koanf:",remain"`
+}
+
+// ExecutorAuthorization contains the allowlists of users and groups for a given executor binding.
+// If both lists are empty, all users are allowed.
+type ExecutorAuthorization struct {
+	// Users holds platform user IDs or emails.
+	Users []string `yaml:"users,omitempty"`
+	// Groups holds platform group names or IDs, such as Slack user groups, Mattermost groups or Discord roles.
+	Groups []string `yaml:"groups,omitempty"`
+}
+
+// IsEnabled returns true if the authorization is configured.
+func (a ExecutorAuthorization) IsEnabled() bool {
+	return len(a.Users) > 0 || len(a.Groups) > 0
 }
 
 // CollectCommandPrefixes returns list of command prefixes for all executors, even disabled ones.
@@ -436,6 +510,18 @@ func (f *KubernetesFilters) SetEnabled(name string, enabled bool) error {
 	return fmt.Errorf("Filter with name %q not found", name)
 }
 
+// IsEnabled returns status of a given filter.
+func (f *KubernetesFilters) IsEnabled(name string) (bool, error) {
+	switch name {
+	case "ObjectAnnotationChecker":
+		return f.ObjectAnnotationChecker, nil
+	case "NodeEventsChecker":
+		return f.NodeEventsChecker, nil
+	default:
+		return false, fmt.Errorf("Filter with name %q not found", name)
+	}
+}
+
 // Analytics contains configuration parameters for analytics collection.
 type Analytics struct {
 	Disable bool `yaml:"disable"`
@@ -554,6 +640,8 @@ func (r *RegexConstraints) IsAllowed(value string) (bool, error) {
 // Notification holds notification configuration.
 type Notification struct {
 	Type NotificationType
+	// UpdateExisting edits the message sent for a given Kubernetes object instead of posting a new one on each change.
+	UpdateExisting bool `yaml:"updateExisting,omitempty"`
 }
 
 // ChannelNotification contains notification configuration for a given platform.
@@ -567,9 +655,18 @@ type Communications struct {
 	SocketSlack   SocketSlack   `yaml:"socketSlack"`
 	Mattermost    Mattermost    `yaml:"mattermost"`
 	Discord       Discord       `yaml:"discord"`
+	Telegram      Telegram      `yaml:"telegram"`
+	GoogleChat    GoogleChat    `yaml:"googleChat"`
+	RocketChat    RocketChat    `yaml:"rocketChat"`
 	Teams         Teams         `yaml:"teams"`
 	Webhook       Webhook       `yaml:"webhook"`
 	Elasticsearch Elasticsearch `yaml:"elasticsearch"`
+	Kafka         Kafka         `yaml:"kafka,omitempty"`
+	NATS          NATS          `yaml:"nats,omitempty"`
+	Loki          Loki          `yaml:"loki,omitempty"`
+	OTLPLogs      OTLPLogs      `yaml:"otlpLogs,omitempty"`
+	Alertmanager  Alertmanager  `yaml:"alertmanager,omitempty"`
+	S3            S3            `yaml:"s3,omitempty"`
 }
 
 // Slack holds Slack integration config.
@@ -589,6 +686,8 @@ type SocketSlack struct {
 	Notification Notification                           `yaml:"notification,omitempty"`
 	BotToken     string                                 `yaml:"botToken,omitempty"`
 	AppToken     string                                 `yaml:"appToken,omitempty"`
+	// DirectMessages configures commands sent to the bot in direct messages.
+	DirectMessages DirectMessages `yaml:"directMessages,omitempty"`
 }
 
 // Elasticsearch config auth settings
@@ -600,6 +699,21 @@ type Elasticsearch struct {
 	SkipTLSVerify bool                `yaml:"skipTLSVerify"`
 	AWSSigning    AWSSigning          `yaml:"awsSigning"`
 	Indices       map[string]ELSIndex `yaml:"indices"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Bulk          ELSBulk             `yaml:"bulk"`
+	Outbox        SinkOutbox          `yaml:"outbox,omitempty"`
+	Transform     SinkTransform       `yaml:"transform,omitempty"`
+}
+
+// ELSBulk contains the Elasticsearch bulk indexing settings. Zero values fall back to defaults.
+type ELSBulk struct {
+	// BatchSize is the number of events sent in a single bulk request.
+	BatchSize int `yaml:"batchSize"`
+	// FlushInterval is the maximum time the events are buffered before sending them.
+	FlushInterval time.Duration `yaml:"flushInterval"`
+	// QueueSize is the number of events buffered in memory. When the queue is full, new events are dropped.
+	QueueSize int `yaml:"queueSize"`
+	// Workers is the number of concurrent bulk requests.
+	Workers int `yaml:"workers"`
 }
 
 // AWSSigning contains AWS configurations
@@ -611,14 +725,50 @@ type AWSSigning struct {
 
 // ELSIndex settings for ELS
 type ELSIndex struct {
-	Name     string `yaml:"name"`
+	Name string `yaml:"name"`
+	// Type is the mapping type of indexed documents.
+	// Deprecated: Mapping types are removed in Elasticsearch 7+ and OpenSearch. Leave it empty to use the typeless API.
 	Type     string `yaml:"type"`
 	Shards   int    `yaml:"shards"`
 	Replicas int    `yaml:"replicas"`
 
+	// DataStream, if true, sends events to a data stream named Name instead of the daily indices.
+	// The data stream is created by Elasticsearch based on a matching index template.
+	DataStream bool `yaml:"dataStream,omitempty"`
+	// Template is the index template which is installed or checked on sink startup.
+	Template ELSIndexTemplate `yaml:"template,omitempty"`
+	// ILMPolicy is the index lifecycle management policy which is installed or checked on sink startup.
+	ILMPolicy ELSILMPolicy `yaml:"ilmPolicy,omitempty"`
+
 	Bindings SinkBindings `yaml:"bindings"`
 }
 
+// AuditEnabled returns true if at least one index has the command execution audit records enabled.
+func (e Elasticsearch) AuditEnabled() bool {
+	for _, index := range e.Indices {
+		if index.Bindings.Audit {
+			return true
+		}
+	}
+	return false
+}
+
+// ELSIndexTemplate holds the Elasticsearch composable index template settings.
+type ELSIndexTemplate struct {
+	// Name of the index template. If the Body is empty, the template must already exist.
+	Name string `yaml:"name,omitempty" validate:"required_with=Body"`
+	// Body is the index template definition in JSON format. If set, the template is created or updated.
+	Body string `yaml:"body,omitempty"`
+}
+
+// ELSILMPolicy holds the Elasticsearch index lifecycle management policy settings.
+type ELSILMPolicy struct {
+	// Name of the ILM policy. If the Body is empty, the policy must already exist.
+	Name string `yaml:"name,omitempty" validate:"required_with=Body"`
+	// Body is the ILM policy definition in JSON format. If set, the policy is created or updated.
+	Body string `yaml:"body,omitempty"`
+}
+
 // Mattermost configuration to authentication and send notifications
 type Mattermost struct {
 	Enabled      bool                                   `yaml:"enabled"`
@@ -628,6 +778,8 @@ type Mattermost struct {
 	Team         string                                 `yaml:"team"`
 	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
 	Notification Notification                           `yaml:"notification,omitempty"`
+	// DirectMessages configures commands sent to the bot in direct messages.
+	DirectMessages DirectMessages `yaml:"directMessages,omitempty"`
 }
 
 // Teams creds for authentication with MS Teams
@@ -653,11 +805,257 @@ type Discord struct {
 	Notification Notification                         `yaml:"notification,omitempty"`
 }
 
+// Telegram configuration for authentication and send notifications
+type Telegram struct {
+	Enabled      bool                                 `yaml:"enabled"`
+	Token        string                               `yaml:"token"`
+	Channels     IdentifiableMap[ChannelBindingsByID] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification Notification                         `yaml:"notification,omitempty"`
+}
+
+// GoogleChat configuration for authentication and send notifications
+type GoogleChat struct {
+	Enabled     bool   `yaml:"enabled"`
+	BotName     string `yaml:"botName"`
+	Port        string `yaml:"port"`
+	MessagePath string `yaml:"messagePath,omitempty"`
+	// ProjectNumber is used to verify that the incoming requests were sent by Google Chat to a given app.
+	ProjectNumber string                                     `yaml:"projectNumber,omitempty" validate:"required_if=Enabled true"`
+	Channels      IdentifiableMap[GoogleChatChannelBindings] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification  Notification                               `yaml:"notification,omitempty"`
+}
+
+// GoogleChatChannelBindings contains configuration bindings per Google Chat space.
+type GoogleChatChannelBindings struct {
+	ChannelBindingsByID `yaml:",inline" koanf:",squash"`
+	// WebhookURL is the incoming webhook URL used to send notifications to a given space.
+	WebhookURL string `yaml:"webhookURL,omitempty"`
+}
+
+// RocketChat configuration for authentication and send notifications
+type RocketChat struct {
+	Enabled bool   `yaml:"enabled"`
+	BotName string `yaml:"botName"`
+	URL     string `yaml:"url"`
+	UserID  string `yaml:"userID"`
+	Token   string `yaml:"token,omitempty"`
+	// WebhookToken is the token of the outgoing webhook integration used to verify incoming requests.
+	WebhookToken string                                 `yaml:"webhookToken,omitempty" validate:"required_if=Enabled true"`
+	Port         string                                 `yaml:"port"`
+	MessagePath  string                                 `yaml:"messagePath,omitempty"`
+	Channels     IdentifiableMap[ChannelBindingsByName] `yaml:"channels"  validate:"required_if=Enabled true,dive,omitempty,min=1"`
+	Notification Notification                           `yaml:"notification,omitempty"`
+}
+
 // Webhook configuration to send notifications
 type Webhook struct {
-	Enabled  bool         `yaml:"enabled"`
-	URL      string       `yaml:"url"`
-	Bindings SinkBindings `yaml:"bindings" validate:"required_if=Enabled true"`
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url"`
+	// Headers are additional HTTP headers sent with each request, e.g. Authorization.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Secret is used to sign the request body with HMAC-SHA256. The signature is sent in the X-Botkube-Signature header.
+	Secret string `yaml:"secret,omitempty"`
+	// PayloadTemplate is an optional Go template used to render the request body instead of the default payload.
+	PayloadTemplate string        `yaml:"payloadTemplate,omitempty"`
+	Retry           WebhookRetry  `yaml:"retry,omitempty"`
+	Outbox          SinkOutbox    `yaml:"outbox,omitempty"`
+	Transform       SinkTransform `yaml:"transform,omitempty"`
+	Bindings        SinkBindings  `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// EventKey defines the event property used as the message key.
+type EventKey string
+
+const (
+	// ClusterEventKey uses the cluster name as the message key.
+	ClusterEventKey EventKey = "cluster"
+	// NamespaceEventKey uses the event namespace as the message key.
+	NamespaceEventKey EventKey = "namespace"
+	// KindEventKey uses the event resource kind as the message key.
+	KindEventKey EventKey = "kind"
+)
+
+// Kafka holds the Kafka sink configuration. Events are published as JSON.
+type Kafka struct {
+	Enabled bool     `yaml:"enabled"`
+	Brokers []string `yaml:"brokers" validate:"required_if=Enabled true"`
+	Topic   string   `yaml:"topic" validate:"required_if=Enabled true"`
+	// Key is the event property used as the message key. Events with the same key are sent to the same partition.
+	Key       EventKey      `yaml:"key,omitempty" validate:"omitempty,oneof=cluster namespace kind"`
+	SASL      KafkaSASL     `yaml:"sasl,omitempty"`
+	TLS       StreamTLS     `yaml:"tls,omitempty"`
+	Transform SinkTransform `yaml:"transform,omitempty"`
+	Bindings  SinkBindings  `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// KafkaSASL holds the Kafka SASL authentication settings.
+type KafkaSASL struct {
+	Enabled bool `yaml:"enabled"`
+	// Mechanism is one of PLAIN, SCRAM-SHA-256, SCRAM-SHA-512.
+	Mechanism string `yaml:"mechanism,omitempty" validate:"omitempty,oneof=PLAIN SCRAM-SHA-256 SCRAM-SHA-512"`
+	Username  string `yaml:"username,omitempty"`
+	Password  string `yaml:"password,omitempty"`
+}
+
+// NATS holds the NATS sink configuration. Events are published as JSON.
+type NATS struct {
+	Enabled bool   `yaml:"enabled"`
+	URL     string `yaml:"url" validate:"required_if=Enabled true"`
+	Subject string `yaml:"subject" validate:"required_if=Enabled true"`
+	// Key is the event property appended to the subject as the last token, e.g. `botkube.events.{namespace}`.
+	Key       EventKey      `yaml:"key,omitempty" validate:"omitempty,oneof=cluster namespace kind"`
+	Username  string        `yaml:"username,omitempty"`
+	Password  string        `yaml:"password,omitempty"`
+	Token     string        `yaml:"token,omitempty"`
+	TLS       StreamTLS     `yaml:"tls,omitempty"`
+	Transform SinkTransform `yaml:"transform,omitempty"`
+	Bindings  SinkBindings  `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// Loki holds the Grafana Loki sink configuration. Events are pushed as JSON log lines
+// with the cluster, namespace, kind, level and type labels.
+type Loki struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Loki base URL, e.g. http://loki:3100. Events are sent to the `/loki/api/v1/push` endpoint.
+	URL string `yaml:"url" validate:"required_if=Enabled true"`
+	// TenantID is sent in the X-Scope-OrgID header for multi-tenant Loki installations.
+	TenantID string `yaml:"tenantID,omitempty"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	// Labels are static labels added to each log stream, e.g. `job: botkube`.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Headers are additional HTTP headers sent with each request.
+	Headers   map[string]string `yaml:"headers,omitempty"`
+	Transform SinkTransform     `yaml:"transform,omitempty"`
+	Bindings  SinkBindings      `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// OTLPLogs holds the OpenTelemetry logs sink configuration. Events are exported using OTLP/HTTP with JSON encoding.
+type OTLPLogs struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the OTLP/HTTP base URL, e.g. http://otel-collector:4318. Events are sent to the `/v1/logs` endpoint.
+	Endpoint string `yaml:"endpoint" validate:"required_if=Enabled true"`
+	// ResourceAttributes are added to the exported resource, in addition to `service.name`.
+	ResourceAttributes map[string]string `yaml:"resourceAttributes,omitempty"`
+	// Headers are additional HTTP headers sent with each request, e.g. Authorization.
+	Headers   map[string]string `yaml:"headers,omitempty"`
+	Transform SinkTransform     `yaml:"transform,omitempty"`
+	Bindings  SinkBindings      `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// Alertmanager holds the Prometheus Alertmanager sink configuration. Events are posted as alerts.
+type Alertmanager struct {
+	Enabled bool `yaml:"enabled"`
+	// URL is the Alertmanager base URL, e.g. http://alertmanager:9093. Alerts are sent to the `/api/v2/alerts` endpoint.
+	URL      string `yaml:"url" validate:"required_if=Enabled true"`
+	Username string `yaml:"username,omitempty"`
+	Password string `yaml:"password,omitempty"`
+	// Headers are additional HTTP headers sent with each request.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Levels are the event levels turned into alerts. If empty, events with all levels are sent.
+	Levels []Level `yaml:"levels,omitempty" validate:"dive,oneof=info warn debug error critical"`
+	// Labels are static labels added to each alert.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// AlertTimeout is the time after which the alert is resolved if no resolving event arrives. Defaults to 24h.
+	AlertTimeout time.Duration `yaml:"alertTimeout,omitempty"`
+	// ResolveRules define events which resolve the previously fired alerts for the same object.
+	ResolveRules []AlertmanagerResolveRule `yaml:"resolveRules,omitempty" validate:"dive"`
+	Transform    SinkTransform             `yaml:"transform,omitempty"`
+	Bindings     SinkBindings              `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// AlertmanagerResolveRule defines an event which resolves the alerts fired for the same object.
+type AlertmanagerResolveRule struct {
+	// Reason is the reason of the event which marks the object as healthy, e.g. NodeReady.
+	Reason string `yaml:"reason" validate:"required"`
+	// Resolves are the reasons of the resolved alerts, e.g. NodeNotReady.
+	Resolves []string `yaml:"resolves" validate:"required,min=1"`
+}
+
+// S3 holds the S3-compatible object storage sink configuration. Events are archived as gzip-compressed NDJSON objects,
+// partitioned by cluster, date and hour.
+type S3 struct {
+	Enabled bool   `yaml:"enabled"`
+	Bucket  string `yaml:"bucket" validate:"required_if=Enabled true"`
+	Region  string `yaml:"region" validate:"required_if=Enabled true"`
+	// Endpoint is a custom S3-compatible endpoint, e.g. http://minio:9000. If empty, AWS S3 is used.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// ForcePathStyle enables the path-style addressing, which is usually required by MinIO.
+	ForcePathStyle bool `yaml:"forcePathStyle,omitempty"`
+	// AccessKeyID and SecretAccessKey are static credentials. If empty, the default AWS credential chain is used.
+	AccessKeyID     string `yaml:"accessKeyID,omitempty"`
+	SecretAccessKey string `yaml:"secretAccessKey,omitempty"`
+	// Prefix is prepended to all object keys, e.g. botkube/events.
+	Prefix    string        `yaml:"prefix,omitempty"`
+	Batch     S3Batch       `yaml:"batch,omitempty"`
+	Transform SinkTransform `yaml:"transform,omitempty"`
+	Bindings  SinkBindings  `yaml:"bindings" validate:"required_if=Enabled true"`
+}
+
+// S3Batch holds the S3 sink batching settings.
+type S3Batch struct {
+	// MaxEvents is the maximum number of events in a single object. Defaults to 1000.
+	MaxEvents int `yaml:"maxEvents,omitempty" validate:"omitempty,min=1"`
+	// FlushInterval is the maximum time between uploads of buffered events. Defaults to 5m.
+	FlushInterval time.Duration `yaml:"flushInterval,omitempty"`
+}
+
+// StreamTLS holds the TLS settings for the event streaming sinks.
+type StreamTLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CACert is the PEM-encoded CA certificate. If empty, the system CA pool is used.
+	CACert string `yaml:"caCert,omitempty"`
+	// Cert and Key are the PEM-encoded client certificate and key used for mutual TLS.
+	Cert          string `yaml:"cert,omitempty"`
+	Key           string `yaml:"key,omitempty"`
+	SkipTLSVerify bool   `yaml:"skipTLSVerify,omitempty"`
+}
+
+// SinkTransform holds the event transformation applied by a given sink before the event is sent.
+type SinkTransform struct {
+	// IncludeFields lists the event fields which are sent, e.g. Kind, Name, Namespace. If empty, all fields are sent.
+	IncludeFields []string `yaml:"includeFields,omitempty"`
+	// ExcludeFields lists the event fields which are not sent.
+	ExcludeFields []string `yaml:"excludeFields,omitempty"`
+	// Redactions are applied to the event title, messages, recommendations, warnings, error and action commands.
+	Redactions []SinkRedaction `yaml:"redactions,omitempty" validate:"dive"`
+	// Labels lists the Kubernetes object labels which are sent with the event. By default, no labels are sent.
+	Labels []string `yaml:"labels,omitempty"`
+	// Annotations lists the Kubernetes object annotations which are sent with the event. By default, no annotations are sent.
+	Annotations []string `yaml:"annotations,omitempty"`
+}
+
+// SinkRedaction describes a regular expression which is replaced in the event text fields.
+type SinkRedaction struct {
+	// Pattern is a regular expression in the RE2 syntax.
+	Pattern string `yaml:"pattern" validate:"required"`
+	// Replacement is the replacement text, which can reference the capture groups, e.g. ${1}. Defaults to `***`.
+	Replacement string `yaml:"replacement,omitempty"`
+}
+
+// SinkOutbox holds the disk-backed outbox settings. The outbox stores events which couldn't be delivered, and replays them in order.
+type SinkOutbox struct {
+	Enabled bool `yaml:"enabled"`
+	// Dir is the base directory for stored events. Each sink uses the `{dir}/{communication group name}/{sink name}` subdirectory.
+	Dir string `yaml:"dir,omitempty" validate:"required_if=Enabled true"`
+	// MaxBytes is the maximum size of stored events. When exceeded, the oldest events are dropped. If not set, defaults to 100MiB.
+	MaxBytes int64 `yaml:"maxBytes,omitempty"`
+	// MaxAge is the maximum age of stored events. Older events are dropped. If not set, defaults to 72h.
+	MaxAge time.Duration `yaml:"maxAge,omitempty"`
+	// RetryInterval is the time between the delivery attempts. If not set, defaults to 30s.
+	RetryInterval time.Duration `yaml:"retryInterval,omitempty"`
+}
+
+// WebhookRetry holds the Webhook retry settings. Requests are retried on network errors, 429 and 5xx responses.
+type WebhookRetry struct {
+	// MaxRetries is the maximum number of retries. If not set, defaults to 3. Set 0 to disable retries.
+	MaxRetries *int `yaml:"maxRetries,omitempty"`
+	// InitialInterval is the wait time before the first retry. It is doubled for each subsequent retry.
+	InitialInterval time.Duration `yaml:"initialInterval,omitempty"`
+	// MaxInterval is the maximum wait time between retries.
+	MaxInterval time.Duration `yaml:"maxInterval,omitempty"`
+	// MaxElapsedTime is the maximum total time spent on a request, including retries.
+	MaxElapsedTime time.Duration `yaml:"maxElapsedTime,omitempty"`
 }
 
 // Kubectl configuration for executing commands inside cluster
@@ -691,6 +1089,8 @@ type Settings struct {
 	MetricsPort           string           `yaml:"metricsPort"`
 	HealthPort            string           `yaml:"healthPort"`
 	LifecycleServer       LifecycleServer  `yaml:"lifecycleServer"`
+	HotReload             HotReload        `yaml:"hotReload,omitempty"`
+	ConfigCRDs            ConfigCRDs       `yaml:"configCRDs,omitempty"`
 	Log                   loggerx.Config   `yaml:"log"`
 	InformersResyncPeriod time.Duration    `yaml:"informersResyncPeriod"`
 	Kubeconfig            string           `yaml:"kubeconfig"`
@@ -703,6 +1103,23 @@ type LifecycleServer struct {
 	Deployment K8sResourceRef `yaml:"deployment"`
 }
 
+// ConfigCRDs contains configuration for reading the Botkube configuration from custom resources,
+// such as Source, ExecutorBinding, Action, Alias, and Channel.
+type ConfigCRDs struct {
+	Enabled bool `yaml:"enabled"`
+	// Namespaces limits the namespaces where the custom resources are watched. If empty, all namespaces are watched.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	// CommunicationGroups holds the communication groups to which the custom resources can add channels. If empty, channels cannot be added.
+	CommunicationGroups []string `yaml:"communicationGroups,omitempty"`
+}
+
+// HotReload contains configuration for applying configuration changes without restarting the app.
+type HotReload struct {
+	Enabled bool `yaml:"enabled"`
+	// Interval is the time between checks of the configuration sources.
+	Interval time.Duration `yaml:"interval" validate:"required_if=Enabled true"`
+}
+
 // PersistentConfig contains configuration for persistent storage.
 type PersistentConfig struct {
 	Startup PartialPersistentConfig `yaml:"startup"`
@@ -730,8 +1147,30 @@ type LoadWithDefaultsDetails struct {
 	ValidateWarnings error
 }
 
+// LoadOption customizes how the configuration is loaded.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	valueFromPlaceholders bool
+}
+
+// WithValueFromPlaceholders validates the `valueFrom` references and sets the ValueFromPlaceholder in their place instead of resolving them,
+// e.g. to check the configuration without access to the referenced Secrets, files and environment variables.
+func WithValueFromPlaceholders() LoadOption {
+	return func(opts *loadOptions) {
+		opts.valueFromPlaceholders = true
+	}
+}
+
 // LoadWithDefaults loads new configuration from files and environment variables.
-func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error) {
+// Files marked with the TemplateMarker are rendered as Go templates first. If a profile is selected,
+// its overlay defined under the `profiles` property is merged into each file which defines it.
+func LoadWithDefaults(configs [][]byte, opts ...LoadOption) (*Config, LoadWithDefaultsDetails, error) {
+	var options loadOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	k := koanf.New(configDelimiter)
 
 	// load default settings
@@ -739,15 +1178,35 @@ func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error
 		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("while loading default configuration: %w", err)
 	}
 
-	// merge with user configs
+	profile := SelectedProfile()
+	configs, err := renderTemplates(configs, profile)
+	if err != nil {
+		return nil, LoadWithDefaultsDetails{}, err
+	}
+
+	// merge with user configs, each one with the overlay of the selected profile applied
+	var profileFound bool
 	for _, rawCfg := range configs {
-		if err := k.Load(rawbytes.Provider(rawCfg), koanfyaml.Parser()); err != nil {
+		fileCfg := koanf.New(configDelimiter)
+		if err := fileCfg.Load(rawbytes.Provider(rawCfg), koanfyaml.Parser()); err != nil {
+			return nil, LoadWithDefaultsDetails{}, err
+		}
+		found, err := applyProfile(fileCfg, profile)
+		if err != nil {
+			return nil, LoadWithDefaultsDetails{}, err
+		}
+		profileFound = profileFound || found
+
+		if err := k.Merge(fileCfg); err != nil {
 			return nil, LoadWithDefaultsDetails{}, err
 		}
 	}
+	if profile != "" && !profileFound {
+		return nil, LoadWithDefaultsDetails{}, fmt.Errorf("profile %q is not defined in any configuration file", profile)
+	}
 
 	// LoadWithDefaults environment variables and merge into the loaded config.
-	err := k.Load(env.Provider(
+	err = k.Load(env.Provider(
 		configEnvVariablePrefix,
 		configDelimiter,
 		normalizeConfigEnvName,
@@ -756,6 +1215,12 @@ func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error
 		return nil, LoadWithDefaultsDetails{}, err
 	}
 
+	// resolve references to values stored outside the configuration, e.g. in Secrets
+	k, err = resolveValueFromRefs(k, options.valueFromPlaceholders)
+	if err != nil {
+		return nil, LoadWithDefaultsDetails{}, err
+	}
+
 	var cfg Config
 	err = k.Unmarshal("", &cfg)
 	if err != nil {
@@ -783,6 +1248,10 @@ func GetProvider(gql *graphql.Gql) config.Provider {
 		return config.NewGqlProvider(dc)
 	}
 
+	if os.Getenv(intconfig.GitProviderRepositoryEnvKey) != "" {
+		return config.NewGitProviderFromEnv()
+	}
+
 	if os.Getenv(intconfig.EnvProviderConfigPathsEnvKey) != "" {
 		return config.NewEnvProvider()
 	}
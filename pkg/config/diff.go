@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ReloadComponent describes an app component which is rebuilt in-process when the configuration changes.
+type ReloadComponent string
+
+const (
+	// RouterReloadComponent describes the sources routing table together with informers, actions and source plugins.
+	RouterReloadComponent ReloadComponent = "router"
+	// BotChannelsReloadComponent describes the channel configuration of running bots.
+	BotChannelsReloadComponent ReloadComponent = "botChannels"
+	// ExecutorsReloadComponent describes the executor factory used by bots and actions.
+	ExecutorsReloadComponent ReloadComponent = "executors"
+	// SinksReloadComponent describes the configured sinks.
+	SinksReloadComponent ReloadComponent = "sinks"
+)
+
+// Diff describes the changes between two configurations.
+type Diff struct {
+	// Components holds the components which have to be rebuilt to apply the changes.
+	Components []ReloadComponent
+	// RestartReasons holds the changes which cannot be applied in-process. If not empty, the app has to be restarted.
+	RestartReasons []string
+}
+
+// IsEmpty returns true if there are no changes.
+func (d Diff) IsEmpty() bool {
+	return len(d.Components) == 0 && len(d.RestartReasons) == 0
+}
+
+// RequiresRestart returns true if the changes cannot be applied in-process.
+func (d Diff) RequiresRestart() bool {
+	return len(d.RestartReasons) > 0
+}
+
+// Has returns true if a given component has to be rebuilt.
+func (d Diff) Has(component ReloadComponent) bool {
+	for _, c := range d.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Diff) add(components ...ReloadComponent) {
+	for _, c := range components {
+		if !d.Has(c) {
+			d.Components = append(d.Components, c)
+		}
+	}
+}
+
+func (d *Diff) restart(reason string) {
+	d.RestartReasons = append(d.RestartReasons, reason)
+}
+
+// DiffConfigs compares two configurations and returns the components affected by the changes.
+// Changes in bot credentials and in process-wide settings cannot be applied in-process and require restart.
+func DiffConfigs(old, new Config) Diff {
+	var d Diff
+
+	if !reflect.DeepEqual(old.Settings, new.Settings) {
+		d.restart("settings changed")
+	}
+	if !reflect.DeepEqual(old.Analytics, new.Analytics) {
+		d.restart("analytics settings changed")
+	}
+	if !reflect.DeepEqual(old.ConfigWatcher, new.ConfigWatcher) {
+		d.restart("Config Watcher settings changed")
+	}
+	if !reflect.DeepEqual(old.Plugins, new.Plugins) {
+		d.restart("plugins settings changed")
+	}
+
+	for _, name := range commGroupNames(old.Communications, new.Communications) {
+		oldGroup, newGroup := old.Communications[name], new.Communications[name]
+
+		oldBots, newBots := botSettingsWithoutChannels(oldGroup), botSettingsWithoutChannels(newGroup)
+		for _, platform := range botPlatforms {
+			if !reflect.DeepEqual(oldBots[platform], newBots[platform]) {
+				d.restart(fmt.Sprintf("%s bot settings changed in %q communication group", platform, name))
+			}
+		}
+
+		if !reflect.DeepEqual(botChannels(oldGroup), botChannels(newGroup)) {
+			d.add(BotChannelsReloadComponent, RouterReloadComponent, ExecutorsReloadComponent)
+		}
+
+		if !reflect.DeepEqual(sinkSettings(oldGroup), sinkSettings(newGroup)) {
+			d.add(SinksReloadComponent, RouterReloadComponent)
+		}
+	}
+
+	if !reflect.DeepEqual(old.Sources, new.Sources) ||
+		!reflect.DeepEqual(old.Actions, new.Actions) ||
+		!reflect.DeepEqual(old.Filters, new.Filters) {
+		// executors render the sources, actions and filters configuration, e.g. for the `list` commands
+		d.add(RouterReloadComponent, ExecutorsReloadComponent)
+	}
+
+	if !reflect.DeepEqual(old.Executors, new.Executors) || !reflect.DeepEqual(old.Aliases, new.Aliases) {
+		d.add(ExecutorsReloadComponent)
+	}
+
+	return d
+}
+
+// botPlatforms holds the bot platforms in the order of the Communications struct fields.
+var botPlatforms = []CommPlatformIntegration{
+	SlackCommPlatformIntegration,
+	SocketSlackCommPlatformIntegration,
+	MattermostCommPlatformIntegration,
+	DiscordCommPlatformIntegration,
+	TelegramCommPlatformIntegration,
+	GoogleChatCommPlatformIntegration,
+	RocketChatCommPlatformIntegration,
+	TeamsCommPlatformIntegration,
+}
+
+// botSettingsWithoutChannels returns the bot settings which can be applied only when the bot is created, e.g. credentials.
+// MS Teams doesn't support channel configuration, so its bindings can be changed only by restarting the app.
+func botSettingsWithoutChannels(c Communications) map[CommPlatformIntegration]any {
+	c.Slack.Channels = nil
+	c.SocketSlack.Channels = nil
+	c.Mattermost.Channels = nil
+	c.Discord.Channels = nil
+	c.Telegram.Channels = nil
+	c.GoogleChat.Channels = nil
+	c.RocketChat.Channels = nil
+
+	return map[CommPlatformIntegration]any{
+		SlackCommPlatformIntegration:       c.Slack,
+		SocketSlackCommPlatformIntegration: c.SocketSlack,
+		MattermostCommPlatformIntegration:  c.Mattermost,
+		DiscordCommPlatformIntegration:     c.Discord,
+		TelegramCommPlatformIntegration:    c.Telegram,
+		GoogleChatCommPlatformIntegration:  c.GoogleChat,
+		RocketChatCommPlatformIntegration:  c.RocketChat,
+		TeamsCommPlatformIntegration:       c.Teams,
+	}
+}
+
+func botChannels(c Communications) map[CommPlatformIntegration]any {
+	return map[CommPlatformIntegration]any{
+		SlackCommPlatformIntegration:       c.Slack.Channels,
+		SocketSlackCommPlatformIntegration: c.SocketSlack.Channels,
+		MattermostCommPlatformIntegration:  c.Mattermost.Channels,
+		DiscordCommPlatformIntegration:     c.Discord.Channels,
+		TelegramCommPlatformIntegration:    c.Telegram.Channels,
+		GoogleChatCommPlatformIntegration:  c.GoogleChat.Channels,
+		RocketChatCommPlatformIntegration:  c.RocketChat.Channels,
+	}
+}
+
+func sinkSettings(c Communications) map[CommPlatformIntegration]any {
+	return map[CommPlatformIntegration]any{
+		ElasticsearchCommPlatformIntegration: c.Elasticsearch,
+		WebhookCommPlatformIntegration:       c.Webhook,
+		KafkaCommPlatformIntegration:         c.Kafka,
+		NATSCommPlatformIntegration:          c.NATS,
+		LokiCommPlatformIntegration:          c.Loki,
+		OTLPLogsCommPlatformIntegration:      c.OTLPLogs,
+		AlertmanagerCommPlatformIntegration:  c.Alertmanager,
+		S3CommPlatformIntegration:            c.S3,
+	}
+}
+
+func commGroupNames(old, new map[string]Communications) []string {
+	var out []string
+	for name := range old {
+		out = append(out, name)
+	}
+	for name := range new {
+		if _, found := old[name]; !found {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
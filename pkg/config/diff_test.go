@@ -0,0 +1,137 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	fixConfig := func() Config {
+		return Config{
+			Sources: map[string]Sources{
+				"k8s-events": {DisplayName: "K8s events"},
+			},
+			Communications: map[string]Communications{
+				"default-group": {
+					SocketSlack: SocketSlack{
+						Enabled:  true,
+						BotToken: "xoxb-token",
+						AppToken: "xapp-token",
+						Channels: IdentifiableMap[ChannelBindingsByName]{
+							"default": {
+								Name:     "botkube",
+								Bindings: BotBindings{Sources: []string{"k8s-events"}},
+							},
+						},
+					},
+					Webhook: Webhook{
+						Enabled:  true,
+						URL:      "http://example.com",
+						Bindings: SinkBindings{Sources: []string{"k8s-events"}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modifyFn func(cfg *Config)
+		expected Diff
+	}{
+		{
+			name:     "No changes",
+			modifyFn: func(cfg *Config) {},
+			expected: Diff{},
+		},
+		{
+			name: "Channel bindings changed",
+			modifyFn: func(cfg *Config) {
+				channel := cfg.Communications["default-group"].SocketSlack.Channels["default"]
+				channel.Bindings.Sources = append(channel.Bindings.Sources, "k8s-err-events")
+				cfg.Communications["default-group"].SocketSlack.Channels["default"] = channel
+			},
+			expected: Diff{
+				Components: []ReloadComponent{BotChannelsReloadComponent, RouterReloadComponent, ExecutorsReloadComponent},
+			},
+		},
+		{
+			name: "Sink changed",
+			modifyFn: func(cfg *Config) {
+				group := cfg.Communications["default-group"]
+				group.Webhook.URL = "http://example.com/events"
+				cfg.Communications["default-group"] = group
+			},
+			expected: Diff{
+				Components: []ReloadComponent{SinksReloadComponent, RouterReloadComponent},
+			},
+		},
+		{
+			name: "Sources changed",
+			modifyFn: func(cfg *Config) {
+				cfg.Sources["k8s-err-events"] = Sources{DisplayName: "K8s errors"}
+			},
+			expected: Diff{
+				Components: []ReloadComponent{RouterReloadComponent, ExecutorsReloadComponent},
+			},
+		},
+		{
+			name: "Executors changed",
+			modifyFn: func(cfg *Config) {
+				cfg.Executors = map[string]Executors{
+					"kubectl-read-only": {Kubectl: Kubectl{Enabled: true}},
+				}
+			},
+			expected: Diff{
+				Components: []ReloadComponent{ExecutorsReloadComponent},
+			},
+		},
+		{
+			name: "Bot credentials changed",
+			modifyFn: func(cfg *Config) {
+				group := cfg.Communications["default-group"]
+				group.SocketSlack.BotToken = "xoxb-new-token"
+				cfg.Communications["default-group"] = group
+			},
+			expected: Diff{
+				RestartReasons: []string{`socketSlack bot settings changed in "default-group" communication group`},
+			},
+		},
+		{
+			name: "Bot enabled in a new communication group",
+			modifyFn: func(cfg *Config) {
+				cfg.Communications["other-group"] = Communications{
+					Discord: Discord{Enabled: true, Token: "token"},
+				}
+			},
+			expected: Diff{
+				RestartReasons: []string{`discord bot settings changed in "other-group" communication group`},
+			},
+		},
+		{
+			name: "Settings changed",
+			modifyFn: func(cfg *Config) {
+				cfg.Settings.ClusterName = "other"
+			},
+			expected: Diff{
+				RestartReasons: []string{"settings changed"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			oldCfg := fixConfig()
+			newCfg := fixConfig()
+			tc.modifyFn(&newCfg)
+
+			// when
+			diff := DiffConfigs(oldCfg, newCfg)
+
+			// then
+			assert.Equal(t, tc.expected, diff)
+			assert.Equal(t, len(tc.expected.RestartReasons) > 0, diff.RequiresRestart())
+		})
+	}
+}
@@ -1,5 +1,24 @@
 package config
 
+import "time"
+
 func NormalizeConfigEnvName(name string) string {
 	return normalizeConfigEnvName(name)
 }
+
+// SetSecretDataGetter replaces the getter used to resolve the secretKeyRef references.
+// It returns a function which restores the original one.
+func SetSecretDataGetter(getter SecretDataGetter) func() {
+	orig := newSecretDataGetter
+	newSecretDataGetter = func(string) (SecretDataGetter, error) {
+		return getter, nil
+	}
+	return func() {
+		newSecretDataGetter = orig
+	}
+}
+
+// SetNow replaces the clock used to timestamp the configuration changes.
+func (m *PersistenceManager) SetNow(now func() time.Time) {
+	m.now = now
+}
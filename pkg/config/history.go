@@ -0,0 +1,167 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxConfigHistoryLen is the maximum number of configuration changes kept in the runtime state. The oldest changes are removed first.
+const MaxConfigHistoryLen = 20
+
+// ErrConfigChangeNotFound is an error returned when a given configuration change is not found in the history.
+var ErrConfigChangeNotFound = errors.New("configuration change not found in history")
+
+// ErrConfigChangeNotAllowed is an error returned when a given configuration change cannot be rolled back from a given channel.
+var ErrConfigChangeNotAllowed = errors.New("configuration change belongs to a different channel")
+
+// ConfigChangeType defines the type of the runtime configuration change.
+type ConfigChangeType string
+
+const (
+	// SourceBindingsConfigChange is a change of the source bindings for a given channel.
+	SourceBindingsConfigChange ConfigChangeType = "sourceBindings"
+	// NotificationsConfigChange is a change of the notifications state for a given channel.
+	NotificationsConfigChange ConfigChangeType = "notifications"
+	// FilterConfigChange is a change of the filter state.
+	FilterConfigChange ConfigChangeType = "filter"
+	// ActionConfigChange is a change of the action state.
+	ActionConfigChange ConfigChangeType = "action"
+)
+
+// ConfigHistory holds the runtime configuration changes, from the oldest to the newest one.
+type ConfigHistory []ConfigChange
+
+// ConfigChange represents a single runtime configuration change.
+type ConfigChange struct {
+	ID        int                     `yaml:"id"`
+	Timestamp time.Time               `yaml:"timestamp"`
+	User      string                  `yaml:"user,omitempty"`
+	Platform  CommPlatformIntegration `yaml:"platform,omitempty"`
+	Type      ConfigChangeType        `yaml:"type"`
+	Target    ConfigChangeTarget      `yaml:"target"`
+	// Before is nil if the value wasn't persisted before the change, so the value from the Botkube configuration was used.
+	Before *ConfigChangeValue `yaml:"before,omitempty"`
+	// After is nil if the persisted value was removed, so the value from the Botkube configuration is used.
+	After *ConfigChangeValue `yaml:"after,omitempty"`
+	// RollbackOf is the ID of the change reverted by this change.
+	RollbackOf int `yaml:"rollbackOf,omitempty"`
+}
+
+// ConfigChangeTarget identifies the changed configuration.
+type ConfigChangeTarget struct {
+	CommGroup string                  `yaml:"commGroup,omitempty"`
+	Platform  CommPlatformIntegration `yaml:"platform,omitempty"`
+	Channel   string                  `yaml:"channel,omitempty"`
+	// Name is the name of the changed filter or action.
+	Name string `yaml:"name,omitempty"`
+}
+
+// IsChannelScoped returns true if a given change type modifies the configuration of a single channel.
+func (t ConfigChangeType) IsChannelScoped() bool {
+	return t == SourceBindingsConfigChange || t == NotificationsConfigChange
+}
+
+// CanBeRolledBackFrom returns true if a given change can be rolled back from a given channel.
+// Channel changes can be rolled back only from the changed channel. Filter and action changes are global,
+// so they can be rolled back from any channel, the same as they can be enabled or disabled.
+func (c ConfigChange) CanBeRolledBackFrom(channel ConfigChangeTarget) bool {
+	if !c.Type.IsChannelScoped() {
+		return true
+	}
+	return c.Target.CommGroup == channel.CommGroup &&
+		c.Target.Platform == channel.Platform &&
+		c.Target.Channel == channel.Channel
+}
+
+// ConfigChangeValue holds the changed value. Only the field related to the change type is set.
+type ConfigChangeValue struct {
+	Sources []string `yaml:"sources,omitempty"`
+	Enabled *bool    `yaml:"enabled,omitempty"`
+}
+
+// ChangeAuthor describes who requested the runtime configuration change.
+type ChangeAuthor struct {
+	User     string
+	Platform CommPlatformIntegration
+}
+
+type changeAuthorCtxKey struct{}
+
+// WithChangeAuthor returns a copy of a given context with the author of the configuration changes persisted within it.
+func WithChangeAuthor(ctx context.Context, author ChangeAuthor) context.Context {
+	return context.WithValue(ctx, changeAuthorCtxKey{}, author)
+}
+
+func changeAuthorFromContext(ctx context.Context) ChangeAuthor {
+	author, _ := ctx.Value(changeAuthorCtxKey{}).(ChangeAuthor)
+	return author
+}
+
+// Get returns the change with a given ID.
+func (h ConfigHistory) Get(id int) (ConfigChange, bool) {
+	for _, change := range h {
+		if change.ID == id {
+			return change, true
+		}
+	}
+	return ConfigChange{}, false
+}
+
+// withChange returns the history with a given change appended. The change gets the next ID, and the oldest changes are removed above MaxConfigHistoryLen.
+func (h ConfigHistory) withChange(change ConfigChange) (ConfigHistory, ConfigChange) {
+	change.ID = 1
+	if len(h) > 0 {
+		change.ID = h[len(h)-1].ID + 1
+	}
+
+	out := append(ConfigHistory{}, h...)
+	out = append(out, change)
+	if len(out) > MaxConfigHistoryLen {
+		out = out[len(out)-MaxConfigHistoryLen:]
+	}
+	return out, change
+}
+
+// Subject returns the human-readable description of the changed configuration.
+func (c ConfigChange) Subject() string {
+	switch c.Type {
+	case SourceBindingsConfigChange:
+		return fmt.Sprintf("source bindings of %s", c.Target.channel())
+	case NotificationsConfigChange:
+		return fmt.Sprintf("notifications of %s", c.Target.channel())
+	case FilterConfigChange:
+		return fmt.Sprintf("filter %q", c.Target.Name)
+	case ActionConfigChange:
+		return fmt.Sprintf("action %q", c.Target.Name)
+	default:
+		return string(c.Type)
+	}
+}
+
+func (t ConfigChangeTarget) channel() string {
+	if t.Platform == TeamsCommPlatformIntegration {
+		return fmt.Sprintf("%s in %q communication group", t.Platform, t.CommGroup)
+	}
+	return fmt.Sprintf("%s channel %q in %q communication group", t.Platform, t.Channel, t.CommGroup)
+}
+
+// String returns the human-readable value.
+func (v *ConfigChangeValue) String() string {
+	switch {
+	case v == nil:
+		return "<not set>"
+	case v.Enabled != nil && *v.Enabled:
+		return "enabled"
+	case v.Enabled != nil:
+		return "disabled"
+	default:
+		return fmt.Sprintf("[%s]", strings.Join(v.Sources, ", "))
+	}
+}
+
+func enabledValue(enabled bool) *ConfigChangeValue {
+	return &ConfigChangeValue{Enabled: &enabled}
+}
@@ -0,0 +1,212 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var historyTestCfg = config.PersistentConfig{
+	Startup: config.PartialPersistentConfig{
+		FileName:  "_startup_state.yaml",
+		ConfigMap: config.K8sResourceRef{Name: "startup", Namespace: "ns"},
+	},
+	Runtime: config.PartialPersistentConfig{
+		FileName:  "_runtime_state.yaml",
+		ConfigMap: config.K8sResourceRef{Name: "runtime", Namespace: "ns"},
+	},
+}
+
+func TestPersistenceManager_RecordsChangeHistory(t *testing.T) {
+	// given
+	manager, _ := newHistoryTestManager(t, "")
+	ctx := config.WithChangeAuthor(context.Background(), config.ChangeAuthor{User: "Alice", Platform: config.SocketSlackCommPlatformIntegration})
+
+	// when
+	require.NoError(t, manager.PersistSourceBindings(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "general", []string{"k8s-events"}))
+	require.NoError(t, manager.PersistNotificationsEnabled(ctx, "default-group", config.SocketSlackCommPlatformIntegration, "general", false))
+	require.NoError(t, manager.PersistFilterEnabled(ctx, "NodeEventsChecker", true))
+
+	// then
+	history, err := manager.ListConfigChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+
+	assert.Equal(t, 1, history[0].ID)
+	assert.Equal(t, "Alice", history[0].User)
+	assert.Equal(t, config.SocketSlackCommPlatformIntegration, history[0].Platform)
+	assert.Equal(t, fixTime(), history[0].Timestamp)
+	assert.Equal(t, config.SourceBindingsConfigChange, history[0].Type)
+	assert.Nil(t, history[0].Before)
+	assert.Equal(t, []string{"k8s-events"}, history[0].After.Sources)
+
+	assert.Equal(t, 2, history[1].ID)
+	assert.Equal(t, config.NotificationsConfigChange, history[1].Type)
+	assert.Equal(t, "disabled", history[1].After.String())
+
+	assert.Equal(t, 3, history[2].ID)
+	assert.Equal(t, `filter "NodeEventsChecker"`, history[2].Subject())
+	assert.Equal(t, "disabled", history[2].Before.String())
+	assert.Equal(t, "enabled", history[2].After.String())
+}
+
+func TestPersistenceManager_HistoryIsBounded(t *testing.T) {
+	// given
+	manager, _ := newHistoryTestManager(t, "")
+
+	// when
+	for i := 0; i < config.MaxConfigHistoryLen+5; i++ {
+		require.NoError(t, manager.PersistSourceBindings(context.Background(), "default-group", config.SlackCommPlatformIntegration, "general", []string{fmt.Sprintf("source-%d", i)}))
+	}
+
+	// then
+	history, err := manager.ListConfigChanges(context.Background())
+	require.NoError(t, err)
+	require.Len(t, history, config.MaxConfigHistoryLen)
+	assert.Equal(t, 6, history[0].ID)
+	assert.Equal(t, config.MaxConfigHistoryLen+5, history[len(history)-1].ID)
+}
+
+func TestPersistenceManager_RollbackConfigChange(t *testing.T) {
+	// given
+	manager, k8sCli := newHistoryTestManager(t, heredoc.Doc(`
+		communications:
+		  default-group:
+		    slack:
+		      channels:
+		        general:
+		          bindings:
+		            sources: [k8s-events]
+		actions:
+		  describe-pod:
+		    enabled: true
+	`))
+	ctx := context.Background()
+	require.NoError(t, manager.PersistSourceBindings(ctx, "default-group", config.SlackCommPlatformIntegration, "general", []string{"k8s-err-events"}))
+	require.NoError(t, manager.PersistSourceBindings(ctx, "default-group", config.SlackCommPlatformIntegration, "random", []string{"k8s-err-events"}))
+	require.NoError(t, manager.PersistActionEnabled(ctx, "describe-pod", false))
+
+	// when
+	rollbackCtx := config.WithChangeAuthor(ctx, config.ChangeAuthor{User: "Bob", Platform: config.SlackCommPlatformIntegration})
+	first, err := manager.RollbackConfigChange(rollbackCtx, 1, slackChannel("general"))
+	require.NoError(t, err)
+	second, err := manager.RollbackConfigChange(rollbackCtx, 2, slackChannel("random"))
+	require.NoError(t, err)
+	third, err := manager.RollbackConfigChange(rollbackCtx, 3, slackChannel("random"))
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, 4, first.ID)
+	assert.Equal(t, 1, first.RollbackOf)
+	assert.Equal(t, "Bob", first.User)
+	assert.Equal(t, []string{"k8s-err-events"}, first.Before.Sources)
+	assert.Equal(t, []string{"k8s-events"}, first.After.Sources)
+
+	assert.Equal(t, 5, second.ID)
+	assert.Nil(t, second.After)
+
+	assert.Equal(t, 6, third.ID)
+	assert.Equal(t, "enabled", third.After.String())
+
+	cm, err := k8sCli.CoreV1().ConfigMaps("ns").Get(ctx, "runtime", metav1.GetOptions{})
+	require.NoError(t, err)
+	runtimeState := cm.Data[historyTestCfg.Runtime.FileName]
+	assert.Contains(t, runtimeState, heredoc.Doc(`
+		communications:
+		  default-group:
+		    slack:
+		      channels:
+		        general:
+		          bindings:
+		            sources:
+		              - k8s-events
+		actions:
+		  describe-pod:
+		    enabled: true
+		history:
+	`))
+
+	// runtime state with history is still a valid Botkube configuration
+	baseCfg := heredoc.Doc(`
+		sources:
+		  k8s-events:
+		    displayName: Events
+		actions:
+		  describe-pod:
+		    command: kubectl describe pod
+		    bindings:
+		      sources: [k8s-events]
+	`)
+	_, _, err = config.LoadWithDefaults([][]byte{[]byte(baseCfg), []byte(runtimeState)})
+	require.NoError(t, err)
+}
+
+func TestPersistenceManager_RollbackConfigChangeNotFound(t *testing.T) {
+	// given
+	manager, _ := newHistoryTestManager(t, "")
+
+	// when
+	_, err := manager.RollbackConfigChange(context.Background(), 42, slackChannel("general"))
+
+	// then
+	require.Error(t, err)
+	assert.ErrorIs(t, err, config.ErrConfigChangeNotFound)
+}
+
+func TestPersistenceManager_RollbackConfigChangeFromDifferentChannel(t *testing.T) {
+	// given
+	manager, _ := newHistoryTestManager(t, "")
+	ctx := context.Background()
+	require.NoError(t, manager.PersistSourceBindings(ctx, "default-group", config.SlackCommPlatformIntegration, "general", []string{"k8s-err-events"}))
+	require.NoError(t, manager.PersistNotificationsEnabled(ctx, "default-group", config.SlackCommPlatformIntegration, "general", false))
+
+	for _, channel := range []config.ConfigChangeTarget{
+		slackChannel("random"),
+		{CommGroup: "other-group", Platform: config.SlackCommPlatformIntegration, Channel: "general"},
+		{CommGroup: "default-group", Platform: config.DiscordCommPlatformIntegration, Channel: "general"},
+	} {
+		for _, id := range []int{1, 2} {
+			// when
+			_, err := manager.RollbackConfigChange(ctx, id, channel)
+
+			// then
+			require.Error(t, err)
+			assert.ErrorIs(t, err, config.ErrConfigChangeNotAllowed)
+		}
+	}
+
+	history, err := manager.ListConfigChanges(ctx)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func slackChannel(name string) config.ConfigChangeTarget {
+	return config.ConfigChangeTarget{CommGroup: "default-group", Platform: config.SlackCommPlatformIntegration, Channel: name}
+}
+
+func newHistoryTestManager(t *testing.T, runtimeState string) (*config.PersistenceManager, *fake.Clientset) {
+	t.Helper()
+
+	k8sCli := fake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: historyTestCfg.Startup.ConfigMap.Name, Namespace: "ns"},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: historyTestCfg.Runtime.ConfigMap.Name, Namespace: "ns"},
+			Data:       map[string]string{historyTestCfg.Runtime.FileName: runtimeState},
+		},
+	)
+	manager := config.NewManager(loggerx.NewNoop(), historyTestCfg, k8sCli)
+	manager.SetNow(fixTime)
+	return manager, k8sCli
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
@@ -10,10 +12,12 @@ import (
 )
 
 // PersistenceManager manages persistence of the configuration.
+// Every persisted change is recorded in the runtime state history, together with its author set via WithChangeAuthor.
 type PersistenceManager struct {
 	log    logrus.FieldLogger
 	cfg    PersistentConfig
 	k8sCli kubernetes.Interface
+	now    func() time.Time
 }
 
 // ErrUnsupportedPlatform is an error returned when a platform is not supported.
@@ -25,6 +29,7 @@ func NewManager(log logrus.FieldLogger, cfg PersistentConfig, k8sCli kubernetes.
 		log:    log,
 		cfg:    cfg,
 		k8sCli: k8sCli,
+		now:    time.Now,
 	}
 }
 
@@ -34,6 +39,9 @@ func (m *PersistenceManager) PersistSourceBindings(ctx context.Context, commGrou
 		string(SlackCommPlatformIntegration),
 		string(SocketSlackCommPlatformIntegration),
 		string(DiscordCommPlatformIntegration),
+		string(TelegramCommPlatformIntegration),
+		string(GoogleChatCommPlatformIntegration),
+		string(RocketChatCommPlatformIntegration),
 		string(MattermostCommPlatformIntegration),
 		string(TeamsCommPlatformIntegration),
 	}
@@ -42,157 +50,306 @@ func (m *PersistenceManager) PersistSourceBindings(ctx context.Context, commGrou
 		return ErrUnsupportedPlatform
 	}
 
-	configMapStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+	target := ConfigChangeTarget{CommGroup: commGroupName, Platform: platform, Channel: channelAlias}
+	if platform == TeamsCommPlatformIntegration {
+		// Teams bindings are set for the whole bot
+		target.Channel = ""
+	}
+	change := m.newChange(ctx, SourceBindingsConfigChange, target, &ConfigChangeValue{Sources: sourceBindings})
+	_, err := m.updateRuntimeState(ctx, change, func(state *RuntimeState) (*ConfigChangeValue, error) {
+		return setSourceBindings(state, target, change.After), nil
+	})
+	return err
+}
+
+// PersistNotificationsEnabled persists notifications state for a given channel.
+// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
+func (m *PersistenceManager) PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, enabled bool) error {
+	supportedPlatforms := []string{
+		string(SlackCommPlatformIntegration),
+		string(SocketSlackCommPlatformIntegration),
+		string(DiscordCommPlatformIntegration),
+		string(TelegramCommPlatformIntegration),
+		string(GoogleChatCommPlatformIntegration),
+		string(RocketChatCommPlatformIntegration),
+		string(MattermostCommPlatformIntegration),
+	}
+
+	if !slices.Contains(supportedPlatforms, string(platform)) {
+		return ErrUnsupportedPlatform
+	}
+
+	target := ConfigChangeTarget{CommGroup: commGroupName, Platform: platform, Channel: channelAlias}
+	change := m.newChange(ctx, NotificationsConfigChange, target, enabledValue(enabled))
+	_, err := m.updateStartupState(ctx, change, func(state *StartupState) (*ConfigChangeValue, error) {
+		return setNotificationsEnabled(state, target, change.After), nil
+	})
+	return err
+}
+
+// PersistFilterEnabled persists status for a given filter.
+// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
+func (m *PersistenceManager) PersistFilterEnabled(ctx context.Context, name string, enabled bool) error {
+	target := ConfigChangeTarget{Name: name}
+	change := m.newChange(ctx, FilterConfigChange, target, enabledValue(enabled))
+	_, err := m.updateStartupState(ctx, change, func(state *StartupState) (*ConfigChangeValue, error) {
+		return setFilterEnabled(state, name, change.After)
+	})
+	return err
+}
+
+// PersistActionEnabled updates runtime config map with desired action.enabled parameter
+func (m *PersistenceManager) PersistActionEnabled(ctx context.Context, name string, enabled bool) error {
+	target := ConfigChangeTarget{Name: name}
+	change := m.newChange(ctx, ActionConfigChange, target, enabledValue(enabled))
+	_, err := m.updateRuntimeState(ctx, change, func(state *RuntimeState) (*ConfigChangeValue, error) {
+		return setActionEnabled(state, name, change.After)
+	})
+	return err
+}
 
-	state, cm, err := configMapStorage.Get(ctx)
+// ListConfigChanges returns the history of the runtime configuration changes, from the oldest to the newest one.
+func (m *PersistenceManager) ListConfigChanges(ctx context.Context) (ConfigHistory, error) {
+	if !m.isHistoryEnabled() {
+		return nil, nil
+	}
+
+	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+	state, _, err := cmStorage.Get(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return state.History, nil
+}
 
-	if state.Communications == nil {
-		state.Communications = make(map[string]CommunicationsRuntimeState)
+// RollbackConfigChange restores the value replaced by the change with a given ID.
+// The rollback is recorded in the history as a new change, which is returned.
+// Channel changes can be rolled back only from the changed channel, otherwise ErrConfigChangeNotAllowed is returned.
+func (m *PersistenceManager) RollbackConfigChange(ctx context.Context, id int, channel ConfigChangeTarget) (ConfigChange, error) {
+	history, err := m.ListConfigChanges(ctx)
+	if err != nil {
+		return ConfigChange{}, err
 	}
-	commGroup, exists := state.Communications[commGroupName]
-	if !exists {
-		commGroup = make(CommunicationsRuntimeState)
-		state.Communications[commGroupName] = commGroup
+	change, found := history.Get(id)
+	if !found {
+		return ConfigChange{}, fmt.Errorf("while getting change %d: %w", id, ErrConfigChangeNotFound)
+	}
+	if !change.CanBeRolledBackFrom(channel) {
+		return ConfigChange{}, fmt.Errorf("while rolling back change %d: %w", id, ErrConfigChangeNotAllowed)
 	}
 
-	platformCfg, exists := commGroup[platform]
-	if !exists {
-		platformCfg = BotRuntimeState{}
-		state.Communications[commGroupName][platform] = platformCfg
+	rollback := m.newChange(ctx, change.Type, change.Target, change.Before)
+	rollback.RollbackOf = change.ID
+
+	switch change.Type {
+	case SourceBindingsConfigChange:
+		return m.updateRuntimeState(ctx, rollback, func(state *RuntimeState) (*ConfigChangeValue, error) {
+			return setSourceBindings(state, change.Target, change.Before), nil
+		})
+	case ActionConfigChange:
+		return m.updateRuntimeState(ctx, rollback, func(state *RuntimeState) (*ConfigChangeValue, error) {
+			return setActionEnabled(state, change.Target.Name, change.Before)
+		})
+	case NotificationsConfigChange:
+		return m.updateStartupState(ctx, rollback, func(state *StartupState) (*ConfigChangeValue, error) {
+			return setNotificationsEnabled(state, change.Target, change.Before), nil
+		})
+	case FilterConfigChange:
+		return m.updateStartupState(ctx, rollback, func(state *StartupState) (*ConfigChangeValue, error) {
+			return setFilterEnabled(state, change.Target.Name, change.Before)
+		})
+	default:
+		return ConfigChange{}, fmt.Errorf("unknown type %q of change %d", change.Type, change.ID)
 	}
+}
 
-	if platform == TeamsCommPlatformIntegration {
-		if platformCfg.MSTeamsOnlyRuntimeState == nil {
-			platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
-		}
+func (m *PersistenceManager) newChange(ctx context.Context, changeType ConfigChangeType, target ConfigChangeTarget, after *ConfigChangeValue) ConfigChange {
+	author := changeAuthorFromContext(ctx)
+	return ConfigChange{
+		Timestamp: m.now().UTC().Truncate(time.Second),
+		User:      author.User,
+		Platform:  author.Platform,
+		Type:      changeType,
+		Target:    target,
+		After:     after,
+	}
+}
 
-		platformCfg.MSTeamsOnlyRuntimeState.Bindings.Sources = sourceBindings
-		state.Communications[commGroupName][platform] = platformCfg
+// isHistoryEnabled returns true if the runtime state, which holds the history, is configured.
+func (m *PersistenceManager) isHistoryEnabled() bool {
+	return m.cfg.Runtime.ConfigMap.Name != ""
+}
 
-		err = configMapStorage.Update(ctx, cm, state)
+// updateRuntimeState applies a given change to the runtime state and records it in the history within the same update.
+// Concurrent updates of the runtime state are not overridden, as the change is re-applied on top of them.
+func (m *PersistenceManager) updateRuntimeState(ctx context.Context, change ConfigChange, apply func(state *RuntimeState) (*ConfigChangeValue, error)) (ConfigChange, error) {
+	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
+
+	var out ConfigChange
+	err := cmStorage.Modify(ctx, func(state *RuntimeState) error {
+		recorded := change
+		before, err := apply(state)
 		if err != nil {
 			return err
 		}
+		recorded.Before = before
+		state.History, out = state.History.withChange(recorded)
+		return nil
+	})
+	if err != nil {
+		return ConfigChange{}, err
+	}
+	return out, nil
+}
+
+// updateStartupState applies a given change to the startup state and records it in the runtime state history.
+// Concurrent updates of the startup state are not overridden, as the change is re-applied on top of them.
+func (m *PersistenceManager) updateStartupState(ctx context.Context, change ConfigChange, apply func(state *StartupState) (*ConfigChangeValue, error)) (ConfigChange, error) {
+	cmStorage := configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup}
 
+	err := cmStorage.Modify(ctx, func(state *StartupState) error {
+		before, err := apply(state)
+		if err != nil {
+			return err
+		}
+		change.Before = before
 		return nil
+	})
+	if err != nil {
+		return ConfigChange{}, err
 	}
 
-	if platformCfg.Channels == nil {
-		platformCfg.Channels = make(map[string]ChannelRuntimeState)
-		state.Communications[commGroupName][platform] = platformCfg
+	if !m.isHistoryEnabled() {
+		m.log.Debug("Runtime state is not configured. Skipping recording configuration change...")
+		return change, nil
 	}
 
-	channel, exists := platformCfg.Channels[channelAlias]
+	change, err = m.updateRuntimeState(ctx, change, func(*RuntimeState) (*ConfigChangeValue, error) {
+		return change.Before, nil
+	})
+	if err != nil {
+		return ConfigChange{}, fmt.Errorf("while recording configuration change: %w", err)
+	}
+	return change, nil
+}
+
+// setSourceBindings sets source bindings for a given channel and returns the previous ones. If the value is nil, the persisted bindings are removed.
+func setSourceBindings(state *RuntimeState, target ConfigChangeTarget, value *ConfigChangeValue) *ConfigChangeValue {
+	if state.Communications == nil {
+		state.Communications = make(map[string]CommunicationsRuntimeState)
+	}
+	commGroup, exists := state.Communications[target.CommGroup]
 	if !exists {
-		channel = ChannelRuntimeState{}
+		commGroup = make(CommunicationsRuntimeState)
+		state.Communications[target.CommGroup] = commGroup
 	}
 
-	channel.Bindings.Sources = sourceBindings
-	state.Communications[commGroupName][platform].Channels[channelAlias] = channel
+	platformCfg := commGroup[target.Platform]
 
-	err = configMapStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
-	}
+	if target.Platform == TeamsCommPlatformIntegration {
+		var before *ConfigChangeValue
+		if platformCfg.MSTeamsOnlyRuntimeState != nil {
+			before = &ConfigChangeValue{Sources: platformCfg.MSTeamsOnlyRuntimeState.Bindings.Sources}
+		}
 
-	return nil
-}
+		switch value {
+		case nil:
+			platformCfg.MSTeamsOnlyRuntimeState = nil
+		default:
+			if platformCfg.MSTeamsOnlyRuntimeState == nil {
+				platformCfg.MSTeamsOnlyRuntimeState = &ChannelRuntimeState{}
+			}
+			platformCfg.MSTeamsOnlyRuntimeState.Bindings.Sources = value.Sources
+		}
+		commGroup[target.Platform] = platformCfg
+		return before
+	}
 
-// PersistNotificationsEnabled persists notifications state for a given channel.
-// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
-func (m *PersistenceManager) PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform CommPlatformIntegration, channelAlias string, enabled bool) error {
-	supportedPlatforms := []string{
-		string(SlackCommPlatformIntegration),
-		string(SocketSlackCommPlatformIntegration),
-		string(DiscordCommPlatformIntegration),
-		string(MattermostCommPlatformIntegration),
+	if platformCfg.Channels == nil {
+		platformCfg.Channels = make(map[string]ChannelRuntimeState)
 	}
 
-	if !slices.Contains(supportedPlatforms, string(platform)) {
-		return ErrUnsupportedPlatform
+	var before *ConfigChangeValue
+	channel, exists := platformCfg.Channels[target.Channel]
+	if exists {
+		before = &ConfigChangeValue{Sources: channel.Bindings.Sources}
 	}
 
-	cmStorage := configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup}
-	state, cm, err := cmStorage.Get(ctx)
-	if err != nil {
-		return err
+	switch value {
+	case nil:
+		delete(platformCfg.Channels, target.Channel)
+	default:
+		channel.Bindings.Sources = value.Sources
+		platformCfg.Channels[target.Channel] = channel
 	}
+	commGroup[target.Platform] = platformCfg
+	return before
+}
 
+// setNotificationsEnabled sets notifications state for a given channel and returns the previous one. If the value is nil, the persisted state is removed.
+func setNotificationsEnabled(state *StartupState, target ConfigChangeTarget, value *ConfigChangeValue) *ConfigChangeValue {
 	if state.Communications == nil {
 		state.Communications = make(map[string]CommunicationsStartupState)
 	}
-	commGroup, exists := state.Communications[commGroupName]
+	commGroup, exists := state.Communications[target.CommGroup]
 	if !exists {
 		commGroup = make(CommunicationsStartupState)
-		state.Communications[commGroupName] = commGroup
-	}
-
-	platformCfg, exists := commGroup[platform]
-	if !exists {
-		platformCfg = BotStartupState{}
-		state.Communications[commGroupName][platform] = platformCfg
+		state.Communications[target.CommGroup] = commGroup
 	}
 
+	platformCfg := commGroup[target.Platform]
 	if platformCfg.Channels == nil {
 		platformCfg.Channels = make(map[string]ChannelStartupState)
-		state.Communications[commGroupName][platform] = platformCfg
 	}
 
-	channel, exists := platformCfg.Channels[channelAlias]
-	if !exists {
-		channel = ChannelStartupState{}
+	var before *ConfigChangeValue
+	channel, exists := platformCfg.Channels[target.Channel]
+	if exists {
+		before = enabledValue(!channel.Notification.Disabled)
 	}
 
-	channel.Notification.Disabled = !enabled
-	state.Communications[commGroupName][platform].Channels[channelAlias] = channel
-
-	err = cmStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
+	switch {
+	case value == nil || value.Enabled == nil:
+		delete(platformCfg.Channels, target.Channel)
+	default:
+		channel.Notification.Disabled = !*value.Enabled
+		platformCfg.Channels[target.Channel] = channel
 	}
-
-	return nil
+	commGroup[target.Platform] = platformCfg
+	return before
 }
 
-// PersistFilterEnabled persists status for a given filter.
-// While this method updates the Botkube ConfigMap, it doesn't reload Botkube itself.
-func (m *PersistenceManager) PersistFilterEnabled(ctx context.Context, name string, enabled bool) error {
-	cmStorage := configMapStorage[StartupState]{k8sCli: m.k8sCli, cfg: m.cfg.Startup}
-
-	state, cm, err := cmStorage.Get(ctx)
-	if err != nil {
-		return err
+// setFilterEnabled sets status for a given filter and returns the previous one.
+func setFilterEnabled(state *StartupState, name string, value *ConfigChangeValue) (*ConfigChangeValue, error) {
+	if value == nil || value.Enabled == nil {
+		return nil, fmt.Errorf("missing status for filter %q", name)
 	}
 
-	err = state.Filters.Kubernetes.SetEnabled(name, enabled)
+	before, err := state.Filters.Kubernetes.IsEnabled(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	err = cmStorage.Update(ctx, cm, state)
-	if err != nil {
-		return err
+	if err := state.Filters.Kubernetes.SetEnabled(name, *value.Enabled); err != nil {
+		return nil, err
 	}
-
-	return nil
+	return enabledValue(before), nil
 }
 
-// PersistActionEnabled updates runtime config map with desired action.enabled parameter
-func (m *PersistenceManager) PersistActionEnabled(ctx context.Context, name string, enabled bool) error {
-	cmStorage := configMapStorage[RuntimeState]{k8sCli: m.k8sCli, cfg: m.cfg.Runtime}
-
-	state, cm, err := cmStorage.Get(ctx)
-	if err != nil {
-		return err
+// setActionEnabled sets status for a given action and returns the previous one.
+func setActionEnabled(state *RuntimeState, name string, value *ConfigChangeValue) (*ConfigChangeValue, error) {
+	if value == nil || value.Enabled == nil {
+		return nil, fmt.Errorf("missing status for action %q", name)
 	}
+
 	if state.Actions == nil {
 		state.Actions = ActionsRuntimeState{}
 	}
-	if err := state.Actions.SetEnabled(name, enabled); err != nil {
-		return err
+	action, found := state.Actions[name]
+	if !found {
+		return nil, fmt.Errorf("action with name %q not found", name)
+	}
+	if err := state.Actions.SetEnabled(name, *value.Enabled); err != nil {
+		return nil, err
 	}
-	return cmStorage.Update(ctx, cm, state)
+	return enabledValue(action.Enabled), nil
 }
@@ -3,19 +3,30 @@ package config_test
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/kubeshop/botkube/internal/loggerx"
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
+func fixTime() time.Time {
+	return time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+}
+
 func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
 	// given
 	commGroupName := "default-group"
@@ -66,6 +77,18 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
                                   sources:
                                     - first
                                     - second
+                      history:
+                        - id: 1
+                          timestamp: 2026-10-19T10:00:00Z
+                          type: sourceBindings
+                          target:
+                            commGroup: default-group
+                            platform: discord
+                            channel: foo
+                          after:
+                            sources:
+                              - first
+                              - second
 					`),
 				},
 			},
@@ -98,6 +121,17 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
                               sources:
                                 - first
                                 - second
+                      history:
+                        - id: 1
+                          timestamp: 2026-10-19T10:00:00Z
+                          type: sourceBindings
+                          target:
+                            commGroup: default-group
+                            platform: teams
+                          after:
+                            sources:
+                              - first
+                              - second
 					`),
 				},
 			},
@@ -153,6 +187,23 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
                                   sources:
                                     - new
                                     - newer
+                      history:
+                        - id: 1
+                          timestamp: 2026-10-19T10:00:00Z
+                          type: sourceBindings
+                          target:
+                            commGroup: default-group
+                            platform: slack
+                            channel: general
+                          before:
+                            sources:
+                              - old
+                              - older
+                              - oldest
+                          after:
+                            sources:
+                              - new
+                              - newer
 					`),
 				},
 			},
@@ -220,6 +271,22 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
                               sources:
                                 - new
                                 - newer
+                      history:
+                        - id: 1
+                          timestamp: 2026-10-19T10:00:00Z
+                          type: sourceBindings
+                          target:
+                            commGroup: default-group
+                            platform: teams
+                          before:
+                            sources:
+                              - old
+                              - older
+                              - oldest
+                          after:
+                            sources:
+                              - new
+                              - newer
 					`),
 				},
 			},
@@ -251,6 +318,7 @@ func TestPersistenceManager_PersistSourceBindings(t *testing.T) {
 		t.Run(testCase.Name, func(t *testing.T) {
 			k8sCli := fake.NewSimpleClientset(testCase.InputCfgMap)
 			manager := config.NewManager(loggerx.NewNoop(), config.PersistentConfig{Runtime: cfg}, k8sCli)
+			manager.SetNow(fixTime)
 
 			// when
 			err := manager.PersistSourceBindings(context.Background(), commGroupName, testCase.InputPlatform, testCase.InputChannel, testCase.InputSourceBindings)
@@ -627,3 +695,163 @@ func TestPersistenceManager_PersistActionEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistenceManager_PersistSourceBindingsRetriesOnConflict(t *testing.T) {
+	// given
+	cfg := config.PartialPersistentConfig{
+		ConfigMap: config.K8sResourceRef{Name: "foo", Namespace: "ns"},
+		FileName:  "_runtime_state.yaml",
+	}
+	k8sCli := newResourceVersionCheckingClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cfg.ConfigMap.Name, Namespace: cfg.ConfigMap.Namespace, ResourceVersion: "1"},
+	})
+
+	// another user edits different channel between our read and write
+	updates := 0
+	k8sCli.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updates++
+		if updates > 1 {
+			return false, nil, nil
+		}
+		concurrent := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: cfg.ConfigMap.Name, Namespace: cfg.ConfigMap.Namespace, ResourceVersion: "2"},
+			Data: map[string]string{
+				cfg.FileName: heredoc.Doc(`
+					communications:
+					  default-group:
+					    slack:
+					      channels:
+					        random:
+					          bindings:
+					            sources: [k8s-err-events]
+				`),
+			},
+		}
+		return false, nil, k8sCli.Tracker().Update(v1.SchemeGroupVersion.WithResource("configmaps"), concurrent, concurrent.Namespace)
+	})
+	manager := config.NewManager(loggerx.NewNoop(), config.PersistentConfig{Runtime: cfg}, k8sCli)
+
+	// when
+	err := manager.PersistSourceBindings(context.Background(), "default-group", config.SlackCommPlatformIntegration, "general", []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates)
+
+	state := getRuntimeState(t, k8sCli, cfg)
+	channels := state.Communications["default-group"][config.SlackCommPlatformIntegration].Channels
+	assert.Equal(t, []string{"k8s-err-events"}, channels["random"].Bindings.Sources)
+	assert.Equal(t, []string{"k8s-events"}, channels["general"].Bindings.Sources)
+	require.Len(t, state.History, 1)
+	assert.Equal(t, "general", state.History[0].Target.Channel)
+}
+
+func TestPersistenceManager_ConcurrentEdits(t *testing.T) {
+	// given
+	cfg := config.PersistentConfig{
+		Startup: config.PartialPersistentConfig{
+			ConfigMap: config.K8sResourceRef{Name: "startup", Namespace: "ns"},
+			FileName:  "_startup_state.yaml",
+		},
+		Runtime: config.PartialPersistentConfig{
+			ConfigMap: config.K8sResourceRef{Name: "runtime", Namespace: "ns"},
+			FileName:  "_runtime_state.yaml",
+		},
+	}
+	k8sCli := newResourceVersionCheckingClientset(
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "startup", Namespace: "ns", ResourceVersion: "1"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "runtime", Namespace: "ns", ResourceVersion: "1"}},
+	)
+	const channelsCount = 5
+
+	// when
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*channelsCount)
+	for i := 0; i < channelsCount; i++ {
+		channel := fmt.Sprintf("channel-%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			manager := config.NewManager(loggerx.NewNoop(), cfg, k8sCli)
+			errs <- manager.PersistSourceBindings(context.Background(), "default-group", config.SlackCommPlatformIntegration, channel, []string{channel + "-events"})
+		}()
+		go func() {
+			defer wg.Done()
+			manager := config.NewManager(loggerx.NewNoop(), cfg, k8sCli)
+			errs <- manager.PersistNotificationsEnabled(context.Background(), "default-group", config.SlackCommPlatformIntegration, channel, false)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	// then
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	runtimeState := getRuntimeState(t, k8sCli, cfg.Runtime)
+	channels := runtimeState.Communications["default-group"][config.SlackCommPlatformIntegration].Channels
+	require.Len(t, channels, channelsCount)
+	for i := 0; i < channelsCount; i++ {
+		channel := fmt.Sprintf("channel-%d", i)
+		assert.Equal(t, []string{channel + "-events"}, channels[channel].Bindings.Sources)
+	}
+
+	// every change is recorded exactly once
+	require.Len(t, runtimeState.History, 2*channelsCount)
+	for idx, change := range runtimeState.History {
+		assert.Equal(t, idx+1, change.ID)
+	}
+
+	cm, err := k8sCli.CoreV1().ConfigMaps("ns").Get(context.Background(), "startup", metav1.GetOptions{})
+	require.NoError(t, err)
+	var startupState config.StartupState
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[cfg.Startup.FileName]), &startupState))
+	startupChannels := startupState.Communications["default-group"][config.SlackCommPlatformIntegration].Channels
+	require.Len(t, startupChannels, channelsCount)
+	for _, channel := range startupChannels {
+		assert.True(t, channel.Notification.Disabled)
+	}
+}
+
+// newResourceVersionCheckingClientset returns a fake clientset which rejects ConfigMap updates with an outdated resourceVersion, same as the API server.
+func newResourceVersionCheckingClientset(objects ...runtime.Object) *fake.Clientset {
+	k8sCli := fake.NewSimpleClientset(objects...)
+	gvr := v1.SchemeGroupVersion.WithResource("configmaps")
+
+	var mu sync.Mutex
+	k8sCli.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		cm := action.(k8stesting.UpdateAction).GetObject().(*v1.ConfigMap).DeepCopy()
+		current, err := k8sCli.Tracker().Get(gvr, cm.Namespace, cm.Name)
+		if err != nil {
+			return true, nil, err
+		}
+
+		currentVersion := current.(*v1.ConfigMap).ResourceVersion
+		if cm.ResourceVersion != currentVersion {
+			return true, nil, apierrors.NewConflict(gvr.GroupResource(), cm.Name, fmt.Errorf("resourceVersion %s is outdated", cm.ResourceVersion))
+		}
+
+		version, err := strconv.Atoi(currentVersion)
+		if err != nil {
+			return true, nil, err
+		}
+		cm.ResourceVersion = strconv.Itoa(version + 1)
+		return true, cm, k8sCli.Tracker().Update(gvr, cm, cm.Namespace)
+	})
+	return k8sCli
+}
+
+func getRuntimeState(t *testing.T, k8sCli *fake.Clientset, cfg config.PartialPersistentConfig) config.RuntimeState {
+	t.Helper()
+
+	cm, err := k8sCli.CoreV1().ConfigMaps(cfg.ConfigMap.Namespace).Get(context.Background(), cfg.ConfigMap.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	var state config.RuntimeState
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[cfg.FileName]), &state))
+	return state
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"regexp"
+)
+
+// RedactedSecretStr is a placeholder for redacted sensitive values.
+const RedactedSecretStr = "*** REDACTED ***"
+
+// sensitivePluginCfgKey matches plugin configuration properties which are likely to hold credentials.
+var sensitivePluginCfgKey = regexp.MustCompile(`(?i)(token|password|secret|apikey|api_key|credentials)`)
+
+// Redacted returns a copy of a given configuration with sensitive data, such as tokens and passwords, redacted.
+// The input configuration is not modified.
+// TODO: avoid printing sensitive data without need to resetting them manually (which is an error-prone approach)
+func Redacted(in Config) Config {
+	out := in
+
+	out.Communications = make(map[string]Communications, len(in.Communications))
+	for key, old := range in.Communications {
+		old.Slack.Token = RedactedSecretStr
+		old.SocketSlack.AppToken = RedactedSecretStr
+		old.SocketSlack.BotToken = RedactedSecretStr
+		old.Elasticsearch.Password = RedactedSecretStr
+		old.Discord.Token = RedactedSecretStr
+		old.Telegram.Token = RedactedSecretStr
+		old.RocketChat.Token = RedactedSecretStr
+		old.RocketChat.WebhookToken = RedactedSecretStr
+		old.GoogleChat.Channels = redactedGoogleChatWebhookURLs(old.GoogleChat.Channels)
+		old.Mattermost.Token = RedactedSecretStr
+		old.Teams.AppPassword = RedactedSecretStr
+		old.Webhook.Secret = RedactedSecretStr
+		old.Webhook.Headers = redactedHeaders(old.Webhook.Headers)
+		// optional sections are redacted only if set, so they are not rendered when not configured
+		old.Kafka.SASL.Password = redactedIfSet(old.Kafka.SASL.Password)
+		old.Kafka.TLS.Key = redactedIfSet(old.Kafka.TLS.Key)
+		old.NATS.Password = redactedIfSet(old.NATS.Password)
+		old.NATS.Token = redactedIfSet(old.NATS.Token)
+		old.NATS.TLS.Key = redactedIfSet(old.NATS.TLS.Key)
+		old.Loki.Password = redactedIfSet(old.Loki.Password)
+		old.Loki.Headers = redactedHeaders(old.Loki.Headers)
+		old.OTLPLogs.Headers = redactedHeaders(old.OTLPLogs.Headers)
+		old.Alertmanager.Password = redactedIfSet(old.Alertmanager.Password)
+		old.Alertmanager.Headers = redactedHeaders(old.Alertmanager.Headers)
+		old.S3.SecretAccessKey = redactedIfSet(old.S3.SecretAccessKey)
+
+		out.Communications[key] = old
+	}
+
+	out.Executors = make(map[string]Executors, len(in.Executors))
+	for key, executor := range in.Executors {
+		executor.Plugins = redactedPlugins(executor.Plugins)
+		out.Executors[key] = executor
+	}
+
+	out.Sources = make(map[string]Sources, len(in.Sources))
+	for key, source := range in.Sources {
+		source.Plugins = redactedPlugins(source.Plugins)
+		out.Sources[key] = source
+	}
+
+	return out
+}
+
+func redactedIfSet(in string) string {
+	if in == "" {
+		return ""
+	}
+	return RedactedSecretStr
+}
+
+// redactedHeaders returns a copy of given headers with redacted values, as they may contain credentials.
+func redactedHeaders(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key := range in {
+		out[key] = RedactedSecretStr
+	}
+	return out
+}
+
+func redactedPlugins(in Plugins) Plugins {
+	if in == nil {
+		return nil
+	}
+	out := make(Plugins, len(in))
+	for key, plugin := range in {
+		plugin.Config = redactedPluginConfig(plugin.Config)
+		out[key] = plugin
+	}
+	return out
+}
+
+// redactedPluginConfig returns a copy of a given plugin configuration with redacted values of properties which are likely to hold credentials.
+func redactedPluginConfig(in any) any {
+	switch typed := in.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, val := range typed {
+			if _, isStr := val.(string); isStr && sensitivePluginCfgKey.MatchString(key) {
+				out[key] = redactedIfSet(val.(string))
+				continue
+			}
+			out[key] = redactedPluginConfig(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for idx, val := range typed {
+			out[idx] = redactedPluginConfig(val)
+		}
+		return out
+	default:
+		return in
+	}
+}
+
+// redactedGoogleChatWebhookURLs returns a copy of given channels with webhook URLs redacted, as they contain the space key and token.
+func redactedGoogleChatWebhookURLs(in IdentifiableMap[GoogleChatChannelBindings]) IdentifiableMap[GoogleChatChannelBindings] {
+	if in == nil {
+		return nil
+	}
+
+	out := make(IdentifiableMap[GoogleChatChannelBindings], len(in))
+	for key, channel := range in {
+		channel.WebhookURL = redactedIfSet(channel.WebhookURL)
+		out[key] = channel
+	}
+	return out
+}
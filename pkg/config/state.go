@@ -4,17 +4,29 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 )
 
+// conflictRetryBackoff defines how the state updates are retried when the ConfigMap was modified concurrently.
+var conflictRetryBackoff = wait.Backoff{
+	Steps:    10,
+	Duration: 10 * time.Millisecond,
+	Factor:   1.5,
+	Jitter:   0.5,
+}
+
 // RuntimeState represents the runtime state.
 type RuntimeState struct {
 	Communications map[string]CommunicationsRuntimeState `yaml:"communications,omitempty"`
 	Actions        ActionsRuntimeState                   `yaml:"actions,omitempty"`
+	History        ConfigHistory                         `yaml:"history,omitempty"`
 }
 
 // ActionsRuntimeState are the actions persisted in runtime state
@@ -144,12 +156,34 @@ func (s *configMapStorage[T]) Get(ctx context.Context) (T, *v1.ConfigMap, error)
 	return state, cm, nil
 }
 
+// Modify reads the latest state, applies a given modification and writes the state back.
+// If the ConfigMap was modified in the meantime, the whole read-modify-write cycle is retried,
+// so the modification is applied on top of the concurrent changes instead of overriding them.
+// The modify function must change only the part of the state it owns, as it may be called multiple times.
+func (s *configMapStorage[T]) Modify(ctx context.Context, modify func(state *T) error) error {
+	return retry.RetryOnConflict(conflictRetryBackoff, func() error {
+		state, cm, err := s.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := modify(&state); err != nil {
+			return err
+		}
+
+		return s.Update(ctx, cm, state)
+	})
+}
+
+// Update writes a given state to the ConfigMap. The update succeeds only if the ConfigMap wasn't modified since originalCM was read,
+// as the resourceVersion of originalCM is sent as the precondition. Otherwise, the Conflict error is returned.
 func (s *configMapStorage[T]) Update(ctx context.Context, originalCM *v1.ConfigMap, state T) error {
 	data, err := state.MarshalToMap(s.cfg)
 	if err != nil {
 		return fmt.Errorf("while marshalling data")
 	}
 
+	// resourceVersion is copied as well, which makes the update conditional
 	cmToUpdate := originalCM.DeepCopy()
 	cmToUpdate.Data = data
 	_, err = s.k8sCli.CoreV1().ConfigMaps(cmToUpdate.Namespace).Update(ctx, cmToUpdate, metav1.UpdateOptions{})
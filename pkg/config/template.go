@@ -0,0 +1,179 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/knadh/koanf"
+	koanfyaml "github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// TemplateMarker marks configuration files which are rendered as Go templates. It must be the first line of the file.
+	TemplateMarker = "# botkube:template"
+	// ProfileEnvKey defines the configuration profile to apply. It takes precedence over the `--profile` flag.
+	ProfileEnvKey = "BOTKUBE_CONFIG_PROFILE"
+	// ClusterNameEnvKey defines the cluster name. It overrides the `settings.clusterName` property.
+	ClusterNameEnvKey = "BOTKUBE_SETTINGS_CLUSTER__NAME"
+
+	profilesKey        = "profiles"
+	clusterNameKey     = "settings.clusterName"
+	templateMissingKey = "missingkey=error"
+)
+
+var configProfileFlag string
+
+// TemplateData holds the data available in configuration templates.
+type TemplateData struct {
+	// ClusterName is taken from the BOTKUBE_SETTINGS_CLUSTER__NAME environment variable.
+	// If not set, it's taken from the configuration files which are not templates.
+	ClusterName string
+	// Profile is the name of the selected configuration profile. It's empty if no profile is selected.
+	Profile string
+}
+
+// RegisterProfileFlag registers the flag which selects the configuration profile.
+func RegisterProfileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&configProfileFlag, "profile", "", "Specify configuration profile, which overlay defined under the `profiles` property is applied.")
+}
+
+// ParseProfileFlag parses only the `--profile` flag from given command-line arguments.
+// Other arguments are ignored, so the remaining flags are not validated and the startup behavior doesn't change.
+func ParseProfileFlag(args []string) error {
+	flags := pflag.NewFlagSet("profile", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	// registered only to not stop parsing with pflag.ErrHelp
+	flags.BoolP("help", "h", false, "")
+	RegisterProfileFlag(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("while parsing profile flag: %w", err)
+	}
+	return nil
+}
+
+// SelectedProfile returns the configuration profile selected with the BOTKUBE_CONFIG_PROFILE environment variable or the `--profile` flag.
+func SelectedProfile() string {
+	if profile := os.Getenv(ProfileEnvKey); profile != "" {
+		return profile
+	}
+	return configProfileFlag
+}
+
+// IsTemplate returns true if a given configuration file starts with the TemplateMarker.
+func IsTemplate(rawCfg []byte) bool {
+	firstLine, _, _ := bytes.Cut(bytes.TrimLeft(rawCfg, " \t\r\n"), []byte("\n"))
+	return strings.TrimSpace(string(firstLine)) == TemplateMarker
+}
+
+// renderTemplates renders configuration files marked with the TemplateMarker. Other files are returned unchanged.
+//
+// Templates can use the TemplateData fields and the slim-sprig functions, e.g.:
+//
+//	settings:
+//	  clusterName: {{ env "CLUSTER_NAME" | default "dev" }}
+//	communications:
+//	  default-group:
+//	    socketSlack:
+//	      channels:
+//	        default:
+//	          name: {{ .ClusterName }}-alerts
+//
+// Expressions evaluated later by Botkube, such as the `{{ .Event }}` variable in action commands, must be escaped in templates,
+// e.g. `{{ "{{ .Event.Name }}" }}`.
+func renderTemplates(configs [][]byte, profile string) ([][]byte, error) {
+	var hasTemplates bool
+	for _, rawCfg := range configs {
+		if IsTemplate(rawCfg) {
+			hasTemplates = true
+			break
+		}
+	}
+	if !hasTemplates {
+		return configs, nil
+	}
+
+	clusterName, err := templateClusterName(configs)
+	if err != nil {
+		return nil, err
+	}
+	data := TemplateData{ClusterName: clusterName, Profile: profile}
+
+	out := make([][]byte, 0, len(configs))
+	for idx, rawCfg := range configs {
+		if !IsTemplate(rawCfg) {
+			out = append(out, rawCfg)
+			continue
+		}
+
+		tpl, err := template.New(fmt.Sprintf("config-%d", idx)).Funcs(sprig.TxtFuncMap()).Option(templateMissingKey).Parse(string(rawCfg))
+		if err != nil {
+			return nil, fmt.Errorf("while parsing configuration template: %w", err)
+		}
+		var buff bytes.Buffer
+		if err := tpl.Execute(&buff, data); err != nil {
+			return nil, fmt.Errorf("while rendering configuration template: %w", err)
+		}
+		out = append(out, buff.Bytes())
+	}
+	return out, nil
+}
+
+// templateClusterName returns the cluster name set with the environment variable, or in the configuration files which are not templates.
+func templateClusterName(configs [][]byte) (string, error) {
+	if name := os.Getenv(ClusterNameEnvKey); name != "" {
+		return name, nil
+	}
+
+	k := koanf.New(configDelimiter)
+	for _, rawCfg := range configs {
+		if IsTemplate(rawCfg) {
+			continue
+		}
+		if err := k.Load(rawbytes.Provider(rawCfg), koanfyaml.Parser()); err != nil {
+			return "", fmt.Errorf("while loading configuration to resolve cluster name: %w", err)
+		}
+	}
+	return k.String(clusterNameKey), nil
+}
+
+// applyProfile merges the overlay of a given profile into a given configuration file and removes all profiles from it.
+// The overlay is merged in the same way as configuration files: maps are merged recursively, while other values, including lists, are replaced.
+// It returns true if the profile is defined in a given configuration file.
+func applyProfile(k *koanf.Koanf, profile string) (bool, error) {
+	if !k.Exists(profilesKey) {
+		return false, nil
+	}
+	profiles, ok := k.Get(profilesKey).(map[string]any)
+	if !ok {
+		return false, errors.New("profiles property must be a map of profile names to configuration overlays")
+	}
+	k.Delete(profilesKey)
+
+	overlay, found := profiles[profile]
+	if profile == "" || !found {
+		return false, nil
+	}
+	if overlay == nil {
+		return true, nil
+	}
+	overlayCfg, ok := overlay.(map[string]any)
+	if !ok {
+		return false, fmt.Errorf("profile %q must be a configuration overlay", profile)
+	}
+
+	if err := k.Load(confmap.Provider(overlayCfg, ""), nil); err != nil {
+		return false, fmt.Errorf("while merging %q profile: %w", profile, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,210 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var templateBaseCfg = heredoc.Doc(`
+	settings:
+	  clusterName: prod-eu
+	sources:
+	  k8s-events:
+	    displayName: Events
+	actions:
+	  describe:
+	    command: kubectl describe {{ .Event.Kind | lower }} {{ .Event.Name }}
+	    bindings:
+	      sources: [k8s-events]
+	communications:
+	  default-group:
+	    slack:
+	      channels:
+	        default:
+	          name: dev-alerts
+	          bindings:
+	            sources: [k8s-events]
+`)
+
+func TestLoadWithDefaultsRendersTemplates(t *testing.T) {
+	// given
+	t.Setenv("TEMPLATE_TEST_KUBECONFIG", "/from/env/kubeconfig")
+	tpl := heredoc.Doc(`
+		# botkube:template
+		settings:
+		  kubeconfig: {{ env "TEMPLATE_TEST_KUBECONFIG" | default "/default/kubeconfig" }}
+		  log:
+		    level: {{ env "TEMPLATE_TEST_UNSET" | default "debug" }}
+		communications:
+		  default-group:
+		    slack:
+		      channels:
+		        default:
+		          name: {{ .ClusterName }}-alerts
+	`)
+
+	// when
+	cfg, _, err := config.LoadWithDefaults([][]byte{[]byte(templateBaseCfg), []byte(tpl)})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "/from/env/kubeconfig", cfg.Settings.Kubeconfig)
+	assert.Equal(t, "debug", cfg.Settings.Log.Level)
+	assert.Equal(t, "prod-eu-alerts", cfg.Communications["default-group"].Slack.Channels["default"].Name)
+	// files without the marker are not rendered
+	assert.Equal(t, "kubectl describe {{ .Event.Kind | lower }} {{ .Event.Name }}", cfg.Actions["describe"].Command)
+}
+
+func TestLoadWithDefaultsTemplateClusterNameFromEnv(t *testing.T) {
+	// given
+	t.Setenv(config.ClusterNameEnvKey, "from-env")
+	tpl := "# botkube:template\nsettings:\n  kubeconfig: /{{ .ClusterName }}/kubeconfig\n"
+
+	// when
+	cfg, _, err := config.LoadWithDefaults([][]byte{[]byte(templateBaseCfg), []byte(tpl)})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "/from-env/kubeconfig", cfg.Settings.Kubeconfig)
+	assert.Equal(t, "from-env", cfg.Settings.ClusterName)
+}
+
+func TestLoadWithDefaultsTemplateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		tpl         string
+		expErrorMsg string
+	}{
+		{
+			name:        "unknown field",
+			tpl:         "# botkube:template\nsettings:\n  clusterName: {{ .Unknown }}\n",
+			expErrorMsg: "while rendering configuration template",
+		},
+		{
+			name:        "invalid syntax",
+			tpl:         "# botkube:template\nsettings:\n  clusterName: {{ .ClusterName \n",
+			expErrorMsg: "while parsing configuration template",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, _, err := config.LoadWithDefaults([][]byte{[]byte(templateBaseCfg), []byte(tc.tpl)})
+
+			// then
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expErrorMsg)
+		})
+	}
+}
+
+func TestLoadWithDefaultsAppliesProfile(t *testing.T) {
+	// given
+	t.Setenv(config.ProfileEnvKey, "prod")
+	base := templateBaseCfg + heredoc.Doc(`
+		profiles:
+		  prod:
+		    settings:
+		      clusterName: prod-us
+		    communications:
+		      default-group:
+		        slack:
+		          channels:
+		            default:
+		              name: prod-alerts
+		  staging:
+		    settings:
+		      clusterName: staging
+	`)
+	overrides := heredoc.Doc(`
+		# botkube:template
+		settings:
+		  kubeconfig: /{{ .Profile }}/kubeconfig
+		profiles:
+		  prod:
+		    settings:
+		      log:
+		        level: warn
+	`)
+
+	// when
+	cfg, _, err := config.LoadWithDefaults([][]byte{[]byte(base), []byte(overrides)})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "prod-us", cfg.Settings.ClusterName)
+	assert.Equal(t, "/prod/kubeconfig", cfg.Settings.Kubeconfig)
+	assert.Equal(t, "warn", cfg.Settings.Log.Level)
+	channel := cfg.Communications["default-group"].Slack.Channels["default"]
+	assert.Equal(t, "prod-alerts", channel.Name)
+	// maps are merged with the overlay
+	assert.Equal(t, []string{"k8s-events"}, channel.Bindings.Sources)
+}
+
+func TestLoadWithDefaultsProfileFromFlag(t *testing.T) {
+	// given
+	require.NoError(t, config.ParseProfileFlag([]string{"--config", "botkube.yaml", "-h", "--profile=staging", "--unknown=true"}))
+	t.Cleanup(func() {
+		require.NoError(t, config.ParseProfileFlag([]string{"--profile="}))
+	})
+
+	base := templateBaseCfg + "profiles:\n  staging:\n    settings:\n      clusterName: staging\n"
+
+	// when
+	cfg, _, err := config.LoadWithDefaults([][]byte{[]byte(base)})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, "staging", config.SelectedProfile())
+	assert.Equal(t, "staging", cfg.Settings.ClusterName)
+}
+
+func TestLoadWithDefaultsProfiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     string
+		cfg         string
+		expErrorMsg string
+	}{
+		{
+			name:    "profiles are ignored if no profile is selected",
+			profile: "",
+			cfg:     templateBaseCfg + "profiles:\n  prod:\n    settings:\n      clusterName: prod-us\n",
+		},
+		{
+			name:        "unknown profile",
+			profile:     "unknown",
+			cfg:         templateBaseCfg + "profiles:\n  prod:\n    settings:\n      clusterName: prod-us\n",
+			expErrorMsg: `profile "unknown" is not defined in any configuration file`,
+		},
+		{
+			name:        "invalid profiles",
+			profile:     "prod",
+			cfg:         templateBaseCfg + "profiles: [prod]\n",
+			expErrorMsg: "profiles property must be a map of profile names to configuration overlays",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			t.Setenv(config.ProfileEnvKey, tc.profile)
+
+			// when
+			cfg, _, err := config.LoadWithDefaults([][]byte{[]byte(tc.cfg)})
+
+			// then
+			if tc.expErrorMsg != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, tc.expErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "prod-eu", cfg.Settings.ClusterName)
+		})
+	}
+}
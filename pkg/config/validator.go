@@ -87,6 +87,10 @@ func ValidateStruct(in any) (ValidateResult, error) {
 	}
 
 	for _, e := range errs {
+		if e.Value() == ValueFromPlaceholder {
+			// referenced value is not known, so it cannot be validated
+			continue
+		}
 		msg := fmt.Errorf("Key: '%s' %s", e.StructNamespace(), e.Translate(trans))
 
 		if _, found := warnsOnlyTags[e.Tag()]; found {
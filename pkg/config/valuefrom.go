@@ -0,0 +1,283 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	valueFromKey        = "valueFrom"
+	secretLookupTimeout = 30 * time.Second
+
+	// ValueFromPlaceholder is set in place of the `valueFrom` references when the configuration is loaded with the WithValueFromPlaceholders option.
+	ValueFromPlaceholder = "VALUE_FROM_PLACEHOLDER"
+)
+
+// ValueFrom describes a reference to a value stored outside the configuration.
+// It can be used in place of any configuration value, e.g. a bot token or a plugin configuration property:
+//
+//	botToken:
+//	  valueFrom:
+//	    secretKeyRef:
+//	      name: botkube-slack
+//	      key: botToken
+//
+// Exactly one reference has to be set.
+type ValueFrom struct {
+	// SecretKeyRef selects a key of a Kubernetes Secret.
+	SecretKeyRef *SecretKeyRef `yaml:"secretKeyRef,omitempty"`
+	// FileRef selects a file, e.g. mounted from a Secret or by a secret store CSI driver.
+	FileRef *FileRef `yaml:"fileRef,omitempty"`
+	// EnvRef selects an environment variable.
+	EnvRef *EnvRef `yaml:"envRef,omitempty"`
+}
+
+// SecretKeyRef selects a key of a Kubernetes Secret.
+type SecretKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+	// Namespace defaults to the Botkube namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// FileRef selects a file. Trailing newlines are trimmed from the file content.
+type FileRef struct {
+	Path string `yaml:"path"`
+}
+
+// EnvRef selects an environment variable.
+type EnvRef struct {
+	Name string `yaml:"name"`
+}
+
+// SecretDataGetter returns data of a given Kubernetes Secret.
+type SecretDataGetter func(ctx context.Context, namespace, name string) (map[string][]byte, error)
+
+// newSecretDataGetter returns the SecretDataGetter used to resolve the secretKeyRef references.
+// The K8s client is created only when the configuration contains such references.
+var newSecretDataGetter = func(kubeconfig string) (SecretDataGetter, error) {
+	restCfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("while loading k8s config: %w", err)
+	}
+	k8sCli, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating K8s clientset: %w", err)
+	}
+
+	return func(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+		secret, err := k8sCli.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return secret.Data, nil
+	}, nil
+}
+
+// HasValueFromRefs returns true if any of the given configuration files contains the `valueFrom` references.
+// Such configuration has to be resolved again to detect changes, e.g. rotated secrets.
+func HasValueFromRefs(configs [][]byte) bool {
+	for _, rawCfg := range configs {
+		if !bytes.Contains(rawCfg, []byte(valueFromKey)) {
+			continue
+		}
+
+		var raw map[string]any
+		if err := yaml.Unmarshal(rawCfg, &raw); err != nil {
+			continue
+		}
+		found := false
+		_, _ = walkValueFromRefs(raw, "", func(string, ValueFrom) (string, error) {
+			found = true
+			return "", nil
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveValueFromRefs returns a new configuration with all `valueFrom` references replaced with the resolved values.
+// If placeholders are requested, the references are only validated and replaced with the ValueFromPlaceholder.
+func resolveValueFromRefs(k *koanf.Koanf, placeholders bool) (*koanf.Koanf, error) {
+	resolver := &valueFromResolver{
+		defaultNamespace: k.String("settings.systemConfigMap.namespace"),
+		kubeconfig:       k.String("settings.kubeconfig"),
+	}
+	resolveFn := resolver.resolve
+	if placeholders {
+		resolveFn = placeholderValueFrom
+	}
+
+	raw := k.Raw()
+	if _, err := walkValueFromRefs(raw, "", resolveFn); err != nil {
+		return nil, err
+	}
+
+	out := koanf.New(configDelimiter)
+	if err := out.Load(confmap.Provider(raw, ""), nil); err != nil {
+		return nil, fmt.Errorf("while loading resolved configuration: %w", err)
+	}
+	return out, nil
+}
+
+// valueFromResolver resolves the `valueFrom` references.
+type valueFromResolver struct {
+	defaultNamespace string
+	kubeconfig       string
+	getSecretData    SecretDataGetter
+}
+
+func (r *valueFromResolver) resolve(path string, ref ValueFrom) (string, error) {
+	if err := ref.validate(path); err != nil {
+		return "", err
+	}
+
+	switch {
+	case ref.SecretKeyRef != nil:
+		return r.resolveSecretKeyRef(*ref.SecretKeyRef)
+	case ref.FileRef != nil:
+		data, err := os.ReadFile(filepath.Clean(ref.FileRef.Path))
+		if err != nil {
+			return "", fmt.Errorf("while reading a file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	default:
+		val, found := os.LookupEnv(ref.EnvRef.Name)
+		if !found {
+			return "", fmt.Errorf("environment variable %q is not set", ref.EnvRef.Name)
+		}
+		return val, nil
+	}
+}
+
+func (r *valueFromResolver) resolveSecretKeyRef(ref SecretKeyRef) (string, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = r.defaultNamespace
+	}
+	if namespace == "" {
+		return "", fmt.Errorf("secretKeyRef namespace is required, as Botkube namespace is not known")
+	}
+
+	if r.getSecretData == nil {
+		getter, err := newSecretDataGetter(r.kubeconfig)
+		if err != nil {
+			return "", err
+		}
+		r.getSecretData = getter
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretLookupTimeout)
+	defer cancel()
+	data, err := r.getSecretData(ctx, namespace, ref.Name)
+	if err != nil {
+		return "", fmt.Errorf("while getting Secret %q: %w", fmt.Sprintf("%s/%s", namespace, ref.Name), err)
+	}
+	val, found := data[ref.Key]
+	if !found {
+		return "", fmt.Errorf("key %q not found in Secret %q", ref.Key, fmt.Sprintf("%s/%s", namespace, ref.Name))
+	}
+	return string(val), nil
+}
+
+// placeholderValueFrom validates a given reference without resolving it.
+func placeholderValueFrom(path string, ref ValueFrom) (string, error) {
+	if err := ref.validate(path); err != nil {
+		return "", err
+	}
+	return ValueFromPlaceholder, nil
+}
+
+func (ref ValueFrom) validate(path string) error {
+	set := 0
+	for _, isSet := range []bool{ref.SecretKeyRef != nil, ref.FileRef != nil, ref.EnvRef != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one of secretKeyRef, fileRef or envRef has to be set for %q", path)
+	}
+
+	switch {
+	case ref.SecretKeyRef != nil && (ref.SecretKeyRef.Name == "" || ref.SecretKeyRef.Key == ""):
+		return fmt.Errorf("secretKeyRef name and key are required")
+	case ref.FileRef != nil && ref.FileRef.Path == "":
+		return fmt.Errorf("fileRef path is required")
+	case ref.EnvRef != nil && ref.EnvRef.Name == "":
+		return fmt.Errorf("envRef name is required")
+	}
+	return nil
+}
+
+type resolveValueFromFn func(path string, ref ValueFrom) (string, error)
+
+// walkValueFromRefs replaces all `valueFrom` references in a given configuration node with the resolved values.
+func walkValueFromRefs(node any, path string, resolveFn resolveValueFromFn) (any, error) {
+	switch typed := node.(type) {
+	case map[string]any:
+		if ref, isRef := typed[valueFromKey]; isRef && len(typed) == 1 {
+			return resolveValueFromNode(ref, path, resolveFn)
+		}
+		for key, val := range typed {
+			resolved, err := walkValueFromRefs(val, joinCfgPath(path, key), resolveFn)
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = resolved
+		}
+	case []any:
+		for idx, val := range typed {
+			resolved, err := walkValueFromRefs(val, fmt.Sprintf("%s[%d]", path, idx), resolveFn)
+			if err != nil {
+				return nil, err
+			}
+			typed[idx] = resolved
+		}
+	}
+
+	return node, nil
+}
+
+func resolveValueFromNode(node any, path string, resolveFn resolveValueFromFn) (string, error) {
+	var ref ValueFrom
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		TagName:     "yaml",
+		Result:      &ref,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := decoder.Decode(node); err != nil {
+		return "", fmt.Errorf("while decoding %q value reference: %w", path, err)
+	}
+
+	val, err := resolveFn(path, ref)
+	if err != nil {
+		return "", fmt.Errorf("while resolving %q value reference: %w", path, err)
+	}
+	return val, nil
+}
+
+func joinCfgPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + configDelimiter + key
+}
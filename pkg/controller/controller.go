@@ -2,8 +2,10 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -62,20 +64,32 @@ type ActionProvider interface {
 type Controller struct {
 	log                   logrus.FieldLogger
 	reporter              AnalyticsReporter
-	startTime             time.Time
-	conf                  *config.Config
-	notifiers             []notifier.Notifier
 	recommFactory         RecommendationFactory
-	filterEngine          filterengine.FilterEngine
 	informersResyncPeriod time.Duration
-	sourcesRouter         *source.Router
-	actionProvider        ActionProvider
 	statusReporter        status.StatusReporter
 
 	dynamicCli dynamic.Interface
-
-	mapper                     meta.RESTMapper
+	mapper     meta.RESTMapper
+
+	// mu guards the properties below, which are replaced when the configuration is reloaded.
+	mu                         sync.RWMutex
+	startTime                  time.Time
+	conf                       *config.Config
+	notifiers                  []notifier.Notifier
+	filterEngine               filterengine.FilterEngine
+	sourcesRouter              *source.Router
+	actionProvider             ActionProvider
 	dynamicKubeInformerFactory dynamicinformer.DynamicSharedInformerFactory
+	stopInformers              context.CancelFunc
+}
+
+// ReloadInput contains the components rebuilt for a reloaded configuration.
+type ReloadInput struct {
+	Conf           *config.Config
+	Notifiers      []notifier.Notifier
+	FilterEngine   filterengine.FilterEngine
+	Router         *source.Router
+	ActionProvider ActionProvider
 }
 
 // New create a new Controller instance.
@@ -111,7 +125,95 @@ func New(log logrus.FieldLogger,
 // Start creates new informer controllers to watch k8s resources
 func (c *Controller) Start(ctx context.Context) error {
 	c.log.Info("Starting controller...")
+
+	c.mu.Lock()
+	informersCtx, err := c.registerInformers(ctx)
+	notifiers, conf := c.notifiers, c.conf
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c.log.Info("Sending welcome message...")
+	err = notifier.SendPlaintextMessage(ctx, notifiers, fmt.Sprintf(controllerStartMsg, conf.Settings.ClusterName))
+	if err != nil {
+		return fmt.Errorf("while sending first message: %w", err)
+	}
+
+	c.mu.Lock()
+	c.startTime = time.Now()
+	c.dynamicKubeInformerFactory.Start(informersCtx.Done())
+	c.mu.Unlock()
+
+	<-ctx.Done()
+
+	c.mu.RLock()
+	notifiers, conf = c.notifiers, c.conf
+	c.stopInformers()
+	c.mu.RUnlock()
+
+	c.log.Info("Shutdown requested. Flushing buffered events and sending final message...")
+	finalMsgCtx, cancelFn := context.WithTimeout(context.Background(), finalMessageTimeout)
+	defer cancelFn()
+	if err := notifier.Flush(finalMsgCtx, notifiers); err != nil {
+		// don't return, as the final message should be sent anyway
+		c.log.Errorf("while flushing buffered events: %s", err.Error())
+	}
+	err = notifier.SendPlaintextMessage(finalMsgCtx, notifiers, fmt.Sprintf(controllerStopMsg, conf.Settings.ClusterName))
+	if err != nil {
+		return fmt.Errorf("while sending final message: %w", err)
+	}
+
+	// use separate ctx as parent ctx is already cancelled
+	ctxTimeout, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	if _, err := c.statusReporter.ReportDeploymentShutdown(ctxTimeout); err != nil {
+		return fmt.Errorf("while reporting botkube shutdown: %w", err)
+	}
+
+	return nil
+}
+
+// Reload replaces the configuration, notifiers, filters, routing table and actions of a running controller.
+// Informers are recreated for the new routing table. The welcome and final messages are not sent.
+func (c *Controller) Reload(ctx context.Context, in ReloadInput) error {
+	c.log.Info("Reloading controller...")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopInformers == nil {
+		return errors.New("controller is not started")
+	}
+	stoppedAt := time.Now()
+	c.stopInformers()
+
+	c.conf = in.Conf
+	c.notifiers = in.Notifiers
+	c.filterEngine = in.FilterEngine
+	c.sourcesRouter = in.Router
+	c.actionProvider = in.ActionProvider
+
+	informersCtx, err := c.registerInformers(ctx)
+	if err != nil {
+		return err
+	}
+
+	// New informers list all watched objects again, so skip events for objects created before the old informers were stopped.
+	// Objects created in the meantime are still reported. However, updates and deletions which happened before
+	// the new informers are synced are not, as the new informers see only the current state of the objects.
+	c.startTime = stoppedAt
+	c.dynamicKubeInformerFactory.Start(informersCtx.Done())
+	return nil
+}
+
+// registerInformers creates a new informer factory and registers the informers for the current routing table.
+// It returns the context which is cancelled when the informers are stopped. It must be called with the lock held.
+func (c *Controller) registerInformers(ctx context.Context) (context.Context, error) {
+	informersCtx, cancel := context.WithCancel(ctx)
+	c.stopInformers = cancel
 	c.dynamicKubeInformerFactory = dynamicinformer.NewDynamicSharedInformerFactory(c.dynamicCli, c.informersResyncPeriod)
+	informerFactory := c.dynamicKubeInformerFactory
 
 	err := c.sourcesRouter.RegisterInformers([]config.EventType{
 		config.CreateEvent,
@@ -123,7 +225,7 @@ func (c *Controller) Start(ctx context.Context) error {
 			c.log.Infof("Unable to parse resource: %s to register with informer\n", resource)
 			return nil, err
 		}
-		return c.dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
+		return informerFactory.ForResource(gvr).Informer(), nil
 	})
 	if err != nil {
 		c.log.WithFields(logrus.Fields{
@@ -134,7 +236,7 @@ func (c *Controller) Start(ctx context.Context) error {
 			},
 			"error": err.Error(),
 		}).Errorf("Could not register informer.")
-		return err
+		return nil, err
 	}
 
 	err = c.sourcesRouter.MapWithEventsInformer(
@@ -146,7 +248,7 @@ func (c *Controller) Start(ctx context.Context) error {
 				c.log.Infof("Unable to parse resource: %s to register with informer\n", resource)
 				return nil, err
 			}
-			return c.dynamicKubeInformerFactory.ForResource(gvr).Informer(), nil
+			return informerFactory.ForResource(gvr).Informer(), nil
 		})
 	if err != nil {
 		c.log.WithFields(logrus.Fields{
@@ -154,7 +256,7 @@ func (c *Controller) Start(ctx context.Context) error {
 			"dstEvent": config.WarningEvent,
 			"error":    err.Error(),
 		}).Errorf("Could not map event with events informer.")
-		return err
+		return nil, err
 	}
 
 	eventTypes := []config.EventType{
@@ -176,48 +278,25 @@ func (c *Controller) Start(ctx context.Context) error {
 		c.handleEvent,
 	)
 
-	c.log.Info("Sending welcome message...")
-	err = notifier.SendPlaintextMessage(ctx, c.notifiers, fmt.Sprintf(controllerStartMsg, c.conf.Settings.ClusterName))
-	if err != nil {
-		return fmt.Errorf("while sending first message: %w", err)
-	}
-
-	c.startTime = time.Now()
-
-	stopCh := ctx.Done()
-	c.dynamicKubeInformerFactory.Start(stopCh)
-
-	<-stopCh
-
-	c.log.Info("Shutdown requested. Sending final message...")
-	finalMsgCtx, cancelFn := context.WithTimeout(context.Background(), finalMessageTimeout)
-	defer cancelFn()
-	err = notifier.SendPlaintextMessage(finalMsgCtx, c.notifiers, fmt.Sprintf(controllerStopMsg, c.conf.Settings.ClusterName))
-	if err != nil {
-		return fmt.Errorf("while sending final message: %w", err)
-	}
-
-	// use separate ctx as parent ctx is already cancelled
-	ctxTimeout, cancel := context.WithTimeout(context.Background(), time.Second*10)
-	defer cancel()
-	if _, err := c.statusReporter.ReportDeploymentShutdown(ctxTimeout); err != nil {
-		return fmt.Errorf("while reporting botkube shutdown: %w", err)
-	}
-
-	return nil
+	return informersCtx, nil
 }
 
 func (c *Controller) handleEvent(ctx context.Context, event event.Event, sources, updateDiffs []string) {
 	c.log.Debugf("Processing %s to %s/%v in %s namespace", event.Type, event.Resource, event.Name, event.Namespace)
-	c.enrichEventWithAdditionalMetadata(&event)
+
+	c.mu.RLock()
+	conf, notifiers, filterEngine, actionProvider, startTime := c.conf, c.notifiers, c.filterEngine, c.actionProvider, c.startTime
+	c.mu.RUnlock()
+
+	event.Cluster = conf.Settings.ClusterName
 
 	// Skip older events
-	if !event.TimeStamp.IsZero() && event.TimeStamp.Before(c.startTime) {
+	if !event.TimeStamp.IsZero() && event.TimeStamp.Before(startTime) {
 		c.log.Debug("Skipping older events")
 		return
 	}
 
-	actions, err := c.actionProvider.RenderedActionsForEvent(event, sources)
+	actions, err := actionProvider.RenderedActionsForEvent(event, sources)
 	if err != nil {
 		c.log.Errorf("while getting rendered actions for event: %s", err.Error())
 		// continue processing event
@@ -239,7 +318,7 @@ func (c *Controller) handleEvent(ctx context.Context, event event.Event, sources
 	}
 
 	// Filter events
-	event = c.filterEngine.Run(ctx, event)
+	event = filterEngine.Run(ctx, event)
 	if event.Skip {
 		c.log.Debugf("Skipping event: %#v", event)
 		return
@@ -250,20 +329,20 @@ func (c *Controller) handleEvent(ctx context.Context, event event.Event, sources
 		return
 	}
 
-	recRunner, recCfg := c.recommFactory.NewForSources(c.conf.Sources, sources)
+	recRunner, recCfg := c.recommFactory.NewForSources(conf.Sources, sources)
 	err = recRunner.Do(ctx, &event)
 	if err != nil {
 		c.log.Errorf("while running recommendations: %w", err)
 	}
 
-	if recommendation.ShouldIgnoreEvent(recCfg, c.conf.Sources, sources, event) {
+	if recommendation.ShouldIgnoreEvent(recCfg, conf.Sources, sources, event) {
 		c.log.Debugf("Skipping event as it is related to recommendation informers and doesn't have any recommendations: %#v", event)
 		return
 	}
 
 	// Send event over notifiers
 	anonymousEvent := analytics.AnonymizedEventDetailsFrom(event)
-	for _, n := range c.notifiers {
+	for _, n := range notifiers {
 		go func(n notifier.Notifier) {
 			defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
 
@@ -287,8 +366,8 @@ func (c *Controller) handleEvent(ctx context.Context, event event.Event, sources
 	// execute actions
 	for _, action := range event.Actions {
 		c.log.Infof("Executing action %q (command: %q)...", action.DisplayName, action.Command)
-		genericMsg := c.actionProvider.ExecuteEventAction(ctx, action)
-		for _, n := range c.notifiers {
+		genericMsg := actionProvider.ExecuteEventAction(ctx, action)
+		for _, n := range notifiers {
 			go func(n notifier.Notifier) {
 				defer analytics.ReportPanicIfOccurs(c.log, c.reporter)
 				err := n.SendMessage(ctx, genericMsg, sources)
@@ -325,7 +404,3 @@ func (c *Controller) strToGVR(arg string) (schema.GroupVersionResource, error) {
 		return schema.GroupVersionResource{}, fmt.Errorf("invalid string: expected 2 or 3 parts when split by %q", separator)
 	}
 }
-
-func (c *Controller) enrichEventWithAdditionalMetadata(event *event.Event) {
-	event.Cluster = c.conf.Settings.ClusterName
-}
@@ -37,6 +37,9 @@ type Event struct {
 	Recommendations []string
 	Warnings        []string
 	Actions         []Action
+	// Labels and Annotations are copied from the ObjectMeta only by sinks which are configured to send them.
+	Labels      map[string]string `json:",omitempty"`
+	Annotations map[string]string `json:",omitempty"`
 
 	// The following fields are ignored when marshalling the event by purpose.
 	// We send the whole Event struct via sink.Elasticsearch integration.
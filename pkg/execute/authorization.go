@@ -0,0 +1,135 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const notAuthorizedMsgFmt = "Sorry, you are not authorized to run this command on the '%s' cluster. Ask your Botkube administrator for access."
+
+// UserInfo holds details about the user who sent a given command.
+type UserInfo struct {
+	ID     string
+	Email  string
+	Groups []string
+}
+
+// UserInfoOptions holds options for getting user details.
+type UserInfoOptions struct {
+	// WithGroups is true if user groups are needed. Resolving groups may require additional, expensive API calls.
+	WithGroups bool
+}
+
+// UserInfoProvider provides details about a given user. It is used to check the executor bindings authorization.
+type UserInfoProvider interface {
+	GetUserInfo(ctx context.Context, userID string, opts UserInfoOptions) (UserInfo, error)
+}
+
+// UserInfoProviderFunc is an adapter to allow the use of ordinary functions as UserInfoProvider.
+type UserInfoProviderFunc func(ctx context.Context, userID string, opts UserInfoOptions) (UserInfo, error)
+
+// GetUserInfo calls f(ctx, userID, opts).
+func (f UserInfoProviderFunc) GetUserInfo(ctx context.Context, userID string, opts UserInfoOptions) (UserInfo, error) {
+	return f(ctx, userID, opts)
+}
+
+// bindingsAuthorizer filters executor bindings based on the user and groups allowlists.
+type bindingsAuthorizer struct {
+	executors map[string]config.Executors
+	userID    string
+	provider  UserInfoProvider
+
+	resolved *UserInfo
+}
+
+// Authorize returns executor bindings that the user is allowed to use and the denied ones.
+// The user details are fetched only for bindings which don't allow the user ID directly. If fetching them fails,
+// such bindings are denied and the error is returned together with the result, so the remaining bindings can be still used.
+func (a *bindingsAuthorizer) Authorize(ctx context.Context, bindings []string) (allowed []string, denied []string, err error) {
+	opts := UserInfoOptions{WithGroups: groupsInBindings(a.executors, bindings)}
+	for _, name := range bindings {
+		executor, found := a.executors[name]
+		if !found || !executor.Authorization.IsEnabled() {
+			allowed = append(allowed, name)
+			continue
+		}
+
+		if isUserAuthorized(executor.Authorization, UserInfo{ID: a.userID}) {
+			allowed = append(allowed, name)
+			continue
+		}
+
+		user, userErr := a.userInfo(ctx, opts)
+		if userErr != nil {
+			err = userErr
+			denied = append(denied, name)
+			continue
+		}
+
+		if !isUserAuthorized(executor.Authorization, user) {
+			denied = append(denied, name)
+			continue
+		}
+		allowed = append(allowed, name)
+	}
+
+	return allowed, denied, err
+}
+
+func (a *bindingsAuthorizer) userInfo(ctx context.Context, opts UserInfoOptions) (UserInfo, error) {
+	if a.resolved != nil {
+		return *a.resolved, nil
+	}
+
+	user := UserInfo{ID: a.userID}
+	if a.provider != nil && a.userID != "" {
+		var err error
+		user, err = a.provider.GetUserInfo(ctx, a.userID, opts)
+		if err != nil {
+			return UserInfo{}, fmt.Errorf("while getting user %q details: %w", a.userID, err)
+		}
+		if user.ID == "" {
+			user.ID = a.userID
+		}
+	}
+
+	a.resolved = &user
+	return user, nil
+}
+
+func isUserAuthorized(authz config.ExecutorAuthorization, user UserInfo) bool {
+	for _, allowed := range authz.Users {
+		if user.ID != "" && allowed == user.ID {
+			return true
+		}
+		if user.Email != "" && strings.EqualFold(allowed, user.Email) {
+			return true
+		}
+	}
+
+	return sliceutil.Intersect(authz.Groups, user.Groups)
+}
+
+// groupsInBindings returns true if authorization of any of a given executor bindings is based on user groups.
+func groupsInBindings(executors map[string]config.Executors, bindings []string) bool {
+	for _, name := range bindings {
+		if len(executors[name].Authorization.Groups) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// kubectlEnabledInBindings returns true if kubectl is enabled in any of a given executor bindings.
+func kubectlEnabledInBindings(executors map[string]config.Executors, bindings []string) bool {
+	for _, name := range bindings {
+		if executors[name].Kubectl.Enabled {
+			return true
+		}
+	}
+	return false
+}
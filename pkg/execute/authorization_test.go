@@ -0,0 +1,162 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestBindingsAuthorizer_Authorize(t *testing.T) {
+	// given
+	executors := map[string]config.Executors{
+		"kubectl-read-only": {
+			Kubectl: config.Kubectl{Enabled: true},
+		},
+		"kubectl-all": {
+			Kubectl: config.Kubectl{Enabled: true},
+			Authorization: config.ExecutorAuthorization{
+				Users:  []string{"U123", "admin@example.com"},
+				Groups: []string{"sre"},
+			},
+		},
+	}
+	bindings := []string{"kubectl-read-only", "kubectl-all"}
+
+	testCases := []struct {
+		Name            string
+		UserID          string
+		Provider        UserInfoProvider
+		ExpectedAllowed []string
+		ExpectedDenied  []string
+	}{
+		{
+			Name:            "Allowed by ID",
+			UserID:          "U123",
+			ExpectedAllowed: bindings,
+		},
+		{
+			Name:   "Allowed by email",
+			UserID: "U456",
+			Provider: UserInfoProviderFunc(func(context.Context, string, UserInfoOptions) (UserInfo, error) {
+				return UserInfo{Email: "Admin@example.com"}, nil
+			}),
+			ExpectedAllowed: bindings,
+		},
+		{
+			Name:   "Allowed by group",
+			UserID: "U456",
+			Provider: UserInfoProviderFunc(func(context.Context, string, UserInfoOptions) (UserInfo, error) {
+				return UserInfo{Groups: []string{"dev", "SRE"}}, nil
+			}),
+			ExpectedAllowed: bindings,
+		},
+		{
+			Name:            "Denied",
+			UserID:          "U456",
+			ExpectedAllowed: []string{"kubectl-read-only"},
+			ExpectedDenied:  []string{"kubectl-all"},
+		},
+		{
+			Name:            "Denied for unknown user",
+			ExpectedAllowed: []string{"kubectl-read-only"},
+			ExpectedDenied:  []string{"kubectl-all"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			authorizer := &bindingsAuthorizer{executors: executors, userID: tc.UserID, provider: tc.Provider}
+
+			// when
+			allowed, denied, err := authorizer.Authorize(context.Background(), bindings)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedAllowed, allowed)
+			assert.Equal(t, tc.ExpectedDenied, denied)
+		})
+	}
+}
+
+func TestBindingsAuthorizer_AuthorizeProviderError(t *testing.T) {
+	// given
+	executors := map[string]config.Executors{
+		"kubectl-all": {
+			Authorization: config.ExecutorAuthorization{Users: []string{"U123"}},
+		},
+		"kubectl-admins": {
+			Authorization: config.ExecutorAuthorization{Users: []string{"admin@example.com"}},
+		},
+		"kubectl-read-only": {},
+	}
+	authorizer := &bindingsAuthorizer{
+		executors: executors,
+		userID:    "U123",
+		provider: UserInfoProviderFunc(func(context.Context, string, UserInfoOptions) (UserInfo, error) {
+			return UserInfo{}, errors.New("fix err")
+		}),
+	}
+
+	// when
+	allowed, denied, err := authorizer.Authorize(context.Background(), []string{"kubectl-all", "kubectl-admins", "kubectl-read-only"})
+
+	// then
+	assert.EqualError(t, err, `while getting user "U123" details: fix err`)
+	assert.Equal(t, []string{"kubectl-all", "kubectl-read-only"}, allowed)
+	assert.Equal(t, []string{"kubectl-admins"}, denied)
+}
+
+func TestBindingsAuthorizer_AuthorizeRequestsGroupsOnlyIfNeeded(t *testing.T) {
+	// given
+	executors := map[string]config.Executors{
+		"kubectl-users": {
+			Authorization: config.ExecutorAuthorization{Users: []string{"admin@example.com"}},
+		},
+		"kubectl-groups": {
+			Authorization: config.ExecutorAuthorization{Groups: []string{"sre"}},
+		},
+	}
+
+	testCases := []struct {
+		Name               string
+		Bindings           []string
+		ExpectedWithGroups bool
+	}{
+		{
+			Name:               "Users only",
+			Bindings:           []string{"kubectl-users"},
+			ExpectedWithGroups: false,
+		},
+		{
+			Name:               "Users and groups",
+			Bindings:           []string{"kubectl-users", "kubectl-groups"},
+			ExpectedWithGroups: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var gotOpts []UserInfoOptions
+			authorizer := &bindingsAuthorizer{
+				executors: executors,
+				userID:    "U456",
+				provider: UserInfoProviderFunc(func(_ context.Context, _ string, opts UserInfoOptions) (UserInfo, error) {
+					gotOpts = append(gotOpts, opts)
+					return UserInfo{}, nil
+				}),
+			}
+
+			// when
+			_, _, err := authorizer.Authorize(context.Background(), tc.Bindings)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, []UserInfoOptions{{WithGroups: tc.ExpectedWithGroups}}, gotOpts)
+		})
+	}
+}
@@ -14,6 +14,7 @@ const (
 	EditVerb     Verb = "edit"
 	StatusVerb   Verb = "status"
 	ShowVerb     Verb = "show"
+	RollbackVerb Verb = "rollback"
 )
 
 func AllVerbs() []Verb {
@@ -28,5 +29,6 @@ func AllVerbs() []Verb {
 		EditVerb,
 		StatusVerb,
 		ShowVerb,
+		RollbackVerb,
 	}
 }
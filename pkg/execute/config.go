@@ -1,17 +1,33 @@
 package execute
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 
+	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 )
 
+const (
+	configHistoryArg           = "history"
+	configHistoryEmptyMsg      = "There are no recorded configuration changes."
+	configChangeIDMissingMsg   = "You forgot to pass the change ID. Run `%s show config history` to see the recorded changes."
+	configChangeNotFoundMsgFmt = "Configuration change %q not found. Run `%s show config history` to see the recorded changes."
+	configChangeNotAllowedFmt  = "Configuration change %d belongs to a different channel. Run `%s rollback config %d` in the changed channel."
+	configRollbackMsgFmt       = "Done. I restored %s to %s (change %d) on '%s' cluster. It will be applied once the configuration is reloaded."
+)
+
 var (
 	configFeatureName = FeatureName{
 		Name:    "config",
@@ -23,17 +39,25 @@ var (
 type ConfigExecutor struct {
 	log               logrus.FieldLogger
 	analyticsReporter AnalyticsReporter
+	cfgManager        ConfigPersistenceManager
 
 	// Used for deprecated showControllerConfig function.
-	cfg config.Config
+	cfg            config.Config
+	configRevision ConfigRevisionGetter
 }
 
-// NewConfigExecutor returns a new ConfigExecutor instance
-func NewConfigExecutor(log logrus.FieldLogger, analyticsReporter AnalyticsReporter, config config.Config) *ConfigExecutor {
+// ConfigRevisionGetter returns the revision of the applied configuration, such as a Git commit SHA.
+// Empty string means that the revision is unknown.
+type ConfigRevisionGetter func() string
+
+// NewConfigExecutor returns a new ConfigExecutor instance. The configRevision is optional.
+func NewConfigExecutor(log logrus.FieldLogger, analyticsReporter AnalyticsReporter, cfgManager ConfigPersistenceManager, config config.Config, configRevision ConfigRevisionGetter) *ConfigExecutor {
 	return &ConfigExecutor{
 		log:               log,
 		analyticsReporter: analyticsReporter,
+		cfgManager:        cfgManager,
 		cfg:               config,
+		configRevision:    configRevision,
 	}
 }
 
@@ -45,15 +69,27 @@ func (e *ConfigExecutor) FeatureName() FeatureName {
 // Commands returns slice of commands the executor supports
 func (e *ConfigExecutor) Commands() map[command.Verb]CommandFn {
 	return map[command.Verb]CommandFn{
-		command.ShowVerb: e.Show,
+		command.ShowVerb:     e.Show,
+		command.RollbackVerb: e.Rollback,
 	}
 }
 
-// Show returns Config in yaml format
-func (e *ConfigExecutor) Show(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+// Show returns Config in yaml format, or the history of the runtime configuration changes
+func (e *ConfigExecutor) Show(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
 	cmdVerb, cmdRes := parseCmdVerb(cmdCtx.Args)
 	defer e.reportCommand(cmdVerb, cmdRes, cmdCtx.Conversation.CommandOrigin, cmdCtx.Platform)
 
+	if len(cmdCtx.Args) > 2 && strings.EqualFold(cmdCtx.Args[2], configHistoryArg) {
+		history, err := e.cfgManager.ListConfigChanges(ctx)
+		if err != nil {
+			return interactive.CoreMessage{}, fmt.Errorf("while listing configuration changes: %w", err)
+		}
+		if len(history) == 0 {
+			return respond(configHistoryEmptyMsg, cmdCtx), nil
+		}
+		return respond(e.renderHistory(history), cmdCtx), nil
+	}
+
 	cfg, err := e.renderBotkubeConfiguration()
 	if err != nil {
 		return interactive.CoreMessage{}, fmt.Errorf("while rendering Botkube configuration: %w", err)
@@ -61,6 +97,63 @@ func (e *ConfigExecutor) Show(_ context.Context, cmdCtx CommandContext) (interac
 	return respond(cfg, cmdCtx), nil
 }
 
+// Rollback restores the value replaced by a given runtime configuration change
+func (e *ConfigExecutor) Rollback(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	cmdVerb, cmdRes := parseCmdVerb(cmdCtx.Args)
+	defer e.reportCommand(cmdVerb, cmdRes, cmdCtx.Conversation.CommandOrigin, cmdCtx.Platform)
+
+	if len(cmdCtx.Args) < 3 {
+		return respond(fmt.Sprintf(configChangeIDMissingMsg, api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	id, err := strconv.Atoi(cmdCtx.Args[2])
+	if err != nil {
+		return respond(fmt.Sprintf(configChangeNotFoundMsgFmt, cmdCtx.Args[2], api.MessageBotNamePlaceholder), cmdCtx), nil
+	}
+
+	channel := config.ConfigChangeTarget{
+		CommGroup: cmdCtx.CommGroupName,
+		Platform:  cmdCtx.Platform,
+		Channel:   cmdCtx.Conversation.Alias,
+	}
+	change, err := e.cfgManager.RollbackConfigChange(ctx, id, channel)
+	switch {
+	case err == nil:
+	case errors.Is(err, config.ErrConfigChangeNotFound):
+		return respond(fmt.Sprintf(configChangeNotFoundMsgFmt, cmdCtx.Args[2], api.MessageBotNamePlaceholder), cmdCtx), nil
+	case errors.Is(err, config.ErrConfigChangeNotAllowed):
+		return respond(fmt.Sprintf(configChangeNotAllowedFmt, id, api.MessageBotNamePlaceholder, id), cmdCtx), nil
+	default:
+		return interactive.CoreMessage{}, fmt.Errorf("while rolling back configuration change %d: %w", id, err)
+	}
+
+	return respond(fmt.Sprintf(configRollbackMsgFmt, change.Subject(), change.After.String(), change.ID, cmdCtx.ClusterName), cmdCtx), nil
+}
+
+func (e *ConfigExecutor) renderHistory(history config.ConfigHistory) string {
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "ID\tTIMESTAMP\tUSER\tPLATFORM\tCHANGE\tBEFORE\tAFTER")
+	// show the newest changes first
+	for i := len(history) - 1; i >= 0; i-- {
+		change := history[i]
+		subject := change.Subject()
+		if change.RollbackOf != 0 {
+			subject = fmt.Sprintf("%s (rollback of %d)", subject, change.RollbackOf)
+		}
+		fmt.Fprintf(w, "\n%d\t%s\t%s\t%s\t%s\t%s\t%s", change.ID, change.Timestamp.Format(time.RFC3339), valueOrDash(change.User), valueOrDash(string(change.Platform)), subject, change.Before.String(), change.After.String())
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func valueOrDash(in string) string {
+	if in == "" {
+		return "-"
+	}
+	return in
+}
+
 func (e *ConfigExecutor) reportCommand(cmdVerb, cmdRes string, commandOrigin command.Origin, platform config.CommPlatformIntegration) {
 	cmdToReport := fmt.Sprintf("%s %s", cmdVerb, cmdRes)
 	err := e.analyticsReporter.ReportCommand(platform, cmdToReport, commandOrigin, false)
@@ -69,30 +162,20 @@ func (e *ConfigExecutor) reportCommand(cmdVerb, cmdRes string, commandOrigin com
 	}
 }
 
-const redactedSecretStr = "*** REDACTED ***"
-
 func (e *ConfigExecutor) renderBotkubeConfiguration() (string, error) {
-	cfg := e.cfg
-
 	// hide sensitive info
-	// TODO: avoid printing sensitive data without need to resetting them manually (which is an error-prone approach)
-	for key, old := range cfg.Communications {
-		old.Slack.Token = redactedSecretStr
-		old.SocketSlack.AppToken = redactedSecretStr
-		old.SocketSlack.BotToken = redactedSecretStr
-		old.Elasticsearch.Password = redactedSecretStr
-		old.Discord.Token = redactedSecretStr
-		old.Mattermost.Token = redactedSecretStr
-		old.Teams.AppPassword = redactedSecretStr
-
-		// maps are not addressable: https://stackoverflow.com/questions/42605337/cannot-assign-to-struct-field-in-a-map
-		cfg.Communications[key] = old
-	}
+	cfg := config.Redacted(e.cfg)
 
 	b, err := yaml.Marshal(cfg)
 	if err != nil {
 		return "", err
 	}
 
+	if e.configRevision != nil {
+		if rev := e.configRevision(); rev != "" {
+			return fmt.Sprintf("# Applied configuration revision: %s\n%s", rev, b), nil
+		}
+	}
+
 	return string(b), nil
 }
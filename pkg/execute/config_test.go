@@ -2,7 +2,9 @@ package execute
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,7 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 		Name           string
 		CmdCtx         CommandContext
 		Cfg            config.Config
+		Revision       ConfigRevisionGetter
 		ExpectedResult string
 	}{
 		{
@@ -80,13 +83,153 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 						    repositories: {}
 						`),
 		},
+		{
+			Name: "Print config with revision",
+			CmdCtx: CommandContext{
+				Args:           []string{"config"},
+				Conversation:   Conversation{Alias: channelAlias, ID: "conv-id"},
+				Platform:       config.SlackCommPlatformIntegration,
+				ClusterName:    configTestClusterName,
+				ExecutorFilter: newExecutorTextFilter("revision"),
+			},
+			Cfg: config.Config{
+				Settings: config.Settings{
+					ClusterName: configTestClusterName,
+				},
+			},
+			Revision: func() string {
+				return "4b9b302aa1c5b1b3f3c5b0a8e4c1f2d3e4f5a6b7"
+			},
+			ExpectedResult: "# Applied configuration revision: 4b9b302aa1c5b1b3f3c5b0a8e4c1f2d3e4f5a6b7",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			e := NewConfigExecutor(loggerx.NewNoop(), &fakeAnalyticsReporter{}, tc.Cfg)
+			e := NewConfigExecutor(loggerx.NewNoop(), &fakeAnalyticsReporter{}, &fakeCfgPersistenceManager{}, tc.Cfg, tc.Revision)
 			msg, err := e.Show(context.Background(), tc.CmdCtx)
 			require.NoError(t, err)
 			assert.Equal(t, tc.ExpectedResult, msg.BaseBody.CodeBlock)
 		})
 	}
 }
+
+func TestConfigExecutorShowHistory(t *testing.T) {
+	// given
+	enabled, disabled := true, false
+	manager := &fakeConfigHistoryManager{
+		history: config.ConfigHistory{
+			{
+				ID:        1,
+				Timestamp: time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC),
+				User:      "Alice",
+				Platform:  config.SocketSlackCommPlatformIntegration,
+				Type:      config.SourceBindingsConfigChange,
+				Target:    config.ConfigChangeTarget{CommGroup: "default-group", Platform: config.SocketSlackCommPlatformIntegration, Channel: "general"},
+				After:     &config.ConfigChangeValue{Sources: []string{"k8s-events", "k8s-err-events"}},
+			},
+			{
+				ID:         2,
+				Timestamp:  time.Date(2026, time.October, 19, 11, 0, 0, 0, time.UTC),
+				Type:       config.ActionConfigChange,
+				Target:     config.ConfigChangeTarget{Name: "describe-pod"},
+				Before:     &config.ConfigChangeValue{Enabled: &enabled},
+				After:      &config.ConfigChangeValue{Enabled: &disabled},
+				RollbackOf: 1,
+			},
+		},
+	}
+	e := NewConfigExecutor(loggerx.NewNoop(), &fakeAnalyticsReporter{}, manager, config.Config{}, nil)
+	cmdCtx := CommandContext{
+		Args:           []string{"show", "config", "history"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	}
+
+	// when
+	msg, err := e.Show(context.Background(), cmdCtx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		ID   TIMESTAMP            USER  PLATFORM    CHANGE                                                                                  BEFORE    AFTER
+		2    2026-10-19T11:00:00Z -     -           action "describe-pod" (rollback of 1)                                                   enabled   disabled
+		1    2026-10-19T10:00:00Z Alice socketSlack source bindings of socketSlack channel "general" in "default-group" communication group <not set> [k8s-events, k8s-err-events]`), msg.BaseBody.CodeBlock)
+}
+
+func TestConfigExecutorRollback(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedMsg string
+	}{
+		{
+			name:        "Rollback",
+			args:        []string{"rollback", "config", "1"},
+			expectedMsg: `Done. I restored filter "NodeEventsChecker" to enabled (change 3) on 'foo' cluster. It will be applied once the configuration is reloaded.`,
+		},
+		{
+			name:        "Missing ID",
+			args:        []string{"rollback", "config"},
+			expectedMsg: "You forgot to pass the change ID. Run `{{BotName}} show config history` to see the recorded changes.",
+		},
+		{
+			name:        "Unknown ID",
+			args:        []string{"rollback", "config", "2"},
+			expectedMsg: "Configuration change \"2\" not found. Run `{{BotName}} show config history` to see the recorded changes.",
+		},
+		{
+			name:        "Invalid ID",
+			args:        []string{"rollback", "config", "latest"},
+			expectedMsg: "Configuration change \"latest\" not found. Run `{{BotName}} show config history` to see the recorded changes.",
+		},
+		{
+			name:        "Change of a different channel",
+			args:        []string{"rollback", "config", "4"},
+			expectedMsg: "Configuration change 4 belongs to a different channel. Run `{{BotName}} rollback config 4` in the changed channel.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			e := NewConfigExecutor(loggerx.NewNoop(), &fakeAnalyticsReporter{}, &fakeConfigHistoryManager{}, config.Config{}, nil)
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ClusterName:    configTestClusterName,
+				Conversation:   Conversation{Alias: channelAlias, ID: "conv-id"},
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := e.Rollback(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+type fakeConfigHistoryManager struct {
+	fakeCfgPersistenceManager
+	history config.ConfigHistory
+}
+
+func (f *fakeConfigHistoryManager) ListConfigChanges(context.Context) (config.ConfigHistory, error) {
+	return f.history, nil
+}
+
+func (f *fakeConfigHistoryManager) RollbackConfigChange(_ context.Context, id int, channel config.ConfigChangeTarget) (config.ConfigChange, error) {
+	if id == 4 && channel.Channel != "general" {
+		return config.ConfigChange{}, fmt.Errorf("while rolling back change %d: %w", id, config.ErrConfigChangeNotAllowed)
+	}
+	if id != 1 {
+		return config.ConfigChange{}, fmt.Errorf("while getting change %d: %w", id, config.ErrConfigChangeNotFound)
+	}
+	enabled := true
+	return config.ConfigChange{
+		ID:         3,
+		Type:       config.FilterConfigChange,
+		Target:     config.ConfigChangeTarget{Name: "NodeEventsChecker"},
+		After:      &config.ConfigChangeValue{Enabled: &enabled},
+		RollbackOf: 1,
+	}, nil
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/audit"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/alias"
@@ -23,6 +25,7 @@ const (
 	unsupportedCmdMsg   = "Command not supported. Please use 'help' to see supported commands."
 	internalErrorMsgFmt = "Sorry, an internal error occurred while executing your command for the '%s' cluster :( See the logs for more details."
 	emptyResponseMsg    = ".... empty response _*<cricket sounds>*_ :cricket: :cricket: :cricket:"
+	directMessageCmdMsg = "This command is not supported in direct messages. Run it in a configured channel instead."
 
 	anonymizedInvalidVerb = "{invalid verb}"
 
@@ -31,6 +34,16 @@ const (
 
 var newLinePattern = regexp.MustCompile(`\r?\n`)
 
+// directMessageVerbs holds the built-in commands allowed in direct messages. Other commands, such as changing notification
+// settings, filters or the persisted configuration, require channel-level bindings and are not supported there.
+var directMessageVerbs = map[command.Verb]struct{}{
+	command.PingVerb:     {},
+	command.HelpVerb:     {},
+	command.VersionVerb:  {},
+	command.FeedbackVerb: {},
+	command.ListVerb:     {},
+}
+
 // DefaultExecutor is a default implementations of Executor
 type DefaultExecutor struct {
 	cfg                   config.Config
@@ -58,8 +71,12 @@ type DefaultExecutor struct {
 	cfgManager            ConfigPersistenceManager
 	commGroupName         string
 	user                  string
+	userID                string
+	userInfoProvider      UserInfoProvider
 	kubectlCmdBuilder     *KubectlCmdBuilder
 	cmdsMapping           *CommandMapping
+	auditEmitter          AuditEmitter
+	actionName            string
 }
 
 // CommandFlags creates custom type for flags in botkube
@@ -79,6 +96,7 @@ func (flag CommandFlags) String() string {
 
 // Execute executes commands and returns output
 func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
+	start := time.Now()
 	empty := interactive.CoreMessage{}
 	rawCmd := sanitizeCommand(e.message)
 
@@ -135,12 +153,52 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		return empty // user specified different target cluster
 	}
 
+	// from now on, the command is addressed to this cluster, so its execution is audited
+	var (
+		execErr   error
+		skipAudit bool
+	)
+	defer func() {
+		if skipAudit {
+			return
+		}
+		e.emitAudit(ctx, cmdCtx, start, execErr)
+	}()
+
+	// commands which don't use executor bindings, such as `help` or `ping`, don't need authorization
+	if e.conversation.CommandOrigin != command.AutomationOrigin && e.usesExecutorBindings(cmdCtx.Args) {
+		authorizer := &bindingsAuthorizer{executors: e.cfg.Executors, userID: e.userID, provider: e.userInfoProvider}
+		allowed, denied, err := authorizer.Authorize(ctx, e.conversation.ExecutorBindings)
+		if err != nil {
+			e.log.Errorf("while authorizing executor bindings: %s", err.Error())
+		}
+
+		if e.isCommandDenied(allowed, denied, cmdCtx.Args) {
+			if err != nil {
+				// the bindings couldn't be verified, so the user may be authorized
+				execErr = fmt.Errorf("while authorizing executor bindings: %w", err)
+				return respond(fmt.Sprintf(internalErrorMsgFmt, cmdCtx.ClusterName), cmdCtx)
+			}
+			e.log.WithFields(logrus.Fields{
+				"user":           e.userID,
+				"deniedBindings": denied,
+			}).Infof("User is not authorized to run command %q", cmdCtx.CleanCmd)
+			execErr = errors.New("user is not authorized to run the command")
+			return respond(fmt.Sprintf(notAuthorizedMsgFmt, cmdCtx.ClusterName), cmdCtx)
+		}
+
+		// commands below are executed only with bindings that the user is allowed to use
+		e.conversation.ExecutorBindings = allowed
+		cmdCtx.Conversation.ExecutorBindings = allowed
+	}
+
 	// checking if registered plugin overrides the built-in kubectl or kubectl command builder
 	isPluginCmd := e.pluginExecutor.CanHandle(e.conversation.ExecutorBindings, cmdCtx.Args)
 
 	if e.kubectlExecutor.CanHandle(cmdCtx.Args) && !isPluginCmd {
 		e.reportCommand(e.kubectlExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive())
 		out, err := e.kubectlExecutor.Execute(e.conversation.ExecutorBindings, cmdCtx.CleanCmd, e.conversation.IsAuthenticated, cmdCtx)
+		execErr = err
 		switch {
 		case err == nil:
 		case IsExecutionCommandError(err):
@@ -155,12 +213,14 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 
 	// commands below are executed only if the channel is authorized
 	if !e.conversation.IsAuthenticated {
+		skipAudit = true
 		return empty
 	}
 
 	if e.kubectlCmdBuilder.CanHandle(cmdCtx.Args) && !isPluginCmd {
 		e.reportCommand(e.kubectlCmdBuilder.GetCommandPrefix(cmdCtx.Args), false)
 		out, err := e.kubectlCmdBuilder.Do(ctx, cmdCtx.Args, e.platform, e.conversation.ExecutorBindings, e.conversation.SlackState, header(cmdCtx), cmdCtx)
+		execErr = err
 		if err != nil {
 			// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
 			e.log.Errorf("while executing kubectl: %s", err.Error())
@@ -175,6 +235,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		}
 		e.reportCommand(e.pluginExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive())
 		out, err := e.pluginExecutor.Execute(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+		execErr = err
 		switch {
 		case err == nil:
 		case IsExecutionCommandError(err):
@@ -193,10 +254,18 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		cmdRes = strings.ToLower(cmdCtx.Args[1])
 	}
 
+	if _, allowed := directMessageVerbs[cmdVerb]; e.conversation.IsDirectMessage && !allowed {
+		e.reportCommand(string(cmdVerb), false)
+		e.log.Infof("received command not supported in direct messages: %q", cmdCtx.CleanCmd)
+		execErr = errUnsupportedCommand
+		return respond(directMessageCmdMsg, cmdCtx)
+	}
+
 	fn, foundRes, foundFn := e.cmdsMapping.FindFn(cmdVerb, cmdRes)
 	if !foundRes {
 		e.reportCommand(anonymizedInvalidVerb, false)
 		e.log.Infof("received unsupported command: %q", cmdCtx.CleanCmd)
+		execErr = errUnsupportedCommand
 		return respond(unsupportedCmdMsg, cmdCtx)
 	}
 
@@ -205,13 +274,17 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 		if cmdRes != "" {
 			e.log.Infof("received unsupported resource: %q", cmdCtx.CleanCmd)
 			reportedCmd = fmt.Sprintf("%s {invalid feature}", reportedCmd)
+			execErr = errUnsupportedCommand
 		}
 		e.reportCommand(reportedCmd, false)
 		msg := e.cmdsMapping.HelpMessageForVerb(cmdVerb)
 		return respond(msg, cmdCtx)
 	}
 
+	// persisted configuration changes are recorded together with the command author
+	ctx = config.WithChangeAuthor(ctx, config.ChangeAuthor{User: cmdCtx.User, Platform: cmdCtx.Platform})
 	msg, err := fn(ctx, cmdCtx)
+	execErr = err
 	switch {
 	case err == nil:
 	case errors.Is(err, errInvalidCommand):
@@ -229,6 +302,32 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	return msg
 }
 
+// usesExecutorBindings returns true if a given command is handled by kubectl or executor plugins, which use the executor bindings.
+func (e *DefaultExecutor) usesExecutorBindings(args []string) bool {
+	return e.kubectlExecutor.CanHandle(args) ||
+		e.kubectlCmdBuilder.CanHandle(args) ||
+		e.pluginExecutor.CanHandle(e.conversation.ExecutorBindings, args)
+}
+
+// isCommandDenied returns true if a given command can be handled only by the executor bindings that the user is not allowed to use.
+func (e *DefaultExecutor) isCommandDenied(allowed, denied []string, args []string) bool {
+	if len(denied) == 0 {
+		return false
+	}
+
+	if e.pluginExecutor.CanHandle(allowed, args) {
+		return false
+	}
+	if e.pluginExecutor.CanHandle(denied, args) {
+		return true
+	}
+
+	isKubectlCmd := e.kubectlExecutor.CanHandle(args) || e.kubectlCmdBuilder.CanHandle(args)
+	return isKubectlCmd &&
+		!kubectlEnabledInBindings(e.cfg.Executors, allowed) &&
+		kubectlEnabledInBindings(e.cfg.Executors, denied)
+}
+
 func (e *DefaultExecutor) ExecuteHelp(ctx context.Context, cmdCtx CommandContext) interactive.CoreMessage {
 	e.reportCommand(e.pluginExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive())
 	msg, err := e.pluginExecutor.Help(ctx, e.conversation.ExecutorBindings, cmdCtx)
@@ -239,6 +338,34 @@ func (e *DefaultExecutor) ExecuteHelp(ctx context.Context, cmdCtx CommandContext
 	return msg
 }
 
+// emitAudit emits the audit record for the executed command, if the audit emitter is configured.
+func (e *DefaultExecutor) emitAudit(ctx context.Context, cmdCtx CommandContext, start time.Time, execErr error) {
+	if e.auditEmitter == nil {
+		return
+	}
+
+	record := audit.Record{
+		Timestamp:        start,
+		Cluster:          cmdCtx.ClusterName,
+		CommGroupName:    cmdCtx.CommGroupName,
+		Platform:         cmdCtx.Platform,
+		Channel:          cmdCtx.Conversation.Alias,
+		User:             cmdCtx.User,
+		UserID:           e.userID,
+		Command:          removeMultipleSpaces(cmdCtx.ExpandedRawCmd),
+		ExecutorBindings: cmdCtx.Conversation.ExecutorBindings,
+		Origin:           string(cmdCtx.Conversation.CommandOrigin),
+		Action:           e.actionName,
+		DurationMs:       time.Since(start).Milliseconds(),
+		Success:          execErr == nil,
+	}
+	if execErr != nil {
+		record.Error = execErr.Error()
+	}
+
+	e.auditEmitter.Emit(ctx, record)
+}
+
 func respond(body string, cmdCtx CommandContext) interactive.CoreMessage {
 	body = cmdCtx.ExecutorFilter.Apply(body)
 	msgBody := api.Body{
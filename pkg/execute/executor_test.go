@@ -0,0 +1,247 @@
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+func TestDefaultExecutorEmitsAuditRecords(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+
+		expRecord audit.Record
+	}{
+		{
+			name:    "successful command",
+			message: "ping",
+			expRecord: audit.Record{
+				Cluster:          "prod",
+				CommGroupName:    "default-group",
+				Platform:         config.SocketSlackCommPlatformIntegration,
+				Channel:          "general",
+				User:             "Jane",
+				UserID:           "U123",
+				Command:          "ping",
+				ExecutorBindings: []string{"kubectl-read-only"},
+				Origin:           string(command.AutomationOrigin),
+				Action:           "Ping cluster",
+				Success:          true,
+			},
+		},
+		{
+			name:    "unsupported command",
+			message: "unknown   command",
+			expRecord: audit.Record{
+				Cluster:          "prod",
+				CommGroupName:    "default-group",
+				Platform:         config.SocketSlackCommPlatformIntegration,
+				Channel:          "general",
+				User:             "Jane",
+				UserID:           "U123",
+				Command:          "unknown command",
+				ExecutorBindings: []string{"kubectl-read-only"},
+				Origin:           string(command.AutomationOrigin),
+				Action:           "Ping cluster",
+				Success:          false,
+				Error:            "unsupported command",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			emitter := &fakeAuditEmitter{}
+			factory, err := NewExecutorFactory(DefaultExecutorFactoryParams{
+				Log:               loggerx.NewNoop(),
+				Cfg:               config.Config{Settings: config.Settings{ClusterName: "prod"}},
+				AnalyticsReporter: &fakeAnalyticsReporter{},
+				AuditEmitter:      emitter,
+			})
+			require.NoError(t, err)
+
+			executor := factory.NewDefault(NewDefaultInput{
+				CommGroupName: "default-group",
+				Platform:      config.SocketSlackCommPlatformIntegration,
+				Conversation: Conversation{
+					Alias:            "general",
+					ExecutorBindings: []string{"kubectl-read-only"},
+					IsAuthenticated:  true,
+					CommandOrigin:    command.AutomationOrigin,
+				},
+				Message:    tc.message,
+				User:       "Jane",
+				UserID:     "U123",
+				ActionName: "Ping cluster",
+			})
+
+			// when
+			executor.Execute(context.Background())
+
+			// then
+			require.Len(t, emitter.records, 1)
+			record := emitter.records[0]
+			assert.False(t, record.Timestamp.IsZero())
+			record.Timestamp = tc.expRecord.Timestamp
+			record.DurationMs = tc.expRecord.DurationMs
+			assert.Equal(t, tc.expRecord, record)
+		})
+	}
+}
+
+func TestDefaultExecutorSkipsAuditForOtherClusters(t *testing.T) {
+	// given
+	emitter := &fakeAuditEmitter{}
+	factory, err := NewExecutorFactory(DefaultExecutorFactoryParams{
+		Log:               loggerx.NewNoop(),
+		Cfg:               config.Config{Settings: config.Settings{ClusterName: "prod"}},
+		AnalyticsReporter: &fakeAnalyticsReporter{},
+		AuditEmitter:      emitter,
+	})
+	require.NoError(t, err)
+
+	executor := factory.NewDefault(NewDefaultInput{
+		Conversation: Conversation{IsAuthenticated: true, CommandOrigin: command.TypedOrigin},
+		Message:      "ping --cluster-name=dev",
+	})
+
+	// when
+	executor.Execute(context.Background())
+
+	// then
+	assert.Empty(t, emitter.records)
+}
+
+func TestDefaultExecutorAuthorizesOnlyExecutorCommands(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+
+		expInternalErr bool
+	}{
+		{name: "ping", message: "ping"},
+		{name: "help", message: "help"},
+		{name: "kubectl", message: "kubectl get pods", expInternalErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			factory, err := NewExecutorFactory(DefaultExecutorFactoryParams{
+				Log: loggerx.NewNoop(),
+				Cfg: config.Config{
+					Settings: config.Settings{ClusterName: "prod"},
+					Executors: map[string]config.Executors{
+						"kubectl-admins": {
+							Kubectl: config.Kubectl{
+								Enabled:  true,
+								Commands: config.Commands{Verbs: []string{"get"}, Resources: []string{"pods"}},
+							},
+							Authorization: config.ExecutorAuthorization{Groups: []string{"sre"}},
+						},
+					},
+				},
+				AnalyticsReporter: &fakeAnalyticsReporter{},
+			})
+			require.NoError(t, err)
+
+			executor := factory.NewDefault(NewDefaultInput{
+				Platform: config.SocketSlackCommPlatformIntegration,
+				Conversation: Conversation{
+					Alias:            "general",
+					ExecutorBindings: []string{"kubectl-admins"},
+					IsAuthenticated:  true,
+					CommandOrigin:    command.TypedOrigin,
+				},
+				Message: tc.message,
+				User:    "Jane",
+				UserID:  "U123",
+				UserInfoProvider: UserInfoProviderFunc(func(context.Context, string, UserInfoOptions) (UserInfo, error) {
+					return UserInfo{}, errors.New("fix err")
+				}),
+			})
+
+			// when
+			msg := executor.Execute(context.Background())
+
+			// then
+			internalErrMsg := fmt.Sprintf(internalErrorMsgFmt, "prod")
+			if tc.expInternalErr {
+				assert.Equal(t, internalErrMsg, msg.BaseBody.CodeBlock)
+				return
+			}
+			assert.NotEqual(t, internalErrMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+type fakeAuditEmitter struct {
+	records []audit.Record
+}
+
+func (f *fakeAuditEmitter) Emit(_ context.Context, record audit.Record) {
+	f.records = append(f.records, record)
+}
+
+func TestDefaultExecutorRestrictsDirectMessageCommands(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+
+		expRejected bool
+	}{
+		{name: "ping", message: "ping"},
+		{name: "list executors", message: "list executors"},
+		{name: "enable notifications", message: "enable notifications", expRejected: true},
+		{name: "edit source bindings", message: "edit sourcebindings k8s-events", expRejected: true},
+		{name: "disable filter", message: "disable filter NodeEventsChecker", expRejected: true},
+		{name: "rollback config", message: "rollback config 1", expRejected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			factory, err := NewExecutorFactory(DefaultExecutorFactoryParams{
+				Log:               loggerx.NewNoop(),
+				Cfg:               config.Config{Settings: config.Settings{ClusterName: "prod"}},
+				AnalyticsReporter: &fakeAnalyticsReporter{},
+			})
+			require.NoError(t, err)
+
+			executor := factory.NewDefault(NewDefaultInput{
+				Platform: config.SocketSlackCommPlatformIntegration,
+				Conversation: Conversation{
+					ID:               "D123",
+					ExecutorBindings: []string{"kubectl-read-only"},
+					IsAuthenticated:  true,
+					IsDirectMessage:  true,
+					CommandOrigin:    command.TypedOrigin,
+				},
+				Message: tc.message,
+				User:    "Jane",
+				UserID:  "U123",
+			})
+
+			// when
+			msg := executor.Execute(context.Background())
+
+			// then
+			if tc.expRejected {
+				assert.Equal(t, directMessageCmdMsg, msg.BaseBody.CodeBlock)
+				return
+			}
+			assert.NotEqual(t, directMessageCmdMsg, msg.BaseBody.CodeBlock)
+		})
+	}
+}
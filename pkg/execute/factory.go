@@ -2,11 +2,13 @@ package execute
 
 import (
 	"context"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 
 	"github.com/kubeshop/botkube/internal/plugin"
+	"github.com/kubeshop/botkube/pkg/audit"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
@@ -37,6 +39,7 @@ type DefaultExecutorFactory struct {
 	cfgManager            ConfigPersistenceManager
 	kubectlCmdBuilder     *KubectlCmdBuilder
 	cmdsMapping           *CommandMapping
+	auditEmitter          AuditEmitter
 }
 
 // DefaultExecutorFactoryParams contains input parameters for DefaultExecutorFactory.
@@ -53,6 +56,10 @@ type DefaultExecutorFactoryParams struct {
 	CommandGuard      CommandGuard
 	PluginManager     *plugin.Manager
 	BotKubeVersion    string
+	// AuditEmitter is optional. If provided, it receives the audit records of executed commands.
+	AuditEmitter AuditEmitter
+	// ConfigRevision is optional. If provided, the revision of the applied configuration is printed by the `show config` command.
+	ConfigRevision ConfigRevisionGetter
 }
 
 // Executor is an interface for processes to execute commands
@@ -66,6 +73,8 @@ type ConfigPersistenceManager interface {
 	PersistNotificationsEnabled(ctx context.Context, commGroupName string, platform config.CommPlatformIntegration, channelAlias string, enabled bool) error
 	PersistFilterEnabled(ctx context.Context, name string, enabled bool) error
 	PersistActionEnabled(ctx context.Context, name string, enabled bool) error
+	ListConfigChanges(ctx context.Context) (config.ConfigHistory, error)
+	RollbackConfigChange(ctx context.Context, id int, channel config.ConfigChangeTarget) (config.ConfigChange, error)
 }
 
 // AnalyticsReporter defines a reporter that collects analytics data.
@@ -74,6 +83,11 @@ type AnalyticsReporter interface {
 	ReportCommand(platform config.CommPlatformIntegration, command string, origin command.Origin, withFilter bool) error
 }
 
+// AuditEmitter sends the command execution audit records.
+type AuditEmitter interface {
+	Emit(ctx context.Context, record audit.Record)
+}
+
 // CommandGuard is an interface that allows to check if a given command is allowed to be executed.
 type CommandGuard interface {
 	GetAllowedResourcesForVerb(verb string, allConfiguredResources []string) ([]kubectl.Resource, error)
@@ -136,7 +150,9 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 	configExecutor := NewConfigExecutor(
 		params.Log.WithField("component", "Config Executor"),
 		params.AnalyticsReporter,
+		params.CfgManager,
 		params.Cfg,
+		params.ConfigRevision,
 	)
 	execExecutor := NewExecExecutor(
 		params.Log.WithField("component", "Executor Bindings Executor"),
@@ -204,6 +220,7 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		cfgManager:            params.CfgManager,
 		kubectlExecutor:       kcExecutor,
 		cmdsMapping:           mappings,
+		auditEmitter:          params.AuditEmitter,
 	}, nil
 }
 
@@ -214,6 +231,7 @@ type Conversation struct {
 	ExecutorBindings []string
 	SourceBindings   []string
 	IsAuthenticated  bool
+	IsDirectMessage  bool
 	CommandOrigin    command.Origin
 	SlackState       *slack.BlockActionStates
 }
@@ -226,6 +244,12 @@ type NewDefaultInput struct {
 	Conversation    Conversation
 	Message         string
 	User            string
+	// UserID is the platform user ID used to check the executor bindings authorization.
+	UserID string
+	// UserInfoProvider is optional. If provided, it is used to resolve the user email and groups.
+	UserInfoProvider UserInfoProvider
+	// ActionName is the name of the automation which triggered the command. It's used in the audit records.
+	ActionName string
 }
 
 // NewDefault creates new Default Executor.
@@ -252,7 +276,11 @@ func (f *DefaultExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
 		cfgManager:            f.cfgManager,
 		kubectlCmdBuilder:     f.kubectlCmdBuilder,
 		cmdsMapping:           f.cmdsMapping,
+		auditEmitter:          f.auditEmitter,
+		actionName:            cfg.ActionName,
 		user:                  cfg.User,
+		userID:                cfg.UserID,
+		userInfoProvider:      cfg.UserInfoProvider,
 		notifierHandler:       cfg.NotifierHandler,
 		conversation:          cfg.Conversation,
 		message:               cfg.Message,
@@ -260,3 +288,28 @@ func (f *DefaultExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
 		commGroupName:         cfg.CommGroupName,
 	}
 }
+
+// ReloadableExecutorFactory delegates to an executor factory which can be replaced when the configuration is reloaded.
+type ReloadableExecutorFactory struct {
+	mu      sync.RWMutex
+	factory *DefaultExecutorFactory
+}
+
+// NewReloadableExecutorFactory creates new ReloadableExecutorFactory.
+func NewReloadableExecutorFactory(factory *DefaultExecutorFactory) *ReloadableExecutorFactory {
+	return &ReloadableExecutorFactory{factory: factory}
+}
+
+// NewDefault creates new Default Executor using the current executor factory.
+func (f *ReloadableExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.factory.NewDefault(cfg)
+}
+
+// Swap replaces the current executor factory. Already created executors are not affected.
+func (f *ReloadableExecutorFactory) Swap(factory *DefaultExecutorFactory) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.factory = factory
+}
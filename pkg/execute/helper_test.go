@@ -40,6 +40,14 @@ func (f *fakeCfgPersistenceManager) PersistActionEnabled(ctx context.Context, na
 	return nil
 }
 
+func (f *fakeCfgPersistenceManager) ListConfigChanges(ctx context.Context) (config.ConfigHistory, error) {
+	return nil, nil
+}
+
+func (f *fakeCfgPersistenceManager) RollbackConfigChange(ctx context.Context, id int, channel config.ConfigChangeTarget) (config.ConfigChange, error) {
+	return config.ConfigChange{}, nil
+}
+
 func (f *fakeCfgPersistenceManager) ListActions(ctx context.Context) (map[string]config.Action, error) {
 	return nil, nil
 }
@@ -1,6 +1,8 @@
 package kubectl
 
 import (
+	"sync"
+
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
@@ -18,6 +20,7 @@ type EnabledKubectl struct {
 // Merger provides functionality to merge multiple bindings
 // associated with the kubectl executor.
 type Merger struct {
+	mu        sync.RWMutex
 	executors map[string]config.Executors
 }
 
@@ -28,6 +31,13 @@ func NewMerger(executors map[string]config.Executors) *Merger {
 	}
 }
 
+// SetExecutors replaces the executors configuration, e.g. when the configuration is reloaded.
+func (kc *Merger) SetExecutors(executors map[string]config.Executors) {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.executors = executors
+}
+
 // MergeForNamespace returns kubectl configuration for a given set of bindings.
 //
 // It merges entries only if a given Namespace is matched.
@@ -65,6 +75,9 @@ func (kc *Merger) GetAllEnabled(includeBindings []string) map[string]config.Kube
 
 // IsAtLeastOneEnabled returns true if at least one kubectl executor is enabled.
 func (kc *Merger) IsAtLeastOneEnabled() bool {
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
+
 	for _, executor := range kc.executors {
 		if executor.Kubectl.Enabled {
 			return true
@@ -128,6 +141,9 @@ func (kc *Merger) merge(collectedKubectls map[string]config.Kubectl, mapKeyOrder
 type collectPredicateFunc func(executor config.Kubectl) bool
 
 func (kc *Merger) collect(includeBindings []string, predicate collectPredicateFunc) map[string]config.Kubectl {
+	kc.mu.RLock()
+	defer kc.mu.RUnlock()
+
 	if kc.executors == nil {
 		return nil
 	}
@@ -235,6 +235,30 @@ func (e *SourceBindingExecutor) currentlySelectedOptions(commGroupName string, p
 			}
 			return channel.Bindings.Sources
 		}
+	case config.TelegramCommPlatformIntegration:
+		channels := e.cfg.Communications[commGroupName].Telegram.Channels
+		for _, channel := range channels {
+			if channel.Identifier() != conversationID {
+				continue
+			}
+			return channel.Bindings.Sources
+		}
+	case config.GoogleChatCommPlatformIntegration:
+		channels := e.cfg.Communications[commGroupName].GoogleChat.Channels
+		for _, channel := range channels {
+			if channel.Identifier() != conversationID {
+				continue
+			}
+			return channel.Bindings.Sources
+		}
+	case config.RocketChatCommPlatformIntegration:
+		channels := e.cfg.Communications[commGroupName].RocketChat.Channels
+		for _, channel := range channels {
+			if channel.Identifier() != conversationID {
+				continue
+			}
+			return channel.Bindings.Sources
+		}
 	case config.TeamsCommPlatformIntegration:
 		return e.cfg.Communications[commGroupName].Teams.Bindings.Sources
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/multierror"
 )
 
 // Notifier sends event notifications and messages on the communication channels.
@@ -31,6 +32,27 @@ type Notifier interface {
 	Type() config.IntegrationType
 }
 
+// Flusher is implemented by notifiers which buffer events, e.g. to send them in batches.
+type Flusher interface {
+	// Flush sends all buffered events.
+	Flush(context.Context) error
+}
+
+// Flush flushes all notifiers which buffer events. It's used on shutdown, so the buffered events are not lost.
+func Flush(ctx context.Context, notifiers []Notifier) error {
+	errs := multierror.New()
+	for _, n := range notifiers {
+		flusher, ok := n.(Flusher)
+		if !ok {
+			continue
+		}
+		if err := flusher.Flush(ctx); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("while flushing %s: %w", n.IntegrationName(), err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
 // SendPlaintextMessage sends a plaintext message to specified providers.
 func SendPlaintextMessage(ctx context.Context, notifiers []Notifier, msg string) error {
 	if msg == "" {
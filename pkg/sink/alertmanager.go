@@ -0,0 +1,288 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/format"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	alertmanagerAlertsPath       = "/api/v2/alerts"
+	defaultAlertmanagerTimeout   = 24 * time.Hour
+	defaultAlertmanagerAlertName = "BotkubeEvent"
+	alertNameLabel               = "alertname"
+)
+
+var _ Sink = &Alertmanager{}
+
+// Alertmanager provides integration with Prometheus Alertmanager. Events are posted as alerts, so they can be
+// silenced and routed as any other alert. The fired alerts are resolved when a matching resolve event arrives
+// for the same object, or after the alert timeout.
+type Alertmanager struct {
+	log          logrus.FieldLogger
+	reporter     AnalyticsReporter
+	transformer  *EventTransformer
+	alertsURL    string
+	username     string
+	password     string
+	headers      map[string]string
+	levels       []config.Level
+	labels       map[string]string
+	alertTimeout time.Duration
+	// resolves maps the resolve event reason to the reasons of alerts it resolves.
+	resolves map[string][]string
+	bindings config.SinkBindings
+
+	mu sync.Mutex
+	// firing holds the alerts which weren't resolved yet, indexed by the object and alert name.
+	// Alertmanager identifies alerts by their label set, so the resolved alert must have exactly the same labels.
+	firing map[string]firingAlert
+}
+
+type firingAlert struct {
+	labels   map[string]string
+	startsAt time.Time
+	endsAt   time.Time
+}
+
+// AlertmanagerAlert is a single alert in the Alertmanager API v2 format.
+type AlertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+// NewAlertmanager creates a new Alertmanager instance.
+func NewAlertmanager(log logrus.FieldLogger, c config.Alertmanager, reporter AnalyticsReporter) (*Alertmanager, error) {
+	amNotifier := &Alertmanager{
+		log:          log,
+		reporter:     reporter,
+		alertsURL:    strings.TrimSuffix(c.URL, "/") + alertmanagerAlertsPath,
+		username:     c.Username,
+		password:     c.Password,
+		headers:      c.Headers,
+		levels:       c.Levels,
+		labels:       c.Labels,
+		alertTimeout: c.AlertTimeout,
+		resolves:     map[string][]string{},
+		bindings:     c.Bindings,
+		firing:       map[string]firingAlert{},
+	}
+	if amNotifier.alertTimeout <= 0 {
+		amNotifier.alertTimeout = defaultAlertmanagerTimeout
+	}
+	for _, rule := range c.ResolveRules {
+		amNotifier.resolves[rule.Reason] = append(amNotifier.resolves[rule.Reason], rule.Resolves...)
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	amNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(amNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return amNotifier, nil
+}
+
+// SendEvent posts event to Alertmanager as a firing alert. If the event matches one of the resolve rules,
+// the previously fired alerts for the same object are resolved instead.
+func (a *Alertmanager) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(a.bindings.Sources, eventSources) {
+		a.log.Debugf("Event sources do not match Alertmanager sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = a.transformer.Apply(event)
+
+	now := time.Now()
+	if resolvedReasons, ok := a.resolves[event.Reason]; ok {
+		return a.resolve(ctx, event, resolvedReasons, now)
+	}
+
+	if !a.levelEnabled(event.Level) {
+		a.log.Debugf("Event level %q does not match Alertmanager levels, skipping...", event.Level)
+		return nil
+	}
+
+	alert := AlertmanagerAlert{
+		Labels:      a.alertLabels(event),
+		Annotations: alertAnnotations(event),
+		StartsAt:    eventTime(event),
+		EndsAt:      now.Add(a.alertTimeout),
+	}
+	if err := a.post(ctx, []AlertmanagerAlert{alert}); err != nil {
+		return fmt.Errorf("while sending alert to Alertmanager: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pruneExpired(now)
+	a.firing[alertKey(event, alert.Labels[alertNameLabel])] = firingAlert{
+		labels:   alert.Labels,
+		startsAt: alert.StartsAt,
+		endsAt:   alert.EndsAt,
+	}
+
+	a.log.Debugf("Alert successfully sent to Alertmanager: %+v", alert.Labels)
+	return nil
+}
+
+func (a *Alertmanager) resolve(ctx context.Context, event event.Event, resolvedReasons []string, now time.Time) error {
+	a.mu.Lock()
+	a.pruneExpired(now)
+	var (
+		keys   []string
+		alerts []AlertmanagerAlert
+	)
+	for _, reason := range resolvedReasons {
+		key := alertKey(event, reason)
+		fired, ok := a.firing[key]
+		if !ok {
+			continue
+		}
+		keys = append(keys, key)
+		alerts = append(alerts, AlertmanagerAlert{
+			Labels:   fired.labels,
+			StartsAt: fired.startsAt,
+			EndsAt:   now,
+		})
+	}
+	a.mu.Unlock()
+
+	if len(alerts) == 0 {
+		a.log.Debugf("No firing alerts to resolve for event %q", event.Reason)
+		return nil
+	}
+
+	if err := a.post(ctx, alerts); err != nil {
+		return fmt.Errorf("while resolving alerts in Alertmanager: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, key := range keys {
+		delete(a.firing, key)
+	}
+
+	a.log.Debugf("Successfully resolved %d alerts in Alertmanager", len(alerts))
+	return nil
+}
+
+func (a *Alertmanager) levelEnabled(level config.Level) bool {
+	if len(a.levels) == 0 {
+		return true
+	}
+	for _, enabled := range a.levels {
+		if enabled == level {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneExpired removes the alerts already resolved by Alertmanager after the timeout. It must be called with the lock held.
+func (a *Alertmanager) pruneExpired(now time.Time) {
+	for key, alert := range a.firing {
+		if now.After(alert.endsAt) {
+			delete(a.firing, key)
+		}
+	}
+}
+
+func (a *Alertmanager) post(ctx context.Context, alerts []AlertmanagerAlert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("while marshaling alerts: %w", err)
+	}
+	return postJSON(ctx, a.alertsURL, a.headers, a.username, a.password, body)
+}
+
+// alertLabels returns the static labels merged with the labels derived from a given event. Empty values are skipped.
+func (a *Alertmanager) alertLabels(event event.Event) map[string]string {
+	out := make(map[string]string, len(a.labels)+7)
+	for key, value := range a.labels {
+		out[key] = value
+	}
+
+	alertName := event.Reason
+	if alertName == "" {
+		alertName = defaultAlertmanagerAlertName
+	}
+
+	for key, value := range map[string]string{
+		alertNameLabel: alertName,
+		"cluster":      event.Cluster,
+		"namespace":    event.Namespace,
+		"kind":         event.Kind,
+		"name":         event.Name,
+		"severity":     string(event.Level),
+		"type":         string(event.Type),
+	} {
+		if value == "" {
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// alertAnnotations returns the alert annotations with the event summary, messages, recommendations and warnings.
+func alertAnnotations(event event.Event) map[string]string {
+	out := map[string]string{
+		"summary": format.ShortMessage(event),
+	}
+	for key, values := range map[string][]string{
+		"description":     event.Messages,
+		"recommendations": event.Recommendations,
+		"warnings":        event.Warnings,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		out[key] = strings.Join(values, "\n")
+	}
+	return out
+}
+
+// alertKey identifies the alert fired for a given object.
+func alertKey(event event.Event, alertName string) string {
+	return strings.Join([]string{event.Cluster, event.Namespace, event.Kind, event.Name, alertName}, "/")
+}
+
+// SendMessageToAll is no-op.
+func (a *Alertmanager) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (a *Alertmanager) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (a *Alertmanager) IntegrationName() config.CommPlatformIntegration {
+	return config.AlertmanagerCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (a *Alertmanager) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
@@ -0,0 +1,174 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestAlertmanager_FiresAndResolvesAlert(t *testing.T) {
+	// given
+	am := &fakeAlertmanager{}
+	ts := httptest.NewServer(am)
+	defer ts.Close()
+
+	sink, err := NewAlertmanager(loggerx.NewNoop(), config.Alertmanager{
+		URL:    ts.URL,
+		Levels: []config.Level{config.Error},
+		Labels: map[string]string{"team": "platform"},
+		ResolveRules: []config.AlertmanagerResolveRule{
+			{Reason: "NodeReady", Resolves: []string{"NodeNotReady"}},
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	notReady := event.Event{
+		TypeMeta:        metaV1.TypeMeta{Kind: "Node"},
+		Name:            "node-1",
+		Cluster:         "prod",
+		Reason:          "NodeNotReady",
+		Level:           config.Error,
+		Type:            config.ErrorEvent,
+		Messages:        []string{"Node node-1 status is now: NodeNotReady"},
+		Recommendations: []string{"Check the kubelet logs"},
+	}
+	ready := event.Event{
+		TypeMeta: metaV1.TypeMeta{Kind: "Node"},
+		Name:     "node-1",
+		Cluster:  "prod",
+		Reason:   "NodeReady",
+		Level:    config.Info,
+		Type:     config.NormalEvent,
+	}
+
+	// when
+	err = sink.SendEvent(context.Background(), notReady, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	expectedLabels := map[string]string{
+		"alertname": "NodeNotReady",
+		"cluster":   "prod",
+		"kind":      "Node",
+		"name":      "node-1",
+		"severity":  "error",
+		"type":      "error",
+		"team":      "platform",
+	}
+
+	alerts := am.Alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, expectedLabels, alerts[0].Labels)
+	assert.Equal(t, "Node node-1 status is now: NodeNotReady", alerts[0].Annotations["description"])
+	assert.Equal(t, "Check the kubelet logs", alerts[0].Annotations["recommendations"])
+	assert.True(t, alerts[0].EndsAt.After(time.Now().Add(time.Hour)))
+
+	// when
+	err = sink.SendEvent(context.Background(), ready, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	alerts = am.Alerts()
+	require.Len(t, alerts, 2)
+	assert.Equal(t, expectedLabels, alerts[1].Labels)
+	assert.Equal(t, alerts[0].StartsAt, alerts[1].StartsAt)
+	assert.False(t, alerts[1].EndsAt.After(time.Now()))
+
+	// when
+	err = sink.SendEvent(context.Background(), ready, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// then
+	assert.Len(t, am.Alerts(), 2, "already resolved alert shouldn't be resolved again")
+}
+
+func TestAlertmanager_SkipsEvents(t *testing.T) {
+	// given
+	am := &fakeAlertmanager{}
+	ts := httptest.NewServer(am)
+	defer ts.Close()
+
+	sink, err := NewAlertmanager(loggerx.NewNoop(), config.Alertmanager{
+		URL:    ts.URL,
+		Levels: []config.Level{config.Error, config.Critical},
+		ResolveRules: []config.AlertmanagerResolveRule{
+			{Reason: "NodeReady", Resolves: []string{"NodeNotReady"}},
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		Name    string
+		Event   event.Event
+		Sources []string
+	}{
+		{
+			Name:    "Not bound source",
+			Event:   event.Event{Name: "foo", Level: config.Error},
+			Sources: []string{"other"},
+		},
+		{
+			Name:    "Not enabled level",
+			Event:   event.Event{Name: "foo", Level: config.Info},
+			Sources: []string{"k8s-events"},
+		},
+		{
+			Name:    "Resolve event without firing alert",
+			Event:   event.Event{Name: "foo", Reason: "NodeReady", Level: config.Error},
+			Sources: []string{"k8s-events"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// when
+			err := sink.SendEvent(context.Background(), tc.Event, tc.Sources)
+
+			// then
+			require.NoError(t, err)
+		})
+	}
+	assert.Empty(t, am.Alerts())
+}
+
+type fakeAlertmanager struct {
+	mu     sync.Mutex
+	alerts []AlertmanagerAlert
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != alertmanagerAlertsPath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var alerts []AlertmanagerAlert
+	if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.alerts = append(f.alerts, alerts...)
+}
+
+func (f *fakeAlertmanager) Alerts() []AlertmanagerAlert {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]AlertmanagerAlert(nil), f.alerts...)
+}
@@ -0,0 +1,39 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	// RecordTypeHeader is the header which describes the type of the sent record. It's set only for the audit records,
+	// so they can be distinguished from events sent to the same destination.
+	RecordTypeHeader = "X-Botkube-Record-Type"
+	// AuditRecordType is the RecordTypeHeader value for the audit records.
+	AuditRecordType = "audit"
+)
+
+// AuditPayload wraps the audit record sent by sinks, so it can be distinguished from events by the payload shape.
+type AuditPayload struct {
+	Audit audit.Record `json:"audit"`
+}
+
+func marshalAuditPayload(record audit.Record) ([]byte, error) {
+	out, err := json.Marshal(AuditPayload{Audit: record})
+	if err != nil {
+		return nil, fmt.Errorf("while marshaling audit record: %w", err)
+	}
+	return out, nil
+}
+
+// auditKey returns the message key for the audit records. Only the cluster key is supported, as the audit records
+// don't have the namespace and kind properties.
+func auditKey(key config.EventKey, record audit.Record) string {
+	if key == config.ClusterEventKey {
+		return record.Cluster
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestWebhook_SendAuditRecord(t *testing.T) {
+	// given
+	var (
+		gotRecordType string
+		gotPayload    AuditPayload
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRecordType = r.Header.Get(RecordTypeHeader)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotPayload))
+	}))
+	defer ts.Close()
+
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL:      ts.URL,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	record := fixAuditRecord()
+
+	// when
+	err = wh.SendAuditRecord(context.Background(), record)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, AuditRecordType, gotRecordType)
+	assert.Equal(t, record, gotPayload.Audit)
+}
+
+func TestElasticsearch_SendAuditRecord(t *testing.T) {
+	// given
+	fakeES := newFakeElasticsearchServer()
+	srv := httptest.NewServer(fakeES)
+	defer srv.Close()
+
+	els := newTestElasticsearch(t, srv.URL, config.ELSIndex{
+		Name:     "botkube",
+		Shards:   1,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, config.ELSBulk{})
+	els.indices["events-only"] = config.ELSIndex{
+		Name:     "botkube-events",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- els.Start(ctx)
+	}()
+
+	// when
+	err := els.SendAuditRecord(context.Background(), fixAuditRecord())
+	require.NoError(t, err)
+	cancel()
+
+	// then
+	select {
+	case err := <-startErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Elasticsearch sink shutdown")
+	}
+
+	fakeES.mu.Lock()
+	defer fakeES.mu.Unlock()
+	assert.Equal(t, 1, fakeES.indexedDocs)
+	require.Len(t, fakeES.bulkLines, 2)
+	assert.Contains(t, fakeES.bulkLines[0], `"_index":"botkube-2022-10-01"`)
+	assert.True(t, strings.HasPrefix(fakeES.bulkLines[1], `{"audit":{`))
+}
+
+func TestLoki_SendAuditRecord(t *testing.T) {
+	// given
+	var gotReq LokiPushRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+	}))
+	defer ts.Close()
+
+	sink, err := NewLoki(loggerx.NewNoop(), config.Loki{
+		URL:      ts.URL,
+		Labels:   map[string]string{"job": "botkube"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	record := fixAuditRecord()
+	record.Success = false
+	record.Error = "user is not authorized to run the command"
+
+	// when
+	err = sink.SendAuditRecord(context.Background(), record)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, gotReq.Streams, 1)
+	assert.Equal(t, map[string]string{
+		"job":     "botkube",
+		"cluster": "prod",
+		"level":   "error",
+		"type":    "audit",
+	}, gotReq.Streams[0].Stream)
+}
+
+func TestKafka_SendAuditRecord(t *testing.T) {
+	// given
+	broker := &fakeKafkaBroker{}
+	sink, err := newKafka(loggerx.NewNoop(), config.Kafka{
+		Topic:    "botkube",
+		Key:      config.NamespaceEventKey,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, broker, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	record := fixAuditRecord()
+
+	// when
+	err = sink.SendAuditRecord(context.Background(), record)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, broker.messages, 1)
+	msg := broker.messages[0]
+	assert.Empty(t, msg.Key, "namespace key is not supported for audit records")
+
+	var payload AuditPayload
+	require.NoError(t, json.Unmarshal(msg.Value, &payload))
+	assert.Equal(t, record, payload.Audit)
+}
+
+func TestNATS_SendAuditRecord(t *testing.T) {
+	// given
+	srv := &fakeNATSServer{}
+	sink, err := newNATS(loggerx.NewNoop(), config.NATS{
+		Subject:  "botkube.events",
+		Key:      config.ClusterEventKey,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, srv, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendAuditRecord(context.Background(), fixAuditRecord())
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, srv.messages, 1)
+	assert.Equal(t, "botkube.events.prod", srv.messages[0].Subject)
+	assert.Equal(t, AuditRecordType, srv.messages[0].Header.Get(RecordTypeHeader))
+}
+
+func fixAuditRecord() audit.Record {
+	return audit.Record{
+		Timestamp:        time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+		Cluster:          "prod",
+		CommGroupName:    "default-group",
+		Platform:         config.SocketSlackCommPlatformIntegration,
+		Channel:          "general",
+		User:             "Jane",
+		UserID:           "U123",
+		Command:          "kubectl delete pod foo",
+		ExecutorBindings: []string{"kubectl-all"},
+		Origin:           "typed",
+		DurationMs:       120,
+		Success:          true,
+	}
+}
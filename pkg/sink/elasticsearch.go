@@ -3,9 +3,12 @@ package sink
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -15,9 +18,12 @@ import (
 	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/olivere/elastic"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sha1sum/aws_signing_client"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/pkg/audit"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/event"
@@ -37,18 +43,68 @@ const (
 	// The token file mount path in POD env variable while using IAM Role for service account
 	// #nosec G101
 	awsWebIDTokenFileEnvName = "AWS_WEB_IDENTITY_TOKEN_FILE"
+
+	defaultELSBatchSize     = 100
+	defaultELSFlushInterval = 5 * time.Second
+	defaultELSQueueSize     = 1000
+	defaultELSWorkers       = 1
+	elsShutdownTimeout      = 30 * time.Second
+	elsBulkMaxRetries       = 3
+	elsBulkInitialInterval  = time.Second
+)
+
+var (
+	elsQueuedEvents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "botkube_elasticsearch_queued_events",
+		Help: "Number of events queued to be sent to Elasticsearch.",
+	}, []string{"index"})
+	elsDroppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "botkube_elasticsearch_dropped_events_total",
+		Help: "Number of events dropped because the Elasticsearch queue was full.",
+	}, []string{"index"})
 )
 
 // Elasticsearch provides integration with the Elasticsearch solution.
+// Events are queued and sent in batches by the bulk workers run with Start.
 type Elasticsearch struct {
-	log      logrus.FieldLogger
-	reporter AnalyticsReporter
-	client   *elastic.Client
-	indices  map[string]config.ELSIndex
+	log         logrus.FieldLogger
+	reporter    AnalyticsReporter
+	transformer *EventTransformer
+	client      *elastic.Client
+	indices     map[string]config.ELSIndex
+	bulkCfg     config.ELSBulk
+	queue       chan elsQueueItem
+	outbox      *Outbox
+
+	knownIndicesMu sync.Mutex
+	knownIndices   map[string]struct{}
+}
+
+// elsQueueItem holds an event or, if set, an audit record to be indexed.
+type elsQueueItem struct {
+	indexAlias  string
+	indexName   string
+	indexCfg    config.ELSIndex
+	event       event.Event
+	sources     []string
+	auditRecord *audit.Record
+}
+
+func (i elsQueueItem) kind() string {
+	if i.auditRecord != nil {
+		return "audit record"
+	}
+	return "event"
+}
+
+func (i elsQueueItem) outboxEntry() OutboxEntry {
+	entry := newOutboxEntry(i.event, i.sources, i.indexAlias)
+	entry.AuditRecord = i.auditRecord
+	return entry
 }
 
 // NewElasticsearch creates a new Elasticsearch instance.
-func NewElasticsearch(log logrus.FieldLogger, c config.Elasticsearch, reporter AnalyticsReporter) (*Elasticsearch, error) {
+func NewElasticsearch(log logrus.FieldLogger, commGroupName string, c config.Elasticsearch, reporter AnalyticsReporter) (*Elasticsearch, error) {
 	var elsClient *elastic.Client
 	var err error
 	var creds *credentials.Credentials
@@ -109,13 +165,31 @@ func NewElasticsearch(log logrus.FieldLogger, c config.Elasticsearch, reporter A
 		}
 	}
 
+	bulkCfg := bulkConfigWithDefaults(c.Bulk)
 	esNotifier := &Elasticsearch{
-		log:      log,
-		reporter: reporter,
-		client:   elsClient,
-		indices:  c.Indices,
+		log:          log,
+		reporter:     reporter,
+		client:       elsClient,
+		indices:      c.Indices,
+		bulkCfg:      bulkCfg,
+		queue:        make(chan elsQueueItem, bulkCfg.QueueSize),
+		knownIndices: map[string]struct{}{},
 	}
 
+	if c.Outbox.Enabled {
+		outbox, err := NewOutbox(log.WithField("component", "Outbox"), commGroupName, "elasticsearch", c.Outbox, esNotifier.deliverFromOutbox)
+		if err != nil {
+			return nil, fmt.Errorf("while creating Elasticsearch outbox: %w", err)
+		}
+		esNotifier.outbox = outbox
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	esNotifier.transformer = transformer
+
 	err = reporter.ReportSinkEnabled(esNotifier.IntegrationName())
 	if err != nil {
 		return nil, fmt.Errorf("while reporting analytics: %w", err)
@@ -133,69 +207,450 @@ type settings struct {
 }
 
 type index struct {
-	Shards   int `json:"number_of_shards"`
-	Replicas int `json:"number_of_replicas"`
+	Shards    int        `json:"number_of_shards"`
+	Replicas  int        `json:"number_of_replicas"`
+	Lifecycle *lifecycle `json:"lifecycle,omitempty"`
+}
+
+type lifecycle struct {
+	Name string `json:"name"`
+}
+
+// dataStreamDoc wraps the event with the `@timestamp` field, which is required for data streams.
+type dataStreamDoc struct {
+	event.Event
+	Timestamp time.Time `json:"@timestamp"`
+}
+
+// auditDataStreamDoc wraps the audit record with the `@timestamp` field, which is required for data streams.
+type auditDataStreamDoc struct {
+	AuditPayload
+	Timestamp time.Time `json:"@timestamp"`
+}
+
+// Start installs configured ILM policies and index templates, and runs the bulk workers which send the queued events to Elasticsearch.
+// If enabled, it also replays the events stored in outbox. On shutdown, the already queued events are flushed.
+func (e *Elasticsearch) Start(ctx context.Context) error {
+	if err := e.setupIndices(ctx); err != nil {
+		// events can be still indexed, e.g. if the Elasticsearch user is not allowed to manage templates
+		e.log.Errorf("while setting up Elasticsearch indices: %s", err.Error())
+	}
+
+	var wg sync.WaitGroup
+	if e.outbox != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = e.outbox.Start(ctx) // it never returns an error
+		}()
+	}
+
+	for i := 0; i < e.bulkCfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e.runBulkWorker(ctx)
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// runBulkWorker sends the queued events in batches. A batch is sent when it's full or when the flush interval elapses.
+func (e *Elasticsearch) runBulkWorker(ctx context.Context) {
+	ticker := time.NewTicker(e.bulkCfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]elsQueueItem, 0, e.bulkCfg.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info("Shutdown requested. Flushing queued events...")
+			e.drainQueue(batch)
+			return
+		case item := <-e.queue:
+			// ctx is used only to detect shutdown, so that the already dequeued event is not lost
+			batch = e.addToBatch(context.Background(), batch, item)
+			if len(batch) >= e.bulkCfg.BatchSize {
+				e.commitBulk(context.Background(), batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			e.commitBulk(context.Background(), batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (e *Elasticsearch) drainQueue(batch []elsQueueItem) {
+	// use separate ctx as the parent one is already cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), elsShutdownTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case item := <-e.queue:
+			batch = e.addToBatch(ctx, batch, item)
+			if len(batch) >= e.bulkCfg.BatchSize {
+				e.commitBulk(ctx, batch)
+				batch = batch[:0]
+			}
+		default:
+			e.commitBulk(ctx, batch)
+			return
+		}
+	}
+}
+
+func (e *Elasticsearch) addToBatch(ctx context.Context, batch []elsQueueItem, item elsQueueItem) []elsQueueItem {
+	elsQueuedEvents.WithLabelValues(item.indexCfg.Name).Dec()
+
+	if err := e.ensureIndex(ctx, item.indexName, item.indexCfg); err != nil {
+		e.storeInOutbox(item, fmt.Errorf("while sending event to Elasticsearch index %q: %w", item.indexName, err))
+		return batch
+	}
+
+	return append(batch, item)
+}
+
+// commitBulk sends given items in bulk requests. If the outbox is disabled, temporary failures are retried with backoff.
+// Otherwise, the failed items are stored in outbox right away, as the outbox replays them in the background.
+// Each attempt uses a new bulk request, so that the already indexed events are not sent again.
+func (e *Elasticsearch) commitBulk(ctx context.Context, items []elsQueueItem) {
+	maxRetries := elsBulkMaxRetries
+	if e.outbox != nil {
+		maxRetries = 0
+	}
+
+	interval := elsBulkInitialInterval
+	for attempt := 0; len(items) > 0; attempt++ {
+		failed, err := e.sendBulk(ctx, items)
+		if len(failed) == 0 {
+			return
+		}
+		if attempt >= maxRetries {
+			for _, item := range failed {
+				e.storeInOutbox(item, err)
+			}
+			return
+		}
+
+		e.log.Debugf("Retrying %d events in %s (retry %d/%d) after error: %s", len(failed), interval, attempt+1, maxRetries, err.Error())
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+		interval *= 2
+		items = failed
+	}
+}
+
+// sendBulk sends given items in a single bulk request. It returns the items which failed with a temporary error.
+// Items which failed permanently are dropped.
+func (e *Elasticsearch) sendBulk(ctx context.Context, items []elsQueueItem) ([]elsQueueItem, error) {
+	bulk := e.client.Bulk()
+	for _, item := range items {
+		bulk.Add(newELSBulkRequest(item))
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return items, fmt.Errorf("while sending %d events to Elasticsearch: %w", len(items), err)
+	}
+
+	var failed []elsQueueItem
+	errs := multierror.New()
+	// response items are in the same order as requests
+	for idx, respItem := range resp.Items {
+		for _, result := range respItem {
+			if result.Status >= 200 && result.Status <= 299 {
+				continue
+			}
+
+			reason := ""
+			if result.Error != nil {
+				reason = result.Error.Reason
+			}
+			err := fmt.Errorf("while indexing event in Elasticsearch index %q: status %d: %s", result.Index, result.Status, reason)
+			if idx >= len(items) || !isELSRetryable(result.Status) {
+				e.log.Errorf("%s. Dropping event...", err.Error())
+				continue
+			}
+			failed = append(failed, items[idx])
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	e.log.Debugf("%d events successfully sent to Elasticsearch", len(resp.Succeeded()))
+	return failed, errs.ErrorOrNil()
+}
+
+func newELSBulkRequest(item elsQueueItem) *elastic.BulkIndexRequest {
+	req := elastic.NewBulkIndexRequest().Index(item.indexName)
+	if item.indexCfg.Type != "" {
+		req = req.Type(item.indexCfg.Type)
+	}
+	if item.auditRecord != nil {
+		if item.indexCfg.DataStream {
+			return req.OpType("create").Doc(auditDataStreamDoc{AuditPayload: AuditPayload{Audit: *item.auditRecord}, Timestamp: item.auditRecord.Timestamp})
+		}
+		return req.Doc(AuditPayload{Audit: *item.auditRecord})
+	}
+
+	if item.indexCfg.DataStream {
+		// data streams accept only the `create` operation
+		return req.OpType("create").Doc(dataStreamDoc{Event: item.event, Timestamp: item.event.TimeStamp})
+	}
+	return req.Doc(item.event)
 }
 
-func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex, event interface{}) error {
-	// Construct the ELS Index Name with timestamp suffix
-	indexName := indexCfg.Name + "-" + time.Now().Format(indexSuffixFormat)
-	// Create index if not exists
+// storeInOutbox stores a given item in outbox, if enabled. Otherwise, it only logs the delivery error.
+func (e *Elasticsearch) storeInOutbox(item elsQueueItem, deliveryErr error) {
+	if e.outbox == nil {
+		e.log.Error(deliveryErr.Error())
+		return
+	}
+
+	e.log.Warnf("%s. Storing %s in outbox...", deliveryErr.Error(), item.kind())
+	if err := e.outbox.Push(item.outboxEntry()); err != nil {
+		e.log.Errorf("while storing %s in Elasticsearch outbox: %s", item.kind(), err.Error())
+	}
+}
+
+func (e *Elasticsearch) deliverFromOutbox(ctx context.Context, entry OutboxEntry) error {
+	indexCfg, found := e.indices[entry.Destination]
+	if !found {
+		e.log.Errorf("Index %q from outbox entry is not configured anymore. Dropping event...", entry.Destination)
+		return nil
+	}
+
+	item := elsQueueItem{
+		indexAlias:  entry.Destination,
+		indexName:   indexNameFor(indexCfg, entry.CreatedAt),
+		indexCfg:    indexCfg,
+		event:       entry.Event,
+		sources:     entry.Sources,
+		auditRecord: entry.AuditRecord,
+	}
+	if err := e.ensureIndex(ctx, item.indexName, item.indexCfg); err != nil {
+		return err
+	}
+
+	resp, err := e.client.Bulk().Add(newELSBulkRequest(item)).Do(ctx)
+	if err != nil {
+		return err
+	}
+	for _, failed := range resp.Failed() {
+		err := fmt.Errorf("while indexing %s in Elasticsearch index %q: status %d", item.kind(), item.indexName, failed.Status)
+		if !isELSRetryable(failed.Status) {
+			e.log.Errorf("%s. Dropping event...", err.Error())
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// setupIndices installs or checks the ILM policies and index templates for all configured indices.
+func (e *Elasticsearch) setupIndices(ctx context.Context) error {
+	errs := multierror.New()
+	for _, indexCfg := range e.indices {
+		// ILM policy is installed first, as it can be referenced by the index template
+		if indexCfg.ILMPolicy.Name != "" {
+			path := "/_ilm/policy/" + url.PathEscape(indexCfg.ILMPolicy.Name)
+			if err := e.putOrCheck(ctx, path, indexCfg.ILMPolicy.Body); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while setting up ILM policy %q for index %q: %w", indexCfg.ILMPolicy.Name, indexCfg.Name, err))
+			}
+		}
+
+		if indexCfg.Template.Name != "" {
+			path := "/_index_template/" + url.PathEscape(indexCfg.Template.Name)
+			if err := e.putOrCheck(ctx, path, indexCfg.Template.Body); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("while setting up index template %q for index %q: %w", indexCfg.Template.Name, indexCfg.Name, err))
+			}
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// putOrCheck creates or updates a given resource if the body is specified. Otherwise, it checks if the resource exists.
+func (e *Elasticsearch) putOrCheck(ctx context.Context, path, body string) error {
+	if body != "" {
+		_, err := e.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+			Method: http.MethodPut,
+			Path:   path,
+			Body:   body,
+		})
+		return err
+	}
+
+	_, err := e.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   path,
+	})
+	if elastic.IsNotFound(err) {
+		return errors.New("not found")
+	}
+	return err
+}
+
+// ensureIndex creates a given index if it doesn't exist. Known indices are cached, so the check is done once per index.
+func (e *Elasticsearch) ensureIndex(ctx context.Context, indexName string, indexCfg config.ELSIndex) error {
+	if indexCfg.DataStream {
+		// data stream is created by Elasticsearch on the first write
+		return nil
+	}
+	e.knownIndicesMu.Lock()
+	_, known := e.knownIndices[indexName]
+	e.knownIndicesMu.Unlock()
+	if known {
+		return nil
+	}
+
 	exists, err := e.client.IndexExists(indexName).Do(ctx)
 	if err != nil {
 		return fmt.Errorf("while getting index: %w", err)
 	}
 	if !exists {
 		// Create a new index.
-		mapping := mapping{
-			Settings: settings{
-				index{
-					Shards:   indexCfg.Shards,
-					Replicas: indexCfg.Replicas,
-				},
-			},
+		createIndex := e.client.CreateIndex(indexName)
+		// settings and mappings from the index template are used if specified
+		if indexCfg.Template.Name == "" {
+			createIndex = createIndex.BodyJson(indexMapping(indexCfg))
 		}
-		_, err := e.client.CreateIndex(indexName).BodyJson(mapping).Do(ctx)
-		if err != nil {
+		_, err := createIndex.Do(ctx)
+		if err != nil && !elastic.IsConflict(err) && !isIndexAlreadyExistsErr(err) {
 			return fmt.Errorf("while creating index: %w", err)
 		}
 	}
 
-	// Send event to els
-	_, err = e.client.Index().Index(indexName).Type(indexCfg.Type).BodyJson(event).Do(ctx)
-	if err != nil {
-		return fmt.Errorf("while posting data to ELS: %w", err)
-	}
-	_, err = e.client.Flush().Index(indexName).Do(ctx)
-	if err != nil {
-		return fmt.Errorf("while flushing data in ELS: %w", err)
-	}
-	e.log.Debugf("Event successfully sent to Elasticsearch index %s", indexName)
+	e.knownIndicesMu.Lock()
+	defer e.knownIndicesMu.Unlock()
+	e.knownIndices[indexName] = struct{}{}
 	return nil
 }
 
-// SendEvent sends event notification to Elasticsearch
-func (e *Elasticsearch) SendEvent(ctx context.Context, event event.Event, eventSources []string) (err error) {
+// SendEvent queues event notification to be sent to Elasticsearch. It doesn't block when the queue is full,
+// and the event is stored in outbox, if enabled, or dropped.
+func (e *Elasticsearch) SendEvent(_ context.Context, event event.Event, eventSources []string) (err error) {
+	event = e.transformer.Apply(event)
 	e.log.Debugf(">> Sending to Elasticsearch: %+v", event)
 
+	now := time.Now()
+
 	errs := multierror.New()
-	for _, indexCfg := range e.indices {
+	for alias, indexCfg := range e.indices {
 		if !sliceutil.Intersect(indexCfg.Bindings.Sources, eventSources) {
 			continue
 		}
 
-		err := e.flushIndex(ctx, indexCfg, event)
+		err := e.enqueue(elsQueueItem{
+			indexAlias: alias,
+			indexName:  indexNameFor(indexCfg, now),
+			indexCfg:   indexCfg,
+			event:      event,
+			sources:    eventSources,
+		})
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while sending event to Elasticsearch index %q: %w", indexCfg.Name, err))
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// SendAuditRecord queues the command execution audit record to be indexed in all indices which have the audit enabled.
+// The audit records are sent in batches and stored in outbox in the same way as events.
+func (e *Elasticsearch) SendAuditRecord(_ context.Context, record audit.Record) error {
+	errs := multierror.New()
+	for alias, indexCfg := range e.indices {
+		if !indexCfg.Bindings.Audit {
 			continue
 		}
 
-		e.log.Debugf("Event successfully sent to Elasticsearch index %q", indexCfg.Name)
+		record := record
+		err := e.enqueue(elsQueueItem{
+			indexAlias:  alias,
+			indexName:   indexNameFor(indexCfg, record.Timestamp),
+			indexCfg:    indexCfg,
+			auditRecord: &record,
+		})
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
-
 	return errs.ErrorOrNil()
 }
 
+// enqueue queues a given item without blocking. If the queue is full, the item is stored in outbox, if enabled, or dropped.
+func (e *Elasticsearch) enqueue(item elsQueueItem) error {
+	if e.outbox != nil {
+		// keep the order until the outbox is drained
+		stored, err := e.outbox.PushIfNotEmpty(item.outboxEntry())
+		if err != nil {
+			return fmt.Errorf("while storing %s for Elasticsearch index %q in outbox: %w", item.kind(), item.indexCfg.Name, err)
+		}
+		if stored {
+			return nil
+		}
+	}
+
+	select {
+	case e.queue <- item:
+		elsQueuedEvents.WithLabelValues(item.indexCfg.Name).Inc()
+		e.log.Debugf("%s successfully queued for Elasticsearch index %q", item.kind(), item.indexCfg.Name)
+		return nil
+	default:
+	}
+
+	if e.outbox != nil {
+		if err := e.outbox.Push(item.outboxEntry()); err != nil {
+			return fmt.Errorf("while storing %s for Elasticsearch index %q in outbox: %w", item.kind(), item.indexCfg.Name, err)
+		}
+		return nil
+	}
+	elsDroppedEvents.WithLabelValues(item.indexCfg.Name).Inc()
+	return fmt.Errorf("while sending %s to Elasticsearch index %q: queue is full, %s dropped", item.kind(), item.indexCfg.Name, item.kind())
+}
+
+// indexNameFor returns the index name with the date suffix, or the data stream name.
+func indexNameFor(indexCfg config.ELSIndex, t time.Time) string {
+	if indexCfg.DataStream {
+		return indexCfg.Name
+	}
+	return indexCfg.Name + "-" + t.Format(indexSuffixFormat)
+}
+
+// isELSRetryable returns true for statuses which indicate a temporary problem, e.g. 429 or 5xx.
+func isELSRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func indexMapping(indexCfg config.ELSIndex) mapping {
+	out := mapping{
+		Settings: settings{
+			index{
+				Shards:   indexCfg.Shards,
+				Replicas: indexCfg.Replicas,
+			},
+		},
+	}
+	if indexCfg.ILMPolicy.Name != "" {
+		out.Settings.Index.Lifecycle = &lifecycle{Name: indexCfg.ILMPolicy.Name}
+	}
+	return out
+}
+
+func isIndexAlreadyExistsErr(err error) bool {
+	elsErr, ok := err.(*elastic.Error)
+	if !ok || elsErr.Details == nil {
+		return false
+	}
+	return elsErr.Details.Type == "resource_already_exists_exception" || elsErr.Details.Type == "index_already_exists_exception"
+}
+
 // SendMessageToAll is no-op.
 func (e *Elasticsearch) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
 	return nil
@@ -215,3 +670,19 @@ func (e *Elasticsearch) IntegrationName() config.CommPlatformIntegration {
 func (e *Elasticsearch) Type() config.IntegrationType {
 	return config.SinkIntegrationType
 }
+
+func bulkConfigWithDefaults(in config.ELSBulk) config.ELSBulk {
+	if in.BatchSize <= 0 {
+		in.BatchSize = defaultELSBatchSize
+	}
+	if in.FlushInterval <= 0 {
+		in.FlushInterval = defaultELSFlushInterval
+	}
+	if in.QueueSize <= 0 {
+		in.QueueSize = defaultELSQueueSize
+	}
+	if in.Workers <= 0 {
+		in.Workers = defaultELSWorkers
+	}
+	return in
+}
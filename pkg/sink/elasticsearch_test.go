@@ -0,0 +1,304 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestElasticsearch_SendEventUsesBulkRequests(t *testing.T) {
+	// given
+	fakeES := newFakeElasticsearchServer()
+	srv := httptest.NewServer(fakeES)
+	defer srv.Close()
+
+	indexCfg := config.ELSIndex{
+		Name:     "botkube",
+		Type:     "botkube-event",
+		Shards:   1,
+		Replicas: 0,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}
+	els := newTestElasticsearch(t, srv.URL, indexCfg, config.ELSBulk{FlushInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- els.Start(ctx)
+	}()
+
+	// when
+	for i := 0; i < 3; i++ {
+		err := els.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+		require.NoError(t, err)
+	}
+	err := els.SendEvent(context.Background(), event.Event{Name: "not-bound"}, []string{"other"})
+	require.NoError(t, err)
+
+	cancel()
+
+	// then
+	select {
+	case err := <-startErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Elasticsearch sink shutdown")
+	}
+
+	fakeES.mu.Lock()
+	defer fakeES.mu.Unlock()
+	assert.Equal(t, 1, fakeES.indexExistsCalls)
+	assert.Equal(t, 1, fakeES.createIndexCalls)
+	assert.Equal(t, 3, fakeES.indexedDocs)
+	assert.Zero(t, fakeES.flushCalls)
+	assert.Zero(t, testutil.ToFloat64(elsQueuedEvents.WithLabelValues(indexCfg.Name)))
+}
+
+func TestElasticsearch_SendEventDropsEventsWhenQueueIsFull(t *testing.T) {
+	// given
+	indexCfg := config.ELSIndex{
+		Name:     "botkube-drop-test",
+		Type:     "botkube-event",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}
+	// Start is not called, so the queue is not consumed
+	els := newTestElasticsearch(t, "http://localhost:9200", indexCfg, config.ELSBulk{QueueSize: 1})
+	droppedBefore := testutil.ToFloat64(elsDroppedEvents.WithLabelValues(indexCfg.Name))
+	queuedBefore := testutil.ToFloat64(elsQueuedEvents.WithLabelValues(indexCfg.Name))
+
+	// when
+	firstErr := els.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+	secondErr := els.SendEvent(context.Background(), event.Event{Name: "bar"}, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, firstErr)
+	require.Error(t, secondErr)
+	assert.Contains(t, secondErr.Error(), `while sending event to Elasticsearch index "botkube-drop-test": queue is full, event dropped`)
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(elsDroppedEvents.WithLabelValues(indexCfg.Name)))
+	assert.Equal(t, queuedBefore+1, testutil.ToFloat64(elsQueuedEvents.WithLabelValues(indexCfg.Name)))
+}
+
+func TestElasticsearch_DataStreamWithTemplateAndILMPolicy(t *testing.T) {
+	// given
+	fakeES := newFakeElasticsearchServer()
+	srv := httptest.NewServer(fakeES)
+	defer srv.Close()
+
+	indexCfg := config.ELSIndex{
+		Name:       "botkube-events",
+		DataStream: true,
+		Template: config.ELSIndexTemplate{
+			Name: "botkube",
+			Body: `{"index_patterns":["botkube-events*"],"data_stream":{}}`,
+		},
+		ILMPolicy: config.ELSILMPolicy{
+			Name: "botkube-retention",
+			Body: `{"policy":{"phases":{"delete":{"min_age":"30d","actions":{"delete":{}}}}}}`,
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}
+	els := newTestElasticsearch(t, srv.URL, indexCfg, config.ELSBulk{FlushInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- els.Start(ctx)
+	}()
+
+	// when
+	err := els.SendEvent(context.Background(), event.Event{Name: "foo", TimeStamp: time.Unix(0, 0).UTC()}, []string{"k8s-events"})
+	require.NoError(t, err)
+
+	// wait for setup, as it's skipped on shutdown
+	require.Eventually(t, func() bool {
+		fakeES.mu.Lock()
+		defer fakeES.mu.Unlock()
+		return len(fakeES.putResources) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+
+	// then
+	select {
+	case err := <-startErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for Elasticsearch sink shutdown")
+	}
+
+	fakeES.mu.Lock()
+	defer fakeES.mu.Unlock()
+	assert.Equal(t, map[string]string{
+		"_ilm/policy/botkube-retention": indexCfg.ILMPolicy.Body,
+		"_index_template/botkube":       indexCfg.Template.Body,
+	}, fakeES.putResources)
+	assert.Zero(t, fakeES.indexExistsCalls)
+	assert.Zero(t, fakeES.createIndexCalls)
+	require.Len(t, fakeES.bulkLines, 2)
+	assert.Equal(t, `{"create":{"_index":"botkube-events"}}`, fakeES.bulkLines[0])
+	assert.Contains(t, fakeES.bulkLines[1], `"@timestamp":"1970-01-01T00:00:00Z"`)
+}
+
+func TestElasticsearch_SetupIndicesMissingTemplate(t *testing.T) {
+	// given
+	fakeES := newFakeElasticsearchServer()
+	srv := httptest.NewServer(fakeES)
+	defer srv.Close()
+
+	indexCfg := config.ELSIndex{
+		Name:     "botkube",
+		Template: config.ELSIndexTemplate{Name: "not-existing"},
+	}
+	els := newTestElasticsearch(t, srv.URL, indexCfg, config.ELSBulk{})
+
+	// when
+	err := els.setupIndices(context.Background())
+
+	// then
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `while setting up index template "not-existing" for index "botkube": not found`)
+}
+
+func TestElasticsearch_CommitBulkStoresFailedEventsInOutboxOnce(t *testing.T) {
+	// given
+	fakeES := newFakeElasticsearchServer()
+	fakeES.bulkStatuses = []int{http.StatusServiceUnavailable}
+	srv := httptest.NewServer(fakeES)
+	defer srv.Close()
+
+	indexCfg := config.ELSIndex{Name: "botkube", Bindings: config.SinkBindings{Sources: []string{"k8s-events"}}}
+	els := newTestElasticsearch(t, srv.URL, indexCfg, config.ELSBulk{})
+	outbox, err := NewOutbox(els.log, "test-group", "elasticsearch", config.SinkOutbox{Enabled: true, Dir: t.TempDir()}, els.deliverFromOutbox)
+	require.NoError(t, err)
+	els.outbox = outbox
+
+	item := func(name string) elsQueueItem {
+		return elsQueueItem{indexAlias: "default", indexName: "botkube-2022-12-01", indexCfg: indexCfg, event: event.Event{Name: name}}
+	}
+
+	// when
+	els.commitBulk(context.Background(), []elsQueueItem{item("failed"), item("indexed")})
+	els.commitBulk(context.Background(), []elsQueueItem{item("next")})
+
+	// then
+	assert.Equal(t, 1, outbox.Len())
+	fakeES.mu.Lock()
+	defer fakeES.mu.Unlock()
+	// the failed event is not resent with the next bulk request
+	assert.Equal(t, 3, fakeES.indexedDocs)
+	assert.Equal(t, 2, fakeES.bulkCalls)
+}
+
+func newTestElasticsearch(t *testing.T, url string, indexCfg config.ELSIndex, bulkCfg config.ELSBulk) *Elasticsearch {
+	t.Helper()
+
+	client, err := elastic.NewClient(
+		elastic.SetURL(url),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+	)
+	require.NoError(t, err)
+
+	logger, _ := logtest.NewNullLogger()
+	bulkCfg = bulkConfigWithDefaults(bulkCfg)
+	return &Elasticsearch{
+		log:          logger.WithField("test", t.Name()),
+		client:       client,
+		indices:      map[string]config.ELSIndex{"default": indexCfg},
+		bulkCfg:      bulkCfg,
+		queue:        make(chan elsQueueItem, bulkCfg.QueueSize),
+		knownIndices: map[string]struct{}{},
+	}
+}
+
+type fakeElasticsearchServer struct {
+	mu               sync.Mutex
+	createdIndices   map[string]struct{}
+	putResources     map[string]string
+	bulkLines        []string
+	indexExistsCalls int
+	createIndexCalls int
+	flushCalls       int
+	indexedDocs      int
+	bulkCalls        int
+	// bulkStatuses holds statuses returned for subsequent documents. Other documents are indexed successfully.
+	bulkStatuses []int
+}
+
+func newFakeElasticsearchServer() *fakeElasticsearchServer {
+	return &fakeElasticsearchServer{
+		createdIndices: map[string]struct{}{},
+		putResources:   map[string]string{},
+	}
+}
+
+func (f *fakeElasticsearchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.Trim(r.URL.Path, "/")
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && path == "_bulk":
+		f.bulkCalls++
+		var items []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			f.bulkLines = append(f.bulkLines, scanner.Text())
+			if !strings.HasPrefix(scanner.Text(), `{"index":`) && !strings.HasPrefix(scanner.Text(), `{"create":`) {
+				continue
+			}
+
+			status := http.StatusCreated
+			if len(f.bulkStatuses) > 0 {
+				status, f.bulkStatuses = f.bulkStatuses[0], f.bulkStatuses[1:]
+			}
+			items = append(items, fmt.Sprintf(`{"index":{"_index":"botkube","status":%d}}`, status))
+		}
+		f.indexedDocs += len(items)
+		_, _ = fmt.Fprintf(w, `{"took":1,"errors":false,"items":[%s]}`, strings.Join(items, ","))
+	case strings.HasPrefix(path, "_ilm/") || strings.HasPrefix(path, "_index_template/"):
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			f.putResources[path] = string(body)
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		case http.MethodGet:
+			if _, found := f.putResources[path]; !found {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception"},"status":404}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}
+	case strings.HasSuffix(path, "_flush"):
+		f.flushCalls++
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodHead:
+		f.indexExistsCalls++
+		if _, found := f.createdIndices[path]; !found {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.Method == http.MethodPut:
+		f.createIndexCalls++
+		f.createdIndices[path] = struct{}{}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,48 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kubeshop/botkube/pkg/multierror"
+)
+
+// maxErrorResponseBodySize limits how much of the error response body is included in the returned error.
+const maxErrorResponseBodySize = 512
+
+// postJSON posts a given JSON body to the HTTP-based sinks. The request is authorized with basic auth if the username is set.
+func postJSON(ctx context.Context, url string, headers map[string]string, username, password string, body []byte) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("while creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	client := &http.Client{Timeout: defaultHTTPCliTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("while sending request: %w", err)
+	}
+	defer func() {
+		deferredErr := resp.Body.Close()
+		if deferredErr != nil {
+			err = multierror.Append(err, deferredErr)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorResponseBodySize))
+		return fmt.Errorf("got unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
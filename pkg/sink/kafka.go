@@ -0,0 +1,190 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &Kafka{}
+
+// kafkaBatchTimeout is short, as events are written synchronously one by one.
+const kafkaBatchTimeout = 10 * time.Millisecond
+
+// kafkaWriter publishes messages to Kafka. It's implemented by kafka.Writer.
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Kafka provides integration with Kafka. Events are published as JSON to a given topic.
+type Kafka struct {
+	log         logrus.FieldLogger
+	reporter    AnalyticsReporter
+	transformer *EventTransformer
+	writer      kafkaWriter
+	key         config.EventKey
+	bindings    config.SinkBindings
+}
+
+// NewKafka creates a new Kafka instance.
+func NewKafka(log logrus.FieldLogger, c config.Kafka, reporter AnalyticsReporter) (*Kafka, error) {
+	tlsCfg, err := newStreamTLSConfig(c.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("while creating TLS configuration: %w", err)
+	}
+
+	transport := &kafka.Transport{TLS: tlsCfg}
+	if c.SASL.Enabled {
+		mechanism, err := kafkaSASLMechanism(c.SASL)
+		if err != nil {
+			return nil, fmt.Errorf("while creating SASL mechanism: %w", err)
+		}
+		transport.SASL = mechanism
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(c.Brokers...),
+		Topic:        c.Topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: kafkaBatchTimeout,
+		Transport:    transport,
+	}
+
+	return newKafka(log, c, writer, reporter)
+}
+
+func newKafka(log logrus.FieldLogger, c config.Kafka, writer kafkaWriter, reporter AnalyticsReporter) (*Kafka, error) {
+	kafkaNotifier := &Kafka{
+		log:      log,
+		reporter: reporter,
+		writer:   writer,
+		key:      c.Key,
+		bindings: c.Bindings,
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	kafkaNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(kafkaNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return kafkaNotifier, nil
+}
+
+// Start waits for the context cancellation and closes the Kafka writer, so the pending messages are flushed.
+func (k *Kafka) Start(ctx context.Context) error {
+	<-ctx.Done()
+	if err := k.writer.Close(); err != nil {
+		return fmt.Errorf("while closing Kafka writer: %w", err)
+	}
+	return nil
+}
+
+// SendEvent publishes event to Kafka topic.
+func (k *Kafka) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(k.bindings.Sources, eventSources) {
+		k.log.Debugf("Event sources do not match Kafka sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = k.transformer.Apply(event)
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	msg := kafka.Message{
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "content-type", Value: []byte("application/json")},
+		},
+	}
+	if key := eventKey(k.key, event); key != "" {
+		msg.Key = []byte(key)
+	}
+
+	if err := k.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("while sending event to Kafka: %w", err)
+	}
+
+	k.log.Debugf("Event successfully sent to Kafka: %+v", event)
+	return nil
+}
+
+// SendAuditRecord publishes the command execution audit record to Kafka topic.
+func (k *Kafka) SendAuditRecord(ctx context.Context, record audit.Record) error {
+	value, err := marshalAuditPayload(record)
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "content-type", Value: []byte("application/json")},
+			{Key: RecordTypeHeader, Value: []byte(AuditRecordType)},
+		},
+	}
+	if key := auditKey(k.key, record); key != "" {
+		msg.Key = []byte(key)
+	}
+
+	if err := k.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("while sending audit record to Kafka: %w", err)
+	}
+	return nil
+}
+
+// SendMessageToAll is no-op.
+func (k *Kafka) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (k *Kafka) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (k *Kafka) IntegrationName() config.CommPlatformIntegration {
+	return config.KafkaCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (k *Kafka) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
+
+func kafkaSASLMechanism(cfg config.KafkaSASL) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case "", "PLAIN":
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("unsupported SASL mechanism %q", cfg.Mechanism)
+	}
+}
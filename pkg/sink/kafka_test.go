@@ -0,0 +1,138 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestKafka_SendEvent(t *testing.T) {
+	// given
+	evt := event.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "foo",
+		Namespace: "default",
+		Cluster:   "prod",
+	}
+
+	testCases := []struct {
+		Name        string
+		Key         config.EventKey
+		Sources     []string
+		ExpectedKey string
+		ExpectedMsg bool
+	}{
+		{
+			Name:        "Cluster key",
+			Key:         config.ClusterEventKey,
+			Sources:     []string{"k8s-events"},
+			ExpectedKey: "prod",
+			ExpectedMsg: true,
+		},
+		{
+			Name:        "Namespace key",
+			Key:         config.NamespaceEventKey,
+			Sources:     []string{"k8s-events"},
+			ExpectedKey: "default",
+			ExpectedMsg: true,
+		},
+		{
+			Name:        "Kind key",
+			Key:         config.KindEventKey,
+			Sources:     []string{"k8s-events"},
+			ExpectedKey: "Pod",
+			ExpectedMsg: true,
+		},
+		{
+			Name:        "No key",
+			Sources:     []string{"k8s-events"},
+			ExpectedMsg: true,
+		},
+		{
+			Name:    "Not bound source",
+			Key:     config.ClusterEventKey,
+			Sources: []string{"other"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			broker := &fakeKafkaBroker{}
+			sink, err := newKafka(loggerx.NewNoop(), config.Kafka{
+				Topic:    "botkube",
+				Key:      tc.Key,
+				Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+			}, broker, &fakeAnalyticsReporter{})
+			require.NoError(t, err)
+
+			// when
+			err = sink.SendEvent(context.Background(), evt, tc.Sources)
+
+			// then
+			require.NoError(t, err)
+			if !tc.ExpectedMsg {
+				assert.Empty(t, broker.messages)
+				return
+			}
+
+			require.Len(t, broker.messages, 1)
+			msg := broker.messages[0]
+			assert.Equal(t, tc.ExpectedKey, string(msg.Key))
+
+			var gotEvent event.Event
+			require.NoError(t, json.Unmarshal(msg.Value, &gotEvent))
+			assert.Equal(t, evt, gotEvent)
+		})
+	}
+}
+
+func TestKafka_SendEventError(t *testing.T) {
+	// given
+	broker := &fakeKafkaBroker{err: errors.New("leader not available")}
+	sink, err := newKafka(loggerx.NewNoop(), config.Kafka{
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, broker, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+
+	// then
+	assert.EqualError(t, err, "while sending event to Kafka: leader not available")
+}
+
+// fakeKafkaBroker is an in-process stand-in for Kafka broker.
+type fakeKafkaBroker struct {
+	mu       sync.Mutex
+	err      error
+	messages []kafka.Message
+	closed   bool
+}
+
+func (f *fakeKafkaBroker) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.messages = append(f.messages, msgs...)
+	return nil
+}
+
+func (f *fakeKafkaBroker) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,209 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	lokiPushPath       = "/loki/api/v1/push"
+	lokiTenantIDHeader = "X-Scope-OrgID"
+)
+
+var _ Sink = &Loki{}
+
+// Loki provides integration with Grafana Loki. Events are pushed as JSON log lines,
+// labeled with the cluster, namespace, kind, level and type of a given event.
+type Loki struct {
+	log         logrus.FieldLogger
+	reporter    AnalyticsReporter
+	transformer *EventTransformer
+	pushURL     string
+	username    string
+	password    string
+	headers     map[string]string
+	labels      map[string]string
+	bindings    config.SinkBindings
+}
+
+// LokiPushRequest is the Loki push API request body.
+type LokiPushRequest struct {
+	Streams []LokiStream `json:"streams"`
+}
+
+// LokiStream is a single Loki log stream with its labels and entries.
+type LokiStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values are the log entries in the [<unix epoch in nanoseconds>, <log line>] format.
+	Values [][2]string `json:"values"`
+}
+
+// NewLoki creates a new Loki instance.
+func NewLoki(log logrus.FieldLogger, c config.Loki, reporter AnalyticsReporter) (*Loki, error) {
+	headers := make(map[string]string, len(c.Headers)+1)
+	for key, value := range c.Headers {
+		headers[key] = value
+	}
+	if c.TenantID != "" {
+		headers[lokiTenantIDHeader] = c.TenantID
+	}
+
+	lokiNotifier := &Loki{
+		log:      log,
+		reporter: reporter,
+		pushURL:  strings.TrimSuffix(c.URL, "/") + lokiPushPath,
+		username: c.Username,
+		password: c.Password,
+		headers:  headers,
+		labels:   c.Labels,
+		bindings: c.Bindings,
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	lokiNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(lokiNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return lokiNotifier, nil
+}
+
+// SendEvent pushes event to Loki.
+func (l *Loki) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(l.bindings.Sources, eventSources) {
+		l.log.Debugf("Event sources do not match Loki sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = l.transformer.Apply(event)
+
+	line, err := json.Marshal(newWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	if err := l.push(ctx, l.streamLabels(event), eventTime(event), line); err != nil {
+		return fmt.Errorf("while sending event to Loki: %w", err)
+	}
+
+	l.log.Debugf("Event successfully sent to Loki: %+v", event)
+	return nil
+}
+
+// SendAuditRecord pushes the command execution audit record to Loki, labeled with the `audit` type.
+func (l *Loki) SendAuditRecord(ctx context.Context, record audit.Record) error {
+	line, err := marshalAuditPayload(record)
+	if err != nil {
+		return err
+	}
+
+	level := config.Info
+	if !record.Success {
+		level = config.Error
+	}
+	labels := l.withStaticLabels(map[string]string{
+		"cluster": record.Cluster,
+		"level":   string(level),
+		"type":    AuditRecordType,
+	})
+
+	if err := l.push(ctx, labels, record.Timestamp, line); err != nil {
+		return fmt.Errorf("while sending audit record to Loki: %w", err)
+	}
+	return nil
+}
+
+func (l *Loki) push(ctx context.Context, labels map[string]string, timestamp time.Time, line []byte) error {
+	body, err := json.Marshal(LokiPushRequest{
+		Streams: []LokiStream{
+			{
+				Stream: labels,
+				Values: [][2]string{
+					{strconv.FormatInt(timestamp.UnixNano(), 10), string(line)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling Loki push request: %w", err)
+	}
+
+	return postJSON(ctx, l.pushURL, l.headers, l.username, l.password, body)
+}
+
+// streamLabels returns the static labels merged with the labels derived from a given event.
+func (l *Loki) streamLabels(event event.Event) map[string]string {
+	return l.withStaticLabels(map[string]string{
+		"cluster":   event.Cluster,
+		"namespace": event.Namespace,
+		"kind":      event.Kind,
+		"level":     string(event.Level),
+		"type":      string(event.Type),
+	})
+}
+
+// withStaticLabels returns the static labels merged with given labels. Empty values are skipped, as Loki rejects them.
+func (l *Loki) withStaticLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(l.labels)+len(labels))
+	for key, value := range l.labels {
+		out[key] = value
+	}
+
+	for key, value := range labels {
+		if value == "" {
+			continue
+		}
+		out[key] = value
+	}
+
+	if len(out) == 0 {
+		// a stream must have at least one label
+		out["job"] = "botkube"
+	}
+	return out
+}
+
+// eventTime returns the event timestamp, or the current time if it's not set.
+func eventTime(event event.Event) time.Time {
+	if event.TimeStamp.IsZero() {
+		return time.Now()
+	}
+	return event.TimeStamp
+}
+
+// SendMessageToAll is no-op.
+func (l *Loki) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (l *Loki) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (l *Loki) IntegrationName() config.CommPlatformIntegration {
+	return config.LokiCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (l *Loki) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
@@ -0,0 +1,130 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestLoki_SendEvent(t *testing.T) {
+	// given
+	var (
+		gotPath     string
+		gotTenantID string
+		gotUser     string
+		gotPass     string
+		gotReq      LokiPushRequest
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotTenantID = r.Header.Get(lokiTenantIDHeader)
+		gotUser, gotPass, _ = r.BasicAuth()
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	sink, err := NewLoki(loggerx.NewNoop(), config.Loki{
+		URL:      ts.URL + "/",
+		TenantID: "tenant-1",
+		Username: "user",
+		Password: "pass",
+		Labels:   map[string]string{"job": "botkube"},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	timestamp := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	evt := event.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "foo",
+		Namespace: "default",
+		Cluster:   "prod",
+		Level:     config.Error,
+		Type:      config.ErrorEvent,
+		TimeStamp: timestamp,
+	}
+
+	// when
+	err = sink.SendEvent(context.Background(), evt, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, lokiPushPath, gotPath)
+	assert.Equal(t, "tenant-1", gotTenantID)
+	assert.Equal(t, "user", gotUser)
+	assert.Equal(t, "pass", gotPass)
+
+	require.Len(t, gotReq.Streams, 1)
+	assert.Equal(t, map[string]string{
+		"job":       "botkube",
+		"cluster":   "prod",
+		"namespace": "default",
+		"kind":      "Pod",
+		"level":     "error",
+		"type":      "error",
+	}, gotReq.Streams[0].Stream)
+
+	require.Len(t, gotReq.Streams[0].Values, 1)
+	assert.Equal(t, "1664625600000000000", gotReq.Streams[0].Values[0][0])
+
+	var line WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(gotReq.Streams[0].Values[0][1]), &line))
+	assert.Equal(t, EventMeta{Kind: "Pod", Name: "foo", Namespace: "default", Cluster: "prod"}, line.EventMeta)
+}
+
+func TestLoki_SendEventSkipsEmptyLabels(t *testing.T) {
+	// given
+	var gotReq LokiPushRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	sink, err := NewLoki(loggerx.NewNoop(), config.Loki{
+		URL:      ts.URL,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), event.Event{TypeMeta: metaV1.TypeMeta{Kind: "Node"}, Name: "foo"}, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, gotReq.Streams, 1)
+	assert.Equal(t, map[string]string{"kind": "Node"}, gotReq.Streams[0].Stream)
+}
+
+func TestLoki_SendEventError(t *testing.T) {
+	// given
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("entry out of order\n"))
+	}))
+	defer ts.Close()
+
+	sink, err := NewLoki(loggerx.NewNoop(), config.Loki{
+		URL:      ts.URL,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+
+	// then
+	assert.EqualError(t, err, "while sending event to Loki: got unexpected status code 400: entry out of order")
+}
@@ -0,0 +1,193 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+var _ Sink = &NATS{}
+
+// natsPublisher publishes messages to NATS. It's implemented by nats.Conn.
+type natsPublisher interface {
+	PublishMsg(msg *nats.Msg) error
+	Drain() error
+}
+
+// NATS provides integration with NATS. Events are published as JSON to a given subject.
+type NATS struct {
+	log         logrus.FieldLogger
+	reporter    AnalyticsReporter
+	transformer *EventTransformer
+	publisher   natsPublisher
+	subject     string
+	key         config.EventKey
+	bindings    config.SinkBindings
+}
+
+// NewNATS creates a new NATS instance.
+func NewNATS(log logrus.FieldLogger, c config.NATS, reporter AnalyticsReporter) (*NATS, error) {
+	opts := []nats.Option{
+		nats.Name("Botkube"),
+		// don't fail on startup if NATS is not available yet
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Errorf("Disconnected from NATS: %s", err.Error())
+			}
+		}),
+		nats.ReconnectHandler(func(_ *nats.Conn) {
+			log.Info("Reconnected to NATS")
+		}),
+	}
+	if c.Username != "" {
+		opts = append(opts, nats.UserInfo(c.Username, c.Password))
+	}
+	if c.Token != "" {
+		opts = append(opts, nats.Token(c.Token))
+	}
+
+	tlsCfg, err := newStreamTLSConfig(c.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("while creating TLS configuration: %w", err)
+	}
+	if tlsCfg != nil {
+		opts = append(opts, nats.Secure(tlsCfg))
+	}
+
+	conn, err := nats.Connect(c.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("while connecting to NATS: %w", err)
+	}
+
+	return newNATS(log, c, conn, reporter)
+}
+
+func newNATS(log logrus.FieldLogger, c config.NATS, publisher natsPublisher, reporter AnalyticsReporter) (*NATS, error) {
+	natsNotifier := &NATS{
+		log:       log,
+		reporter:  reporter,
+		publisher: publisher,
+		subject:   c.Subject,
+		key:       c.Key,
+		bindings:  c.Bindings,
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	natsNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(natsNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return natsNotifier, nil
+}
+
+// Start waits for the context cancellation and drains the NATS connection, so the pending messages are flushed.
+func (n *NATS) Start(ctx context.Context) error {
+	<-ctx.Done()
+	if err := n.publisher.Drain(); err != nil {
+		return fmt.Errorf("while draining NATS connection: %w", err)
+	}
+	return nil
+}
+
+// SendEvent publishes event to NATS subject.
+func (n *NATS) SendEvent(_ context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(n.bindings.Sources, eventSources) {
+		n.log.Debugf("Event sources do not match NATS sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = n.transformer.Apply(event)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	msg := nats.NewMsg(n.subjectFor(event))
+	msg.Data = data
+	msg.Header.Set("Content-Type", "application/json")
+
+	if err := n.publisher.PublishMsg(msg); err != nil {
+		return fmt.Errorf("while sending event to NATS: %w", err)
+	}
+
+	n.log.Debugf("Event successfully sent to NATS: %+v", event)
+	return nil
+}
+
+// SendAuditRecord publishes the command execution audit record to NATS subject.
+func (n *NATS) SendAuditRecord(_ context.Context, record audit.Record) error {
+	data, err := marshalAuditPayload(record)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(n.subjectForKey(auditKey(n.key, record)))
+	msg.Data = data
+	msg.Header.Set("Content-Type", "application/json")
+	msg.Header.Set(RecordTypeHeader, AuditRecordType)
+
+	if err := n.publisher.PublishMsg(msg); err != nil {
+		return fmt.Errorf("while sending audit record to NATS: %w", err)
+	}
+	return nil
+}
+
+// subjectFor returns the subject with the event key appended as the last token, if configured.
+func (n *NATS) subjectFor(event event.Event) string {
+	return n.subjectForKey(eventKey(n.key, event))
+}
+
+func (n *NATS) subjectForKey(key string) string {
+	if key == "" {
+		return n.subject
+	}
+
+	// dots, wildcards and whitespaces are not allowed in a single subject token
+	key = strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, key)
+	return n.subject + "." + key
+}
+
+// SendMessageToAll is no-op.
+func (n *NATS) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (n *NATS) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (n *NATS) IntegrationName() config.CommPlatformIntegration {
+	return config.NATSCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (n *NATS) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
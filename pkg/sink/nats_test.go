@@ -0,0 +1,114 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestNATS_SendEvent(t *testing.T) {
+	// given
+	evt := event.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "foo",
+		Namespace: "default",
+		Cluster:   "prod.eu-west 1",
+	}
+
+	testCases := []struct {
+		Name            string
+		Key             config.EventKey
+		ExpectedSubject string
+	}{
+		{
+			Name:            "No key",
+			ExpectedSubject: "botkube.events",
+		},
+		{
+			Name:            "Namespace key",
+			Key:             config.NamespaceEventKey,
+			ExpectedSubject: "botkube.events.default",
+		},
+		{
+			Name:            "Sanitized cluster key",
+			Key:             config.ClusterEventKey,
+			ExpectedSubject: "botkube.events.prod_eu-west_1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			server := &fakeNATSServer{}
+			sink, err := newNATS(loggerx.NewNoop(), config.NATS{
+				Subject:  "botkube.events",
+				Key:      tc.Key,
+				Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+			}, server, &fakeAnalyticsReporter{})
+			require.NoError(t, err)
+
+			// when
+			err = sink.SendEvent(context.Background(), evt, []string{"k8s-events"})
+			require.NoError(t, err)
+			err = sink.SendEvent(context.Background(), evt, []string{"other"})
+			require.NoError(t, err)
+
+			// then
+			require.Len(t, server.messages, 1)
+			msg := server.messages[0]
+			assert.Equal(t, tc.ExpectedSubject, msg.Subject)
+			assert.Equal(t, "application/json", msg.Header.Get("Content-Type"))
+
+			var gotEvent event.Event
+			require.NoError(t, json.Unmarshal(msg.Data, &gotEvent))
+			assert.Equal(t, evt, gotEvent)
+		})
+	}
+}
+
+func TestNATS_StartDrainsConnection(t *testing.T) {
+	// given
+	server := &fakeNATSServer{}
+	sink, err := newNATS(loggerx.NewNoop(), config.NATS{}, server, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// when
+	err = sink.Start(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, server.drained)
+}
+
+// fakeNATSServer is an in-process stand-in for NATS server.
+type fakeNATSServer struct {
+	mu       sync.Mutex
+	messages []*nats.Msg
+	drained  bool
+}
+
+func (f *fakeNATSServer) PublishMsg(msg *nats.Msg) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.messages = append(f.messages, msg)
+	return nil
+}
+
+func (f *fakeNATSServer) Drain() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drained = true
+	return nil
+}
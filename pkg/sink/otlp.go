@@ -0,0 +1,272 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/version"
+)
+
+const (
+	otlpLogsPath           = "/v1/logs"
+	otlpServiceName        = "botkube"
+	otlpInstrumentationLib = "github.com/kubeshop/botkube/pkg/sink"
+)
+
+var _ Sink = &OTLPLogs{}
+
+// OTLPLogs provides integration with OpenTelemetry. Events are exported as log records using OTLP/HTTP with JSON encoding.
+type OTLPLogs struct {
+	log                logrus.FieldLogger
+	reporter           AnalyticsReporter
+	transformer        *EventTransformer
+	logsURL            string
+	headers            map[string]string
+	resourceAttributes []OTLPKeyValue
+	bindings           config.SinkBindings
+}
+
+// OTLPLogsRequest is the OTLP ExportLogsServiceRequest in the JSON encoding.
+type OTLPLogsRequest struct {
+	ResourceLogs []OTLPResourceLogs `json:"resourceLogs"`
+}
+
+// OTLPResourceLogs is a collection of log records produced by a given resource.
+type OTLPResourceLogs struct {
+	Resource  OTLPResource    `json:"resource"`
+	ScopeLogs []OTLPScopeLogs `json:"scopeLogs"`
+}
+
+// OTLPResource describes the entity producing the logs.
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes"`
+}
+
+// OTLPScopeLogs is a collection of log records produced by a given instrumentation scope.
+type OTLPScopeLogs struct {
+	Scope      OTLPScope       `json:"scope"`
+	LogRecords []OTLPLogRecord `json:"logRecords"`
+}
+
+// OTLPScope describes the instrumentation scope.
+type OTLPScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// OTLPLogRecord is a single OTLP log record.
+type OTLPLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 OTLPAnyValue   `json:"body"`
+	Attributes           []OTLPKeyValue `json:"attributes"`
+}
+
+// OTLPKeyValue is a single OTLP attribute.
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue is an OTLP attribute value. Only the string values are used.
+type OTLPAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// NewOTLPLogs creates a new OTLPLogs instance.
+func NewOTLPLogs(log logrus.FieldLogger, c config.OTLPLogs, reporter AnalyticsReporter) (*OTLPLogs, error) {
+	resourceAttrs := map[string]string{
+		"service.name":    otlpServiceName,
+		"service.version": version.Info().Version,
+	}
+	for key, value := range c.ResourceAttributes {
+		resourceAttrs[key] = value
+	}
+
+	otlpNotifier := &OTLPLogs{
+		log:                log,
+		reporter:           reporter,
+		logsURL:            strings.TrimSuffix(c.Endpoint, "/") + otlpLogsPath,
+		headers:            c.Headers,
+		resourceAttributes: otlpAttributes(resourceAttrs),
+		bindings:           c.Bindings,
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	otlpNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(otlpNotifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return otlpNotifier, nil
+}
+
+// SendEvent exports event as OTLP log record.
+func (o *OTLPLogs) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(o.bindings.Sources, eventSources) {
+		o.log.Debugf("Event sources do not match OTLP logs sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = o.transformer.Apply(event)
+
+	record, err := o.logRecord(event)
+	if err != nil {
+		return err
+	}
+
+	if err := o.export(ctx, record); err != nil {
+		return fmt.Errorf("while sending event to OTLP logs endpoint: %w", err)
+	}
+
+	o.log.Debugf("Event successfully sent to OTLP logs endpoint: %+v", event)
+	return nil
+}
+
+// SendAuditRecord exports the command execution audit record as OTLP log record.
+func (o *OTLPLogs) SendAuditRecord(ctx context.Context, record audit.Record) error {
+	line, err := marshalAuditPayload(record)
+	if err != nil {
+		return err
+	}
+
+	level := config.Info
+	if !record.Success {
+		level = config.Error
+	}
+	severityNumber, severityText := otlpSeverity(level)
+	timestamp := strconv.FormatInt(record.Timestamp.UnixNano(), 10)
+
+	logRecord := OTLPLogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       severityNumber,
+		SeverityText:         severityText,
+		Body:                 OTLPAnyValue{StringValue: string(line)},
+		Attributes: otlpAttributes(map[string]string{
+			"k8s.cluster.name": record.Cluster,
+			"event.type":       AuditRecordType,
+			"enduser.id":       record.UserID,
+		}),
+	}
+	if err := o.export(ctx, logRecord); err != nil {
+		return fmt.Errorf("while sending audit record to OTLP logs endpoint: %w", err)
+	}
+	return nil
+}
+
+func (o *OTLPLogs) export(ctx context.Context, record OTLPLogRecord) error {
+	body, err := json.Marshal(OTLPLogsRequest{
+		ResourceLogs: []OTLPResourceLogs{
+			{
+				Resource: OTLPResource{Attributes: o.resourceAttributes},
+				ScopeLogs: []OTLPScopeLogs{
+					{
+						Scope:      OTLPScope{Name: otlpInstrumentationLib, Version: version.Info().Version},
+						LogRecords: []OTLPLogRecord{record},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling OTLP logs request: %w", err)
+	}
+
+	return postJSON(ctx, o.logsURL, o.headers, "", "", body)
+}
+
+func (o *OTLPLogs) logRecord(event event.Event) (OTLPLogRecord, error) {
+	line, err := json.Marshal(newWebhookPayload(event))
+	if err != nil {
+		return OTLPLogRecord{}, fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	severityNumber, severityText := otlpSeverity(event.Level)
+	timestamp := strconv.FormatInt(eventTime(event).UnixNano(), 10)
+	return OTLPLogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       severityNumber,
+		SeverityText:         severityText,
+		Body:                 OTLPAnyValue{StringValue: string(line)},
+		// attribute names follow the OpenTelemetry semantic conventions where possible
+		Attributes: otlpAttributes(map[string]string{
+			"k8s.cluster.name":   event.Cluster,
+			"k8s.namespace.name": event.Namespace,
+			"k8s.object.kind":    event.Kind,
+			"k8s.object.name":    event.Name,
+			"event.type":         string(event.Type),
+			"event.reason":       event.Reason,
+		}),
+	}, nil
+}
+
+// otlpSeverity maps the event level to the OTLP severity number and text.
+func otlpSeverity(level config.Level) (int, string) {
+	switch level {
+	case config.Debug:
+		return 5, "DEBUG"
+	case config.Warn:
+		return 13, "WARN"
+	case config.Error:
+		return 17, "ERROR"
+	case config.Critical:
+		return 21, "FATAL"
+	default:
+		return 9, "INFO"
+	}
+}
+
+// otlpAttributes converts a given map to OTLP attributes sorted by key. Empty values are skipped.
+func otlpAttributes(in map[string]string) []OTLPKeyValue {
+	out := make([]OTLPKeyValue, 0, len(in))
+	for key, value := range in {
+		if value == "" {
+			continue
+		}
+		out = append(out, OTLPKeyValue{Key: key, Value: OTLPAnyValue{StringValue: value}})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// SendMessageToAll is no-op.
+func (o *OTLPLogs) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (o *OTLPLogs) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (o *OTLPLogs) IntegrationName() config.CommPlatformIntegration {
+	return config.OTLPLogsCommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (o *OTLPLogs) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
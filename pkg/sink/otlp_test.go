@@ -0,0 +1,106 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestOTLPLogs_SendEvent(t *testing.T) {
+	// given
+	var (
+		gotPath   string
+		gotHeader string
+		gotReq    OTLPLogsRequest
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotHeader = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotReq))
+	}))
+	defer ts.Close()
+
+	sink, err := NewOTLPLogs(loggerx.NewNoop(), config.OTLPLogs{
+		Endpoint:           ts.URL,
+		Headers:            map[string]string{"Authorization": "Bearer token"},
+		ResourceAttributes: map[string]string{"deployment.environment": "prod"},
+		Bindings:           config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	evt := event.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "Pod"},
+		Name:      "foo",
+		Namespace: "default",
+		Cluster:   "prod",
+		Reason:    "BackOff",
+		Level:     config.Warn,
+		Type:      config.WarningEvent,
+		TimeStamp: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	// when
+	err = sink.SendEvent(context.Background(), evt, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, otlpLogsPath, gotPath)
+	assert.Equal(t, "Bearer token", gotHeader)
+
+	require.Len(t, gotReq.ResourceLogs, 1)
+	resourceLogs := gotReq.ResourceLogs[0]
+	assert.Contains(t, resourceLogs.Resource.Attributes, OTLPKeyValue{Key: "service.name", Value: OTLPAnyValue{StringValue: "botkube"}})
+	assert.Contains(t, resourceLogs.Resource.Attributes, OTLPKeyValue{Key: "deployment.environment", Value: OTLPAnyValue{StringValue: "prod"}})
+
+	require.Len(t, resourceLogs.ScopeLogs, 1)
+	require.Len(t, resourceLogs.ScopeLogs[0].LogRecords, 1)
+	record := resourceLogs.ScopeLogs[0].LogRecords[0]
+	assert.Equal(t, "1664625600000000000", record.TimeUnixNano)
+	assert.Equal(t, 13, record.SeverityNumber)
+	assert.Equal(t, "WARN", record.SeverityText)
+	assert.Equal(t, []OTLPKeyValue{
+		{Key: "event.reason", Value: OTLPAnyValue{StringValue: "BackOff"}},
+		{Key: "event.type", Value: OTLPAnyValue{StringValue: "warning"}},
+		{Key: "k8s.cluster.name", Value: OTLPAnyValue{StringValue: "prod"}},
+		{Key: "k8s.namespace.name", Value: OTLPAnyValue{StringValue: "default"}},
+		{Key: "k8s.object.kind", Value: OTLPAnyValue{StringValue: "Pod"}},
+		{Key: "k8s.object.name", Value: OTLPAnyValue{StringValue: "foo"}},
+	}, record.Attributes)
+
+	var body WebhookPayload
+	require.NoError(t, json.Unmarshal([]byte(record.Body.StringValue), &body))
+	assert.Equal(t, config.Warn, body.EventStatus.Level)
+}
+
+func TestOTLPLogs_SendEventNotBoundSource(t *testing.T) {
+	// given
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer ts.Close()
+
+	sink, err := NewOTLPLogs(loggerx.NewNoop(), config.OTLPLogs{
+		Endpoint: ts.URL,
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = sink.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"other"})
+
+	// then
+	require.NoError(t, err)
+	assert.Zero(t, calls)
+}
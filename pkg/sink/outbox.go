@@ -0,0 +1,370 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/pkg/audit"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+const (
+	defaultOutboxMaxBytes      = 100 * 1024 * 1024 // 100MiB
+	defaultOutboxMaxAge        = 72 * time.Hour
+	defaultOutboxRetryInterval = 30 * time.Second
+
+	outboxFileExt    = ".json"
+	outboxTmpFileExt = ".tmp"
+
+	outboxDropReasonSize    = "size"
+	outboxDropReasonAge     = "age"
+	outboxDropReasonInvalid = "invalid"
+)
+
+var (
+	outboxEvents = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "botkube_sink_outbox_events",
+		Help: "Number of undelivered events stored in the sink outbox.",
+	}, []string{"sink"})
+	outboxBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "botkube_sink_outbox_bytes",
+		Help: "Size in bytes of undelivered events stored in the sink outbox.",
+	}, []string{"sink"})
+	outboxDroppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "botkube_sink_outbox_dropped_events_total",
+		Help: "Number of events dropped from the sink outbox.",
+	}, []string{"sink", "reason"})
+)
+
+// OutboxEntry is an undelivered event stored in the outbox.
+type OutboxEntry struct {
+	Event event.Event `json:"event"`
+	// Object and ObjectMeta hold the event fields which are skipped when the event is marshaled.
+	// They are restored when the entry is read, so they are available e.g. in the Webhook payload template.
+	Object     any               `json:"object,omitempty"`
+	ObjectMeta metaV1.ObjectMeta `json:"objectMeta"`
+	Sources    []string          `json:"sources"`
+	// AuditRecord is set if the entry holds a command execution audit record instead of an event.
+	AuditRecord *audit.Record `json:"auditRecord,omitempty"`
+	// Destination is an optional sink-specific target, e.g. the Elasticsearch index alias.
+	Destination string    `json:"destination,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// OutboxDeliverFunc delivers a given entry synchronously. The entry is removed from the outbox only if no error is returned.
+type OutboxDeliverFunc func(ctx context.Context, entry OutboxEntry) error
+
+// Outbox is a disk-backed FIFO queue for events which couldn't be delivered by a given sink.
+// Each entry is stored in a separate file, named after a monotonically increasing sequence number.
+// The stored events are replayed in order with Start.
+type Outbox struct {
+	log           logrus.FieldLogger
+	name          string
+	dir           string
+	maxBytes      int64
+	maxAge        time.Duration
+	retryInterval time.Duration
+	deliver       OutboxDeliverFunc
+	notifyCh      chan struct{}
+
+	mu    sync.Mutex
+	files []outboxFile
+	bytes int64
+	seq   uint64
+}
+
+type outboxFile struct {
+	name      string
+	size      int64
+	createdAt time.Time
+}
+
+// NewOutbox creates a new Outbox instance and loads the entries already stored in the `{cfg.Dir}/{commGroupName}/{sinkName}` directory.
+func NewOutbox(log logrus.FieldLogger, commGroupName, sinkName string, cfg config.SinkOutbox, deliver OutboxDeliverFunc) (*Outbox, error) {
+	o := &Outbox{
+		log:           log,
+		name:          commGroupName + "/" + sinkName,
+		dir:           filepath.Join(cfg.Dir, commGroupName, sinkName),
+		maxBytes:      cfg.MaxBytes,
+		maxAge:        cfg.MaxAge,
+		retryInterval: cfg.RetryInterval,
+		deliver:       deliver,
+		notifyCh:      make(chan struct{}, 1),
+	}
+	if o.maxBytes <= 0 {
+		o.maxBytes = defaultOutboxMaxBytes
+	}
+	if o.maxAge <= 0 {
+		o.maxAge = defaultOutboxMaxAge
+	}
+	if o.retryInterval <= 0 {
+		o.retryInterval = defaultOutboxRetryInterval
+	}
+
+	if err := os.MkdirAll(o.dir, 0o750); err != nil {
+		return nil, fmt.Errorf("while creating outbox directory: %w", err)
+	}
+	if err := o.load(); err != nil {
+		return nil, fmt.Errorf("while loading outbox entries: %w", err)
+	}
+
+	return o, nil
+}
+
+// Empty returns true if there are no undelivered events.
+func (o *Outbox) Empty() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.files) == 0
+}
+
+// Len returns the number of undelivered events.
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.files)
+}
+
+// Push stores a given entry. If the outbox exceeds the maximum size, the oldest entries are dropped.
+func (o *Outbox) Push(entry OutboxEntry) error {
+	_, err := o.push(entry, false)
+	return err
+}
+
+// PushIfNotEmpty stores a given entry only if there are undelivered events, so that the events order is kept until
+// the outbox is drained. The check and the write are done atomically. It returns true if the entry was stored.
+func (o *Outbox) PushIfNotEmpty(entry OutboxEntry) (bool, error) {
+	return o.push(entry, true)
+}
+
+func (o *Outbox) push(entry OutboxEntry, onlyIfNotEmpty bool) (bool, error) {
+	if onlyIfNotEmpty && o.Empty() {
+		// fast path, which avoids marshaling the entry
+		return false, nil
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return false, fmt.Errorf("while marshaling outbox entry: %w", err)
+	}
+	size := int64(len(data))
+	if size > o.maxBytes {
+		outboxDroppedEvents.WithLabelValues(o.name, outboxDropReasonSize).Inc()
+		return false, fmt.Errorf("outbox entry size %d exceeds the outbox capacity %d", size, o.maxBytes)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.evictExpired()
+	if onlyIfNotEmpty && len(o.files) == 0 {
+		return false, nil
+	}
+	for len(o.files) > 0 && o.bytes+size > o.maxBytes {
+		o.removeHead(outboxDropReasonSize)
+	}
+
+	name := fmt.Sprintf("%020d%s", o.seq, outboxFileExt)
+	tmpPath := filepath.Join(o.dir, name+outboxTmpFileExt)
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return false, fmt.Errorf("while writing outbox entry: %w", err)
+	}
+	// rename is atomic, so partially written entries are never loaded
+	if err := os.Rename(tmpPath, filepath.Join(o.dir, name)); err != nil {
+		return false, fmt.Errorf("while writing outbox entry: %w", err)
+	}
+
+	o.seq++
+	o.files = append(o.files, outboxFile{name: name, size: size, createdAt: entry.CreatedAt})
+	o.bytes += size
+	o.updateMetrics()
+
+	select {
+	case o.notifyCh <- struct{}{}:
+	default:
+	}
+	return true, nil
+}
+
+// Start replays the stored events in order. If the delivery fails, it's retried after the retry interval.
+func (o *Outbox) Start(ctx context.Context) error {
+	o.log.Infof("Starting outbox with %d stored events...", o.Len())
+	ticker := time.NewTicker(o.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		o.replay(ctx)
+
+		select {
+		case <-ctx.Done():
+			o.log.Info("Shutdown requested. Finishing...")
+			return nil
+		case <-ticker.C:
+		case <-o.notifyCh:
+		}
+	}
+}
+
+func (o *Outbox) replay(ctx context.Context) {
+	for ctx.Err() == nil {
+		head, found := o.head()
+		if !found {
+			return
+		}
+
+		entry, err := o.read(head)
+		if err != nil {
+			o.log.Errorf("while reading outbox entry %q: %s", head.name, err.Error())
+			o.remove(head, outboxDropReasonInvalid)
+			continue
+		}
+
+		if err := o.deliver(ctx, entry); err != nil {
+			o.log.Debugf("while delivering outbox entry %q: %s. Retrying in %s", head.name, err.Error(), o.retryInterval)
+			return
+		}
+		o.remove(head, "")
+	}
+}
+
+func (o *Outbox) head() (outboxFile, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.evictExpired()
+	if len(o.files) == 0 {
+		return outboxFile{}, false
+	}
+	return o.files[0], true
+}
+
+func (o *Outbox) read(file outboxFile) (OutboxEntry, error) {
+	data, err := os.ReadFile(filepath.Join(o.dir, file.name))
+	if err != nil {
+		return OutboxEntry{}, err
+	}
+
+	var entry OutboxEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return OutboxEntry{}, err
+	}
+
+	entry.Event.ObjectMeta = entry.ObjectMeta
+	if obj, ok := entry.Object.(map[string]any); ok {
+		// objects are watched with the dynamic informers
+		entry.Event.Object = &unstructured.Unstructured{Object: obj}
+	}
+	return entry, nil
+}
+
+// remove removes a given file if it is still the head of the queue, as it could be evicted in the meantime.
+func (o *Outbox) remove(file outboxFile, dropReason string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.files) == 0 || o.files[0].name != file.name {
+		return
+	}
+	o.removeHead(dropReason)
+}
+
+// removeHead removes the oldest entry. It must be called with the lock held.
+func (o *Outbox) removeHead(dropReason string) {
+	head := o.files[0]
+	if err := os.Remove(filepath.Join(o.dir, head.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		o.log.Errorf("while removing outbox entry %q: %s", head.name, err.Error())
+	}
+
+	o.files = o.files[1:]
+	o.bytes -= head.size
+	if dropReason != "" {
+		o.log.Warnf("Dropping undelivered event %q from outbox (reason: %s)", head.name, dropReason)
+		outboxDroppedEvents.WithLabelValues(o.name, dropReason).Inc()
+	}
+	o.updateMetrics()
+}
+
+// evictExpired removes entries older than the maximum age. It must be called with the lock held.
+func (o *Outbox) evictExpired() {
+	for len(o.files) > 0 && time.Since(o.files[0].createdAt) > o.maxAge {
+		o.removeHead(outboxDropReasonAge)
+	}
+}
+
+func (o *Outbox) load() error {
+	dirEntries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, outboxTmpFileExt) {
+			// leftover after a crash during write
+			_ = os.Remove(filepath.Join(o.dir, name))
+			continue
+		}
+
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxFileExt), 10, 64)
+		if err != nil || !strings.HasSuffix(name, outboxFileExt) {
+			o.log.Warnf("Ignoring unknown file %q in outbox directory", name)
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+
+		o.files = append(o.files, outboxFile{name: name, size: info.Size(), createdAt: info.ModTime()})
+		o.bytes += info.Size()
+		if seq >= o.seq {
+			o.seq = seq + 1
+		}
+	}
+
+	// names are zero-padded, so the lexical order is the same as the sequence order
+	sort.Slice(o.files, func(i, j int) bool {
+		return o.files[i].name < o.files[j].name
+	})
+	o.updateMetrics()
+	return nil
+}
+
+func (o *Outbox) updateMetrics() {
+	outboxEvents.WithLabelValues(o.name).Set(float64(len(o.files)))
+	outboxBytes.WithLabelValues(o.name).Set(float64(o.bytes))
+}
+
+func newOutboxEntry(event event.Event, sources []string, destination string) OutboxEntry {
+	return OutboxEntry{
+		Event:       event,
+		Object:      event.Object,
+		ObjectMeta:  event.ObjectMeta,
+		Sources:     sources,
+		Destination: destination,
+		CreatedAt:   time.Now(),
+	}
+}
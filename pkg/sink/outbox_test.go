@@ -0,0 +1,291 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestOutbox_ReplaysInOrderAfterRestart(t *testing.T) {
+	// given
+	cfg := config.SinkOutbox{Enabled: true, Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond}
+	failingDeliver := func(context.Context, OutboxEntry) error {
+		return errors.New("destination is down")
+	}
+
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "test-sink", cfg, failingDeliver)
+	require.NoError(t, err)
+
+	for _, name := range []string{"first", "second", "third"} {
+		require.NoError(t, outbox.Push(newOutboxEntry(event.Event{Name: name}, []string{"k8s-events"}, "")))
+	}
+	outbox.replay(context.Background())
+	require.Equal(t, 3, outbox.Len())
+	assert.Equal(t, float64(3), testutil.ToFloat64(outboxEvents.WithLabelValues("test-group/test-sink")))
+
+	var (
+		mu        sync.Mutex
+		delivered []string
+	)
+	deliver := func(_ context.Context, entry OutboxEntry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, entry.Event.Name)
+		return nil
+	}
+
+	// when
+	restarted, err := NewOutbox(loggerx.NewNoop(), "test-group", "test-sink", cfg, deliver)
+	require.NoError(t, err)
+	require.NoError(t, restarted.Push(newOutboxEntry(event.Event{Name: "fourth"}, []string{"k8s-events"}, "")))
+	restarted.replay(context.Background())
+
+	// then
+	assert.Equal(t, []string{"first", "second", "third", "fourth"}, delivered)
+	assert.True(t, restarted.Empty())
+	assert.Zero(t, testutil.ToFloat64(outboxEvents.WithLabelValues("test-group/test-sink")))
+	assert.Zero(t, testutil.ToFloat64(outboxBytes.WithLabelValues("test-group/test-sink")))
+}
+
+func TestOutbox_PushIfNotEmpty(t *testing.T) {
+	// given
+	cfg := config.SinkOutbox{Enabled: true, Dir: t.TempDir()}
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "push-sink", cfg, func(context.Context, OutboxEntry) error {
+		return errors.New("destination is down")
+	})
+	require.NoError(t, err)
+
+	// when
+	storedWhenEmpty, err := outbox.PushIfNotEmpty(newOutboxEntry(event.Event{Name: "first"}, nil, ""))
+	require.NoError(t, err)
+	require.NoError(t, outbox.Push(newOutboxEntry(event.Event{Name: "second"}, nil, "")))
+	storedWhenNotEmpty, err := outbox.PushIfNotEmpty(newOutboxEntry(event.Event{Name: "third"}, nil, ""))
+	require.NoError(t, err)
+
+	// then
+	assert.False(t, storedWhenEmpty)
+	assert.True(t, storedWhenNotEmpty)
+	assert.Equal(t, 2, outbox.Len())
+}
+
+func TestOutbox_RestoresEventObject(t *testing.T) {
+	// given
+	cfg := config.SinkOutbox{Enabled: true, Dir: t.TempDir()}
+	var delivered []event.Event
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "object-sink", cfg, func(_ context.Context, entry OutboxEntry) error {
+		delivered = append(delivered, entry.Event)
+		return nil
+	})
+	require.NoError(t, err)
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "nginx", "namespace": "default"},
+		"spec":       map[string]any{"nodeName": "node-1"},
+	}}
+	objectMeta := metaV1.ObjectMeta{Name: "nginx", Namespace: "default", Labels: map[string]string{"app": "nginx"}}
+
+	// when
+	require.NoError(t, outbox.Push(newOutboxEntry(event.Event{Name: "nginx", Object: obj, ObjectMeta: objectMeta}, nil, "")))
+	outbox.replay(context.Background())
+
+	// then
+	require.Len(t, delivered, 1)
+	assert.Equal(t, obj, delivered[0].Object)
+	assert.Equal(t, objectMeta, delivered[0].ObjectMeta)
+}
+
+func TestOutbox_RestoresAuditRecord(t *testing.T) {
+	// given
+	cfg := config.SinkOutbox{Enabled: true, Dir: t.TempDir()}
+	var delivered []OutboxEntry
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "audit-sink", cfg, func(_ context.Context, entry OutboxEntry) error {
+		delivered = append(delivered, entry)
+		return nil
+	})
+	require.NoError(t, err)
+
+	record := fixAuditRecord()
+	entry := newOutboxEntry(event.Event{}, nil, "botkube")
+	entry.AuditRecord = &record
+
+	// when
+	require.NoError(t, outbox.Push(entry))
+	outbox.replay(context.Background())
+
+	// then
+	require.Len(t, delivered, 1)
+	assert.Equal(t, &record, delivered[0].AuditRecord)
+}
+
+func TestOutbox_EvictsOldestEntries(t *testing.T) {
+	// given
+	entry := newOutboxEntry(event.Event{Name: "foo"}, []string{"k8s-events"}, "")
+	data, err := json.Marshal(entry)
+	require.NoError(t, err)
+
+	cfg := config.SinkOutbox{
+		Enabled: true,
+		Dir:     t.TempDir(),
+		// fits two entries, with a margin for the timestamp length differences
+		MaxBytes: int64(2*len(data) + len(data)/2),
+	}
+
+	var delivered []string
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "evict-sink", cfg, func(_ context.Context, entry OutboxEntry) error {
+		delivered = append(delivered, entry.Event.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	droppedBefore := testutil.ToFloat64(outboxDroppedEvents.WithLabelValues("test-group/evict-sink", outboxDropReasonSize))
+
+	// when
+	for _, name := range []string{"foo", "bar", "baz"} {
+		require.NoError(t, outbox.Push(newOutboxEntry(event.Event{Name: name}, []string{"k8s-events"}, "")))
+	}
+	outbox.replay(context.Background())
+
+	// then
+	assert.Equal(t, []string{"bar", "baz"}, delivered)
+	assert.Equal(t, droppedBefore+1, testutil.ToFloat64(outboxDroppedEvents.WithLabelValues("test-group/evict-sink", outboxDropReasonSize)))
+}
+
+func TestOutbox_DropsExpiredEntries(t *testing.T) {
+	// given
+	cfg := config.SinkOutbox{Enabled: true, Dir: t.TempDir(), MaxAge: time.Hour}
+
+	var delivered []string
+	outbox, err := NewOutbox(loggerx.NewNoop(), "test-group", "age-sink", cfg, func(_ context.Context, entry OutboxEntry) error {
+		delivered = append(delivered, entry.Event.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	expired := newOutboxEntry(event.Event{Name: "expired"}, []string{"k8s-events"}, "")
+	expired.CreatedAt = time.Now().Add(-2 * time.Hour)
+
+	// when
+	require.NoError(t, outbox.Push(expired))
+	require.NoError(t, outbox.Push(newOutboxEntry(event.Event{Name: "fresh"}, []string{"k8s-events"}, "")))
+	outbox.replay(context.Background())
+
+	// then
+	assert.Equal(t, []string{"fresh"}, delivered)
+}
+
+func TestWebhook_StoresUndeliveredEventsInOutbox(t *testing.T) {
+	// given
+	var (
+		available atomic.Bool
+		mu        sync.Mutex
+		received  []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload WebhookPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, payload.EventMeta.Name)
+	}))
+	defer ts.Close()
+
+	// events are not retried on the send path when the outbox is enabled, so default retries don't delay the test
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL:      ts.URL,
+		Outbox:   config.SinkOutbox{Enabled: true, Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	require.NoError(t, wh.SendEvent(context.Background(), event.Event{Name: "first"}, []string{"k8s-events"}))
+	require.NoError(t, wh.SendEvent(context.Background(), event.Event{Name: "second"}, []string{"k8s-events"}))
+	require.Equal(t, 2, wh.outbox.Len())
+
+	available.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = wh.Start(ctx)
+	}()
+
+	// then
+	require.Eventually(t, func() bool {
+		return wh.outbox.Empty()
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"first", "second"}, received)
+}
+
+func TestWebhook_StoresUndeliveredAuditRecordsInOutbox(t *testing.T) {
+	// given
+	var (
+		available atomic.Bool
+		mu        sync.Mutex
+		received  []AuditPayload
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, AuditRecordType, r.Header.Get(RecordTypeHeader))
+		var payload AuditPayload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, payload)
+	}))
+	defer ts.Close()
+
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL:      ts.URL,
+		Outbox:   config.SinkOutbox{Enabled: true, Dir: t.TempDir(), RetryInterval: 10 * time.Millisecond},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}, Audit: true},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	record := fixAuditRecord()
+
+	// when
+	require.NoError(t, wh.SendAuditRecord(context.Background(), record))
+	require.Equal(t, 1, wh.outbox.Len())
+
+	available.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = wh.Start(ctx)
+	}()
+
+	// then
+	require.Eventually(t, func() bool {
+		return wh.outbox.Empty()
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 1)
+	assert.Equal(t, record, received[0].Audit)
+}
@@ -0,0 +1,324 @@
+package sink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+const (
+	defaultS3MaxEvents     = 1000
+	defaultS3FlushInterval = 5 * time.Minute
+
+	// maxS3PendingObjects limits how many objects which failed to upload are kept in memory for the next attempt.
+	maxS3PendingObjects = 100
+	s3ObjectExt         = ".ndjson.gz"
+	unknownS3Partition  = "unknown"
+)
+
+var (
+	s3UploadedObjects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "botkube_s3_uploaded_objects_total",
+		Help: "Number of event archives uploaded to S3.",
+	})
+	s3DroppedEvents = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "botkube_s3_dropped_events_total",
+		Help: "Number of events dropped because their archive couldn't be uploaded to S3.",
+	})
+)
+
+var _ Sink = &S3{}
+
+// s3Uploader uploads objects to S3. It's implemented by s3.S3.
+type s3Uploader interface {
+	PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error)
+}
+
+// S3 provides integration with the S3-compatible object storage. Events are buffered and uploaded as gzip-compressed
+// NDJSON objects, partitioned by cluster, date and hour of the event. The buffered events are uploaded when the batch is full,
+// periodically by Start, and on shutdown with Flush.
+type S3 struct {
+	log           logrus.FieldLogger
+	reporter      AnalyticsReporter
+	transformer   *EventTransformer
+	uploader      s3Uploader
+	bucket        string
+	prefix        string
+	maxEvents     int
+	flushInterval time.Duration
+	bindings      config.SinkBindings
+
+	mu      sync.Mutex
+	batches map[string]*s3Batch
+	// pending holds the objects which failed to upload, so they are retried on the next flush.
+	pending []s3Object
+}
+
+type s3Batch struct {
+	buf    bytes.Buffer
+	gz     *gzip.Writer
+	events int
+}
+
+type s3Object struct {
+	key    string
+	body   []byte
+	events int
+}
+
+// NewS3 creates a new S3 instance.
+func NewS3(log logrus.FieldLogger, c config.S3, reporter AnalyticsReporter) (*S3, error) {
+	awsCfg := aws.NewConfig().
+		WithRegion(c.Region).
+		WithS3ForcePathStyle(c.ForcePathStyle)
+	if c.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(c.Endpoint)
+	}
+	if c.AccessKeyID != "" {
+		awsCfg = awsCfg.WithCredentials(credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, ""))
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("while creating AWS session: %w", err)
+	}
+
+	return newS3(log, c, s3.New(sess), reporter)
+}
+
+func newS3(log logrus.FieldLogger, c config.S3, uploader s3Uploader, reporter AnalyticsReporter) (*S3, error) {
+	s3Notifier := &S3{
+		log:           log,
+		reporter:      reporter,
+		uploader:      uploader,
+		bucket:        c.Bucket,
+		prefix:        strings.Trim(c.Prefix, "/"),
+		maxEvents:     c.Batch.MaxEvents,
+		flushInterval: c.Batch.FlushInterval,
+		bindings:      c.Bindings,
+		batches:       map[string]*s3Batch{},
+	}
+	if s3Notifier.maxEvents <= 0 {
+		s3Notifier.maxEvents = defaultS3MaxEvents
+	}
+	if s3Notifier.flushInterval <= 0 {
+		s3Notifier.flushInterval = defaultS3FlushInterval
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	s3Notifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(s3Notifier.IntegrationName())
+	if err != nil {
+		return nil, fmt.Errorf("while reporting analytics: %w", err)
+	}
+
+	return s3Notifier, nil
+}
+
+// Start uploads the buffered events periodically. The events buffered on shutdown are uploaded with Flush,
+// which is called by the controller before sending the final message.
+func (s *S3) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				s.log.Errorf("while uploading events to S3: %s", err.Error())
+			}
+		}
+	}
+}
+
+// SendEvent adds event to the batch for its partition. If the batch is full, it's uploaded to S3.
+func (s *S3) SendEvent(ctx context.Context, event event.Event, eventSources []string) error {
+	if !sliceutil.Intersect(s.bindings.Sources, eventSources) {
+		s.log.Debugf("Event sources do not match S3 sources, event: %+v, eventSources: %+v", event, eventSources)
+		return nil
+	}
+
+	event = s.transformer.Apply(event)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("while marshaling event: %w", err)
+	}
+
+	partition := s.partitionFor(event)
+
+	s.mu.Lock()
+	batch, found := s.batches[partition]
+	if !found {
+		batch = &s3Batch{}
+		batch.gz = gzip.NewWriter(&batch.buf)
+		s.batches[partition] = batch
+	}
+	if _, err := batch.gz.Write(append(line, '\n')); err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("while compressing event: %w", err)
+	}
+	batch.events++
+
+	var full *s3Object
+	if batch.events >= s.maxEvents {
+		full, err = s.closeBatch(partition, batch)
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if full == nil {
+		return nil
+	}
+	if err := s.upload(ctx, *full); err != nil {
+		s.keepPending(*full)
+		return fmt.Errorf("while uploading events to S3: %w", err)
+	}
+	return nil
+}
+
+// Flush uploads all buffered events and the objects which previously failed to upload.
+func (s *S3) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	objects := s.pending
+	s.pending = nil
+
+	partitions := make([]string, 0, len(s.batches))
+	for partition := range s.batches {
+		partitions = append(partitions, partition)
+	}
+	sort.Strings(partitions)
+
+	errs := multierror.New()
+	for _, partition := range partitions {
+		obj, err := s.closeBatch(partition, s.batches[partition])
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		objects = append(objects, *obj)
+	}
+	s.mu.Unlock()
+
+	for _, obj := range objects {
+		if err := s.upload(ctx, obj); err != nil {
+			s.keepPending(obj)
+			errs = multierror.Append(errs, fmt.Errorf("while uploading %q: %w", obj.key, err))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// closeBatch finishes the batch compression and removes it from buffered batches. It must be called with the lock held.
+func (s *S3) closeBatch(partition string, batch *s3Batch) (*s3Object, error) {
+	delete(s.batches, partition)
+	if err := batch.gz.Close(); err != nil {
+		s3DroppedEvents.Add(float64(batch.events))
+		return nil, fmt.Errorf("while compressing events for %q: %w", partition, err)
+	}
+
+	return &s3Object{
+		key:    s.objectKey(partition),
+		body:   batch.buf.Bytes(),
+		events: batch.events,
+	}, nil
+}
+
+func (s *S3) upload(ctx context.Context, obj s3Object) error {
+	_, err := s.uploader.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(obj.key),
+		Body:            bytes.NewReader(obj.body),
+		ContentType:     aws.String("application/x-ndjson"),
+		ContentEncoding: aws.String("gzip"),
+	})
+	if err != nil {
+		return err
+	}
+
+	s3UploadedObjects.Inc()
+	s.log.Debugf("Successfully uploaded %d events to S3 object %q", obj.events, obj.key)
+	return nil
+}
+
+// keepPending stores the object for the next upload attempt. If there are too many pending objects, the oldest one is dropped.
+func (s *S3) keepPending(obj s3Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, obj)
+	if len(s.pending) > maxS3PendingObjects {
+		dropped := s.pending[0]
+		s.pending = s.pending[1:]
+		s3DroppedEvents.Add(float64(dropped.events))
+		s.log.Errorf("Too many S3 objects pending upload. Dropping %d events from %q...", dropped.events, dropped.key)
+	}
+}
+
+// partitionFor returns the Hive-style partition path for a given event, e.g. `cluster=prod/date=2022-10-01/hour=12`.
+func (s *S3) partitionFor(event event.Event) string {
+	cluster := event.Cluster
+	if cluster == "" {
+		cluster = unknownS3Partition
+	}
+	t := eventTime(event).UTC()
+	return fmt.Sprintf("cluster=%s/date=%s/hour=%s", cluster, t.Format("2006-01-02"), t.Format("15"))
+}
+
+// objectKey returns the unique object key for a given partition.
+func (s *S3) objectKey(partition string) string {
+	// #nosec G404
+	name := fmt.Sprintf("%d-%08x%s", time.Now().UnixNano(), rand.Uint32(), s3ObjectExt)
+	return path.Join(s.prefix, partition, name)
+}
+
+// SendMessageToAll is no-op.
+func (s *S3) SendMessageToAll(_ context.Context, _ interactive.CoreMessage) error {
+	return nil
+}
+
+// SendMessage is no-op.
+func (s *S3) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []string) error {
+	return nil
+}
+
+// IntegrationName describes the notifier integration name.
+func (s *S3) IntegrationName() config.CommPlatformIntegration {
+	return config.S3CommPlatformIntegration
+}
+
+// Type describes the notifier type.
+func (s *S3) Type() config.IntegrationType {
+	return config.SinkIntegrationType
+}
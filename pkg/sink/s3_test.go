@@ -0,0 +1,158 @@
+package sink
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestS3_UploadsPartitionedBatches(t *testing.T) {
+	// given
+	storage := &fakeS3Storage{}
+	sink, err := newS3(loggerx.NewNoop(), config.S3{
+		Bucket:   "archive",
+		Prefix:   "/botkube/events/",
+		Batch:    config.S3Batch{MaxEvents: 2},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, storage, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	firstHour := time.Date(2022, 10, 1, 12, 15, 0, 0, time.UTC)
+	secondHour := firstHour.Add(time.Hour)
+
+	// when
+	for _, evt := range []event.Event{
+		{Name: "foo", Cluster: "prod", TimeStamp: firstHour},
+		{Name: "bar", Cluster: "prod", TimeStamp: secondHour},
+		{Name: "baz", Cluster: "prod", TimeStamp: firstHour},
+		{Name: "not-bound", Cluster: "prod", TimeStamp: firstHour},
+	} {
+		sources := []string{"k8s-events"}
+		if evt.Name == "not-bound" {
+			sources = []string{"other"}
+		}
+		require.NoError(t, sink.SendEvent(context.Background(), evt, sources))
+	}
+
+	// then
+	objects := storage.Objects()
+	require.Len(t, objects, 1, "full batch should be uploaded immediately")
+	assert.True(t, strings.HasPrefix(objects[0].key, "botkube/events/cluster=prod/date=2022-10-01/hour=12/"))
+	assert.True(t, strings.HasSuffix(objects[0].key, ".ndjson.gz"))
+	assert.Equal(t, []string{"foo", "baz"}, objects[0].eventNames)
+
+	// when
+	err = sink.Flush(context.Background())
+
+	// then
+	require.NoError(t, err)
+	objects = storage.Objects()
+	require.Len(t, objects, 2)
+	assert.True(t, strings.HasPrefix(objects[1].key, "botkube/events/cluster=prod/date=2022-10-01/hour=13/"))
+	assert.Equal(t, []string{"bar"}, objects[1].eventNames)
+
+	// when
+	err = sink.Flush(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Len(t, storage.Objects(), 2, "nothing should be uploaded if there are no buffered events")
+}
+
+func TestS3_RetriesFailedUploadsOnFlush(t *testing.T) {
+	// given
+	storage := &fakeS3Storage{err: errors.New("service unavailable")}
+	sink, err := newS3(loggerx.NewNoop(), config.S3{
+		Bucket:   "archive",
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, storage, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"}))
+
+	// when
+	err = sink.Flush(context.Background())
+
+	// then
+	require.Error(t, err)
+	assert.Empty(t, storage.Objects())
+
+	// when
+	storage.SetErr(nil)
+	err = sink.Flush(context.Background())
+
+	// then
+	require.NoError(t, err)
+	objects := storage.Objects()
+	require.Len(t, objects, 1)
+	assert.True(t, strings.HasPrefix(objects[0].key, "cluster=unknown/date="))
+	assert.Equal(t, []string{"foo"}, objects[0].eventNames)
+}
+
+type fakeS3Object struct {
+	key        string
+	eventNames []string
+}
+
+type fakeS3Storage struct {
+	mu      sync.Mutex
+	err     error
+	objects []fakeS3Object
+}
+
+func (f *fakeS3Storage) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	gz, err := gzip.NewReader(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	obj := fakeS3Object{key: aws.StringValue(input.Key)}
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var evt event.Event
+		if err := json.Unmarshal(scanner.Bytes(), &evt); err != nil {
+			return nil, err
+		}
+		obj.eventNames = append(obj.eventNames, evt.Name)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	f.objects = append(f.objects, obj)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3Storage) SetErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *fakeS3Storage) Objects() []fakeS3Object {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeS3Object(nil), f.objects...)
+}
@@ -0,0 +1,56 @@
+package sink
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+// eventKey returns the event property used as the message key for the event streaming sinks.
+func eventKey(key config.EventKey, event event.Event) string {
+	switch key {
+	case config.ClusterEventKey:
+		return event.Cluster
+	case config.NamespaceEventKey:
+		return event.Namespace
+	case config.KindEventKey:
+		return event.Kind
+	default:
+		return ""
+	}
+}
+
+// newStreamTLSConfig returns the TLS configuration for the event streaming sinks. It returns nil if TLS is disabled.
+func newStreamTLSConfig(cfg config.StreamTLS) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	out := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402
+		InsecureSkipVerify: cfg.SkipTLSVerify,
+	}
+
+	if cfg.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACert)) {
+			return nil, errors.New("while parsing CA certificate: no valid PEM certificates found")
+		}
+		out.RootCAs = pool
+	}
+
+	if cfg.Cert != "" || cfg.Key != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.Cert), []byte(cfg.Key))
+		if err != nil {
+			return nil, fmt.Errorf("while parsing client certificate: %w", err)
+		}
+		out.Certificates = []tls.Certificate{cert}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,186 @@
+package sink
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+const defaultRedactionReplacement = "***"
+
+// EventTransformer applies the sink transformation to events before they are serialized.
+// The nil EventTransformer returns events unchanged.
+type EventTransformer struct {
+	// fields holds the indices of the event fields which are cleared.
+	fields      [][]int
+	redactions  []redaction
+	labels      []string
+	annotations []string
+}
+
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// NewEventTransformer creates a new EventTransformer instance. It returns nil if the transformation is not configured.
+func NewEventTransformer(cfg config.SinkTransform) (*EventTransformer, error) {
+	if len(cfg.IncludeFields) == 0 && len(cfg.ExcludeFields) == 0 && len(cfg.Redactions) == 0 &&
+		len(cfg.Labels) == 0 && len(cfg.Annotations) == 0 {
+		return nil, nil
+	}
+
+	fields, err := clearedEventFields(cfg.IncludeFields, cfg.ExcludeFields)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &EventTransformer{
+		fields:      fields,
+		labels:      cfg.Labels,
+		annotations: cfg.Annotations,
+	}
+	for _, r := range cfg.Redactions {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("while compiling redaction pattern %q: %w", r.Pattern, err)
+		}
+		replacement := r.Replacement
+		if replacement == "" {
+			replacement = defaultRedactionReplacement
+		}
+		out.redactions = append(out.redactions, redaction{pattern: pattern, replacement: replacement})
+	}
+
+	return out, nil
+}
+
+// Apply returns a transformed copy of a given event.
+func (t *EventTransformer) Apply(in event.Event) event.Event {
+	if t == nil {
+		return in
+	}
+
+	out := in
+	out.Labels = filterMap(in.ObjectMeta.Labels, t.labels)
+	out.Annotations = filterMap(in.ObjectMeta.Annotations, t.annotations)
+
+	if len(t.redactions) > 0 {
+		out.Title = t.redact(in.Title)
+		out.Messages = t.redactAll(in.Messages)
+		out.Recommendations = t.redactAll(in.Recommendations)
+		out.Warnings = t.redactAll(in.Warnings)
+		out.Error = t.redact(in.Error)
+		out.Actions = t.redactActions(in.Actions)
+	}
+
+	value := reflect.ValueOf(&out).Elem()
+	for _, idx := range t.fields {
+		field := value.FieldByIndex(idx)
+		field.Set(reflect.Zero(field.Type()))
+	}
+
+	return out
+}
+
+func (t *EventTransformer) redactAll(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, 0, len(in))
+	for _, item := range in {
+		out = append(out, t.redact(item))
+	}
+	return out
+}
+
+func (t *EventTransformer) redactActions(in []event.Action) []event.Action {
+	if in == nil {
+		return nil
+	}
+	out := make([]event.Action, 0, len(in))
+	for _, action := range in {
+		action.Command = t.redact(action.Command)
+		out = append(out, action)
+	}
+	return out
+}
+
+func (t *EventTransformer) redact(in string) string {
+	for _, r := range t.redactions {
+		in = r.pattern.ReplaceAllString(in, r.replacement)
+	}
+	return in
+}
+
+// clearedEventFields returns the indices of the event fields which are not included or are excluded.
+// Field names are matched case-insensitively, so both the Go and the JSON names can be used, e.g. APIVersion or apiVersion.
+func clearedEventFields(include, exclude []string) ([][]int, error) {
+	known := serializedEventFields()
+	for _, name := range append(append([]string(nil), include...), exclude...) {
+		if _, found := known[strings.ToLower(name)]; !found {
+			return nil, fmt.Errorf("unknown event field %q", name)
+		}
+	}
+
+	included := toLowerSet(include)
+	excluded := toLowerSet(exclude)
+
+	var out [][]int
+	for name, idx := range known {
+		_, isIncluded := included[name]
+		_, isExcluded := excluded[name]
+		if (len(included) > 0 && !isIncluded) || isExcluded {
+			out = append(out, idx)
+		}
+	}
+	return out, nil
+}
+
+// serializedEventFields returns the indices of the serialized event fields, including fields of the embedded TypeMeta, by lowercase name.
+// Labels and Annotations are skipped, as they are controlled by the allowlists.
+func serializedEventFields() map[string][]int {
+	out := map[string][]int{}
+	eventType := reflect.TypeOf(event.Event{})
+	for i := 0; i < eventType.NumField(); i++ {
+		field := eventType.Field(i)
+		if field.Tag.Get("json") == "-" || field.Name == "Labels" || field.Name == "Annotations" {
+			continue
+		}
+		if !field.Anonymous {
+			out[strings.ToLower(field.Name)] = field.Index
+			continue
+		}
+		for j := 0; j < field.Type.NumField(); j++ {
+			out[strings.ToLower(field.Type.Field(j).Name)] = []int{i, j}
+		}
+	}
+	return out
+}
+
+func filterMap(in map[string]string, keys []string) map[string]string {
+	var out map[string]string
+	for _, key := range keys {
+		value, found := in[key]
+		if !found {
+			continue
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func toLowerSet(in []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(in))
+	for _, item := range in {
+		out[strings.ToLower(item)] = struct{}{}
+	}
+	return out
+}
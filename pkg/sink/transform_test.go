@@ -0,0 +1,202 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+)
+
+func TestEventTransformer_Apply(t *testing.T) {
+	// given
+	in := event.Event{
+		TypeMeta:  metaV1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		Name:      "foo",
+		Namespace: "default",
+		Title:     "ConfigMap updated",
+		Messages:  []string{"password=s3cr3t", "no secret here"},
+		Error:     "token=abc",
+		ObjectMeta: metaV1.ObjectMeta{
+			Labels:      map[string]string{"app": "foo", "team": "a"},
+			Annotations: map[string]string{"owner": "jane", "kubectl.kubernetes.io/last-applied-configuration": "{}"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      config.SinkTransform
+		expected event.Event
+	}{
+		{
+			name: "include fields",
+			cfg:  config.SinkTransform{IncludeFields: []string{"kind", "Name", "namespace"}},
+			expected: event.Event{
+				TypeMeta:   metaV1.TypeMeta{Kind: "ConfigMap"},
+				Name:       "foo",
+				Namespace:  "default",
+				ObjectMeta: in.ObjectMeta,
+			},
+		},
+		{
+			name: "exclude fields",
+			cfg:  config.SinkTransform{ExcludeFields: []string{"apiVersion", "Messages", "Title"}},
+			expected: event.Event{
+				TypeMeta:   metaV1.TypeMeta{Kind: "ConfigMap"},
+				Name:       "foo",
+				Namespace:  "default",
+				Error:      "token=abc",
+				ObjectMeta: in.ObjectMeta,
+			},
+		},
+		{
+			name: "redactions",
+			cfg: config.SinkTransform{Redactions: []config.SinkRedaction{
+				{Pattern: `(password|token)=\S+`, Replacement: "${1}=[REDACTED]"},
+			}},
+			expected: event.Event{
+				TypeMeta:   in.TypeMeta,
+				Name:       "foo",
+				Namespace:  "default",
+				Title:      "ConfigMap updated",
+				Messages:   []string{"password=[REDACTED]", "no secret here"},
+				Error:      "token=[REDACTED]",
+				ObjectMeta: in.ObjectMeta,
+			},
+		},
+		{
+			name: "labels and annotations allowlists",
+			cfg:  config.SinkTransform{Labels: []string{"app", "missing"}, Annotations: []string{"owner"}},
+			expected: func() event.Event {
+				out := in
+				out.Labels = map[string]string{"app": "foo"}
+				out.Annotations = map[string]string{"owner": "jane"}
+				return out
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			transformer, err := NewEventTransformer(tc.cfg)
+			require.NoError(t, err)
+
+			// when
+			out := transformer.Apply(in)
+
+			// then
+			assert.Equal(t, tc.expected, out)
+			assert.Equal(t, []string{"password=s3cr3t", "no secret here"}, in.Messages, "input event shouldn't be modified")
+		})
+	}
+}
+
+func TestEventTransformer_Errors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cfg    config.SinkTransform
+		expErr string
+	}{
+		{
+			name:   "unknown field",
+			cfg:    config.SinkTransform{ExcludeFields: []string{"ObjectMeta"}},
+			expErr: `unknown event field "ObjectMeta"`,
+		},
+		{
+			name:   "invalid pattern",
+			cfg:    config.SinkTransform{Redactions: []config.SinkRedaction{{Pattern: "("}}},
+			expErr: "while compiling redaction pattern \"(\": error parsing regexp: missing closing ): `(`",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			_, err := NewEventTransformer(tc.cfg)
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestEventTransformer_NilIsNoop(t *testing.T) {
+	// given
+	transformer, err := NewEventTransformer(config.SinkTransform{})
+	require.NoError(t, err)
+	in := event.Event{Name: "foo", Messages: []string{"bar"}}
+
+	// when
+	out := transformer.Apply(in)
+
+	// then
+	assert.Nil(t, transformer)
+	assert.Equal(t, in, out)
+}
+
+func TestEventTransformer_ApplyRedactsTitleAndActions(t *testing.T) {
+	// given
+	transformer, err := NewEventTransformer(config.SinkTransform{
+		Redactions: []config.SinkRedaction{{Pattern: `token=\S+`}},
+	})
+	require.NoError(t, err)
+
+	in := event.Event{
+		Title: "Secret token=abc updated",
+		Actions: []event.Action{
+			{Command: "{{BotName}} kubectl annotate secret foo token=abc", ExecutorBindings: []string{"kubectl-read-only"}},
+		},
+	}
+
+	// when
+	out := transformer.Apply(in)
+
+	// then
+	assert.Equal(t, "Secret *** updated", out.Title)
+	assert.Equal(t, []event.Action{
+		{Command: "{{BotName}} kubectl annotate secret foo ***", ExecutorBindings: []string{"kubectl-read-only"}},
+	}, out.Actions)
+	assert.Equal(t, "{{BotName}} kubectl annotate secret foo token=abc", in.Actions[0].Command, "input event is not modified")
+}
+
+func TestWebhook_SendEventAppliesTransform(t *testing.T) {
+	// given
+	var got WebhookPayload
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer ts.Close()
+
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL: ts.URL,
+		Transform: config.SinkTransform{
+			Redactions: []config.SinkRedaction{{Pattern: `password=\S+`}},
+			Labels:     []string{"app"},
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	evt := event.Event{
+		TypeMeta:   metaV1.TypeMeta{Kind: "ConfigMap"},
+		Name:       "foo",
+		Messages:   []string{"data changed: password=s3cr3t"},
+		ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "foo", "team": "a"}},
+	}
+
+	// when
+	err = wh.SendEvent(context.Background(), evt, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data changed: ***"}, got.EventStatus.Messages)
+	assert.Equal(t, map[string]string{"app": "foo"}, got.EventMeta.Labels)
+}
@@ -3,13 +3,22 @@ package sink
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"text/template"
 	"time"
 
+	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/pkg/audit"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/event"
@@ -18,15 +27,41 @@ import (
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
-const defaultHTTPCliTimeout = 30 * time.Second
+const (
+	defaultHTTPCliTimeout = 30 * time.Second
+
+	defaultWebhookMaxRetries      = 3
+	defaultWebhookInitialInterval = time.Second
+	defaultWebhookMaxInterval     = 30 * time.Second
+	defaultWebhookMaxElapsedTime  = 2 * time.Minute
+
+	// WebhookSignatureHeader is the HTTP header with the HMAC-SHA256 signature of the request body.
+	WebhookSignatureHeader = "X-Botkube-Signature"
+)
 
 // Webhook provides functionality to notify external service about new events.
 type Webhook struct {
-	log      logrus.FieldLogger
-	reporter AnalyticsReporter
+	log         logrus.FieldLogger
+	reporter    AnalyticsReporter
+	transformer *EventTransformer
 
 	URL      string
 	Bindings config.SinkBindings
+
+	headers         map[string]string
+	secret          string
+	payloadTpl      *template.Template
+	maxRetries      int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	maxElapsedTime  time.Duration
+	outbox          *Outbox
+}
+
+// WebhookTemplateData is the data passed to the Webhook payload template.
+type WebhookTemplateData struct {
+	WebhookPayload
+	Event event.Event
 }
 
 // WebhookPayload contains json payload to be sent to webhook url
@@ -41,10 +76,12 @@ type WebhookPayload struct {
 
 // EventMeta contains the metadata about the event occurred
 type EventMeta struct {
-	Kind      string `json:"kind"`
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Cluster   string `json:"cluster,omitempty"`
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Cluster     string            `json:"cluster,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // EventStatus contains the status about the event occurred
@@ -57,15 +94,55 @@ type EventStatus struct {
 }
 
 // NewWebhook creates a new Webhook instance.
-func NewWebhook(log logrus.FieldLogger, c config.Webhook, reporter AnalyticsReporter) (*Webhook, error) {
+func NewWebhook(log logrus.FieldLogger, commGroupName string, c config.Webhook, reporter AnalyticsReporter) (*Webhook, error) {
 	whNotifier := &Webhook{
-		log:      log,
-		reporter: reporter,
-		URL:      c.URL,
-		Bindings: c.Bindings,
+		log:             log,
+		reporter:        reporter,
+		URL:             c.URL,
+		Bindings:        c.Bindings,
+		headers:         c.Headers,
+		secret:          c.Secret,
+		maxRetries:      defaultWebhookMaxRetries,
+		initialInterval: c.Retry.InitialInterval,
+		maxInterval:     c.Retry.MaxInterval,
+		maxElapsedTime:  c.Retry.MaxElapsedTime,
+	}
+	if c.Retry.MaxRetries != nil {
+		whNotifier.maxRetries = *c.Retry.MaxRetries
+	}
+	if whNotifier.initialInterval <= 0 {
+		whNotifier.initialInterval = defaultWebhookInitialInterval
+	}
+	if whNotifier.maxInterval <= 0 {
+		whNotifier.maxInterval = defaultWebhookMaxInterval
+	}
+	if whNotifier.maxElapsedTime <= 0 {
+		whNotifier.maxElapsedTime = defaultWebhookMaxElapsedTime
+	}
+
+	if c.PayloadTemplate != "" {
+		tpl, err := template.New("webhook-payload").Funcs(sprig.TxtFuncMap()).Parse(c.PayloadTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("while parsing Webhook payload template: %w", err)
+		}
+		whNotifier.payloadTpl = tpl
 	}
 
-	err := reporter.ReportSinkEnabled(whNotifier.IntegrationName())
+	if c.Outbox.Enabled {
+		outbox, err := NewOutbox(log.WithField("component", "Outbox"), commGroupName, "webhook", c.Outbox, whNotifier.deliverFromOutbox)
+		if err != nil {
+			return nil, fmt.Errorf("while creating Webhook outbox: %w", err)
+		}
+		whNotifier.outbox = outbox
+	}
+
+	transformer, err := NewEventTransformer(c.Transform)
+	if err != nil {
+		return nil, fmt.Errorf("while creating event transformer: %w", err)
+	}
+	whNotifier.transformer = transformer
+
+	err = reporter.ReportSinkEnabled(whNotifier.IntegrationName())
 	if err != nil {
 		return nil, fmt.Errorf("while reporting analytics: %w", err)
 	}
@@ -73,6 +150,14 @@ func NewWebhook(log logrus.FieldLogger, c config.Webhook, reporter AnalyticsRepo
 	return whNotifier, nil
 }
 
+// Start replays the events stored in the outbox. It returns immediately if the outbox is disabled.
+func (w *Webhook) Start(ctx context.Context) error {
+	if w.outbox == nil {
+		return nil
+	}
+	return w.outbox.Start(ctx)
+}
+
 // SendEvent sends event notification to Webhook url
 func (w *Webhook) SendEvent(ctx context.Context, event event.Event, eventSources []string) (err error) {
 	if !sliceutil.Intersect(w.Bindings.Sources, eventSources) {
@@ -80,12 +165,106 @@ func (w *Webhook) SendEvent(ctx context.Context, event event.Event, eventSources
 		return nil
 	}
 
-	jsonPayload := &WebhookPayload{
+	event = w.transformer.Apply(event)
+
+	if err := w.sendOrStore(ctx, newOutboxEntry(event, eventSources, "")); err != nil {
+		return err
+	}
+
+	w.log.Debugf("Event successfully sent to Webhook: %+v", event)
+	return nil
+}
+
+// sendOrStore sends a given outbox entry. If the outbox is enabled, the entry is stored in it when the outbox is not empty
+// or when sending fails with a temporary error.
+func (w *Webhook) sendOrStore(ctx context.Context, entry OutboxEntry) error {
+	kind := entryKind(entry)
+	if w.outbox != nil {
+		// keep the entries order until the outbox is drained
+		stored, err := w.outbox.PushIfNotEmpty(entry)
+		if err != nil {
+			return fmt.Errorf("while storing %s in Webhook outbox: %w", kind, err)
+		}
+		if stored {
+			return nil
+		}
+	}
+
+	retryable, err := w.deliver(ctx, entry, w.eventRetries())
+	if err != nil {
+		err = fmt.Errorf("while sending %s to webhook: %w", kind, err)
+		if w.outbox == nil || !retryable {
+			return err
+		}
+
+		w.log.Warnf("%s. Storing %s in outbox...", err.Error(), kind)
+		if pushErr := w.outbox.Push(entry); pushErr != nil {
+			return multierror.Append(err, fmt.Errorf("while storing %s in Webhook outbox: %w", kind, pushErr))
+		}
+	}
+	return nil
+}
+
+// deliver sends a given outbox entry, which holds either an event or an audit record.
+func (w *Webhook) deliver(ctx context.Context, entry OutboxEntry, maxRetries int) (bool, error) {
+	if entry.AuditRecord != nil {
+		return w.sendAuditRecord(ctx, *entry.AuditRecord, maxRetries)
+	}
+	return w.send(ctx, entry.Event, maxRetries)
+}
+
+func entryKind(entry OutboxEntry) string {
+	if entry.AuditRecord != nil {
+		return "audit record"
+	}
+	return "event"
+}
+
+// eventRetries returns the number of retries for sending events. If the outbox is enabled, the events are not retried
+// on the send path, as the outbox replays them in the background.
+func (w *Webhook) eventRetries() int {
+	if w.outbox != nil {
+		return 0
+	}
+	return w.maxRetries
+}
+
+func (w *Webhook) deliverFromOutbox(ctx context.Context, entry OutboxEntry) error {
+	retryable, err := w.deliver(ctx, entry, 0)
+	if err != nil && !retryable {
+		kind := entryKind(entry)
+		w.log.Errorf("while sending %s from outbox to webhook: %s. Dropping %s...", kind, err.Error(), kind)
+		return nil
+	}
+	return err
+}
+
+// send sends a given event with up to a given number of retries. It returns true if a potential error is temporary,
+// so the event can be resent later.
+func (w *Webhook) send(ctx context.Context, event event.Event, maxRetries int) (bool, error) {
+	jsonPayload := newWebhookPayload(event)
+
+	if w.payloadTpl != nil {
+		return w.postTemplatedWebhook(ctx, WebhookTemplateData{WebhookPayload: *jsonPayload, Event: event}, maxRetries)
+	}
+
+	message, err := json.Marshal(jsonPayload)
+	if err != nil {
+		return false, err
+	}
+	return w.postWithRetries(ctx, message, nil, maxRetries)
+}
+
+// newWebhookPayload returns the structured event representation used by the HTTP-based sinks.
+func newWebhookPayload(event event.Event) *WebhookPayload {
+	return &WebhookPayload{
 		EventMeta: EventMeta{
-			Kind:      event.Kind,
-			Name:      event.Name,
-			Namespace: event.Namespace,
-			Cluster:   event.Cluster,
+			Kind:        event.Kind,
+			Name:        event.Name,
+			Namespace:   event.Namespace,
+			Cluster:     event.Cluster,
+			Labels:      event.Labels,
+			Annotations: event.Annotations,
 		},
 		EventStatus: EventStatus{
 			Type:     event.Type,
@@ -99,14 +278,22 @@ func (w *Webhook) SendEvent(ctx context.Context, event event.Event, eventSources
 		Recommendations: event.Recommendations,
 		Warnings:        event.Warnings,
 	}
+}
+
+// SendAuditRecord sends the command execution audit record to Webhook url.
+// If the outbox is enabled, the audit records are stored in it the same way as events.
+func (w *Webhook) SendAuditRecord(ctx context.Context, record audit.Record) error {
+	entry := newOutboxEntry(event.Event{}, nil, "")
+	entry.AuditRecord = &record
+	return w.sendOrStore(ctx, entry)
+}
 
-	err = w.PostWebhook(ctx, jsonPayload)
+func (w *Webhook) sendAuditRecord(ctx context.Context, record audit.Record, maxRetries int) (bool, error) {
+	body, err := marshalAuditPayload(record)
 	if err != nil {
-		return fmt.Errorf("while sending event to webhook: %w", err)
+		return false, err
 	}
-
-	w.log.Debugf("Event successfully sent to Webhook: %+v", event)
-	return nil
+	return w.postWithRetries(ctx, body, map[string]string{RecordTypeHeader: AuditRecordType}, maxRetries)
 }
 
 // SendMessageToAll is no-op.
@@ -120,22 +307,76 @@ func (w *Webhook) SendMessage(_ context.Context, _ interactive.CoreMessage, _ []
 }
 
 // PostWebhook posts webhook to listener
-func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload) (err error) {
+func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload) error {
 	message, err := json.Marshal(jsonPayload)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewBuffer(message))
+	_, err = w.postWithRetries(ctx, message, nil, w.maxRetries)
+	return err
+}
+
+func (w *Webhook) postTemplatedWebhook(ctx context.Context, data WebhookTemplateData, maxRetries int) (bool, error) {
+	var buff bytes.Buffer
+	if err := w.payloadTpl.Execute(&buff, data); err != nil {
+		return false, fmt.Errorf("while rendering payload template: %w", err)
+	}
+
+	return w.postWithRetries(ctx, buff.Bytes(), nil, maxRetries)
+}
+
+// postWithRetries posts a given body and retries with exponential backoff on network errors, 429 and 5xx responses.
+// Retries stop once the next attempt would exceed the maximum elapsed time. It returns true if the last error is temporary.
+func (w *Webhook) postWithRetries(ctx context.Context, body []byte, extraHeaders map[string]string, maxRetries int) (bool, error) {
+	deadline := time.Now().Add(w.maxElapsedTime)
+	interval := w.initialInterval
+	for attempt := 0; ; attempt++ {
+		statusCode, err := w.post(ctx, body, extraHeaders)
+		if err == nil {
+			return false, nil
+		}
+
+		retryable := isWebhookRetryable(statusCode, err)
+		if attempt >= maxRetries || !retryable || time.Now().Add(interval).After(deadline) {
+			return retryable, err
+		}
+
+		w.log.Debugf("Retrying Webhook request in %s (retry %d/%d) after error: %s", interval, attempt+1, maxRetries, err.Error())
+		select {
+		case <-ctx.Done():
+			return true, err
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > w.maxInterval {
+			interval = w.maxInterval
+		}
+	}
+}
+
+// post posts a given body. It returns the response status code, which is zero if the request wasn't sent.
+func (w *Webhook) post(ctx context.Context, body []byte, extraHeaders map[string]string) (statusCode int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return 0, err
 	}
 	req.Header.Add("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range extraHeaders {
+		req.Header.Set(key, value)
+	}
+	if w.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, signWebhookBody(w.secret, body))
+	}
 
 	client := &http.Client{Timeout: defaultHTTPCliTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		deferredErr := resp.Body.Close()
@@ -144,11 +385,35 @@ func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload)
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(resp.StatusCode))
+	// any 2xx status code means that the request was accepted
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("Error Posting Webhook: %s", fmt.Sprint(resp.StatusCode))
 	}
 
-	return nil
+	return resp.StatusCode, nil
+}
+
+// signWebhookBody returns the hex-encoded HMAC-SHA256 signature of a given body, prefixed with the algorithm name.
+func signWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body) // it never returns an error
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isWebhookRetryable returns true for 429 and 5xx responses, and for network errors and timeouts.
+// Other errors, such as an invalid URL or a canceled request, are not retried.
+func isWebhookRetryable(statusCode int, err error) bool {
+	if statusCode != 0 {
+		return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+	}
+
+	// url.Error implements net.Error itself, so the underlying error is checked
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
 }
 
 // IntegrationName describes the notifier integration name.
@@ -2,12 +2,28 @@ package sink
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/botkube/internal/loggerx"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/event"
+	"github.com/kubeshop/botkube/pkg/format"
 )
 
 // Unit test PostWebhook
@@ -44,3 +60,185 @@ func TestPostWebhook(t *testing.T) {
 		})
 	}
 }
+
+func TestWebhook_SendEventRetries(t *testing.T) {
+	tests := map[string]struct {
+		statusCodes      []int
+		expectedAttempts int
+		expectedErr      string
+	}{
+		"Retries on 5xx until success": {
+			statusCodes:      []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusAccepted},
+			expectedAttempts: 3,
+		},
+		"Retries on 429": {
+			statusCodes:      []int{http.StatusTooManyRequests, http.StatusNoContent},
+			expectedAttempts: 2,
+		},
+		"Does not retry on 4xx": {
+			statusCodes:      []int{http.StatusBadRequest},
+			expectedAttempts: 1,
+			expectedErr:      "while sending event to webhook: Error Posting Webhook: 400",
+		},
+		"Gives up after max retries": {
+			statusCodes:      []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedAttempts: 3,
+			expectedErr:      "while sending event to webhook: Error Posting Webhook: 500",
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// given
+			var attempts int32
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				idx := atomic.AddInt32(&attempts, 1) - 1
+				w.WriteHeader(test.statusCodes[idx])
+			}))
+			defer ts.Close()
+
+			maxRetries := 2
+			wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+				URL: ts.URL,
+				Retry: config.WebhookRetry{
+					MaxRetries:      &maxRetries,
+					InitialInterval: time.Millisecond,
+				},
+				Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+			}, &fakeAnalyticsReporter{})
+			require.NoError(t, err)
+
+			// when
+			err = wh.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+
+			// then
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.EqualValues(t, test.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestWebhook_SendEventWithHeadersSignatureAndTemplate(t *testing.T) {
+	// given
+	var (
+		gotHeaders http.Header
+		gotBody    []byte
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL:             ts.URL,
+		Headers:         map[string]string{"Authorization": "Bearer token"},
+		Secret:          "my-secret",
+		PayloadTemplate: `{"summary": {{ .EventSummary | toJson }}, "reason": "{{ .Event.Reason }}", "severity": "{{ .EventStatus.Level }}"}`,
+		Bindings:        config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	evt := event.Event{
+		TypeMeta: metaV1.TypeMeta{Kind: "Pod"},
+		Name:     "foo",
+		Reason:   "BackOff",
+		Level:    config.Error,
+		Type:     config.ErrorEvent,
+	}
+
+	// when
+	err = wh.SendEvent(context.Background(), evt, []string{"k8s-events"})
+
+	// then
+	require.NoError(t, err)
+	expBody := fmt.Sprintf(`{"summary": %q, "reason": "BackOff", "severity": "error"}`, format.ShortMessage(evt))
+	assert.Equal(t, expBody, string(gotBody))
+	assert.Equal(t, "Bearer token", gotHeaders.Get("Authorization"))
+
+	mac := hmac.New(sha256.New, []byte("my-secret"))
+	mac.Write(gotBody)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), gotHeaders.Get(WebhookSignatureHeader))
+}
+
+type fakeAnalyticsReporter struct{}
+
+func (f *fakeAnalyticsReporter) ReportSinkEnabled(config.CommPlatformIntegration) error {
+	return nil
+}
+
+func TestIsWebhookRetryable(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		err        error
+		expected   bool
+	}{
+		"5xx response": {
+			statusCode: http.StatusBadGateway,
+			err:        errors.New("Error Posting Webhook: 502"),
+			expected:   true,
+		},
+		"4xx response": {
+			statusCode: http.StatusUnauthorized,
+			err:        errors.New("Error Posting Webhook: 401"),
+			expected:   false,
+		},
+		"Connection error": {
+			err:      &url.Error{Op: "Post", URL: "http://localhost", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			expected: true,
+		},
+		"Invalid URL": {
+			err:      &url.Error{Op: "parse", URL: "::", Err: errors.New("missing protocol scheme")},
+			expected: false,
+		},
+		"Canceled request": {
+			err:      &url.Error{Op: "Post", URL: "http://localhost", Err: context.Canceled},
+			expected: false,
+		},
+	}
+	for name, test := range tests {
+		name, test := name, test
+		t.Run(name, func(t *testing.T) {
+			// when
+			retryable := isWebhookRetryable(test.statusCode, test.err)
+
+			// then
+			assert.Equal(t, test.expected, retryable)
+		})
+	}
+}
+
+func TestWebhook_SendEventStopsRetriesAfterMaxElapsedTime(t *testing.T) {
+	// given
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	maxRetries := 10
+	wh, err := NewWebhook(loggerx.NewNoop(), "test-group", config.Webhook{
+		URL: ts.URL,
+		Retry: config.WebhookRetry{
+			MaxRetries:      &maxRetries,
+			InitialInterval: 20 * time.Millisecond,
+			MaxElapsedTime:  50 * time.Millisecond,
+		},
+		Bindings: config.SinkBindings{Sources: []string{"k8s-events"}},
+	}, &fakeAnalyticsReporter{})
+	require.NoError(t, err)
+
+	// when
+	err = wh.SendEvent(context.Background(), event.Event{Name: "foo"}, []string{"k8s-events"})
+
+	// then
+	assert.EqualError(t, err, "while sending event to webhook: Error Posting Webhook: 503")
+	// attempts after 0ms and 20ms, the next one would be after 60ms
+	assert.EqualValues(t, 2, atomic.LoadInt32(&attempts))
+}